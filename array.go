@@ -27,25 +27,71 @@ func makeArrayCodec(st map[string]*Codec, enclosingNamespace string, schemaMap m
 		return nil, fmt.Errorf("Array items ought to be valid Avro type: %s", err)
 	}
 
-	return &Codec{
-		generator: NewArrayCodecGenerator(itemCodec),
-		typeName:  &name{"array", nullNamespace},
-		nativeFromBinary: func(buf []byte) (interface{}, []byte, error) {
-			var value interface{}
-			var err error
-
-			// block count and block size
+	// arrayNativeFromBinary decodes a binary array, recycling the backing array
+	// and items of the provided slice when it is not nil.
+	arrayNativeFromBinary := func(buf []byte, recycled []interface{}) (interface{}, []byte, error) {
+		var value interface{}
+		var err error
+
+		// block count and block size
+		if value, buf, err = longNativeFromBinary(buf); err != nil {
+			return nil, nil, fmt.Errorf("cannot decode binary array block count: %s", err)
+		}
+		blockCount := value.(int64)
+		if blockCount < 0 {
+			// NOTE: A negative block count implies there is a long encoded
+			// block size following the negative block count. We have no use
+			// for the block size in this decoder, so we read and discard
+			// the value.
+			if blockCount == math.MinInt64 {
+				// The minimum number for any signed numerical type can never be made positive
+				return nil, nil, fmt.Errorf("cannot decode binary array with block count: %d", blockCount)
+			}
+			blockCount = -blockCount // convert to its positive equivalent
+			if _, buf, err = longNativeFromBinary(buf); err != nil {
+				return nil, nil, fmt.Errorf("cannot decode binary array block size: %s", err)
+			}
+		}
+		// Ensure block count does not exceed some sane value.
+		if blockCount > MaxBlockCount {
+			return nil, nil, fmt.Errorf("cannot decode binary array when block count exceeds MaxBlockCount: %d > %d", blockCount, MaxBlockCount)
+		}
+		// NOTE: While the attempt of a RAM optimization shown below is not
+		// necessary, many encoders will encode all items in a single block.
+		// We can optimize amount of RAM allocated by runtime for the array
+		// by initializing the array for that number of items.
+		var arrayValues []interface{}
+		if recycled == nil {
+			arrayValues = make([]interface{}, 0, blockCount)
+		} else {
+			arrayValues = recycled[:0]
+		}
+
+		for blockCount != 0 {
+			// Decode `blockCount` datum values from buffer
+			for i := int64(0); i < blockCount; i++ {
+				var recycledItem interface{}
+				if n := len(arrayValues); n < len(recycled) {
+					recycledItem = recycled[n]
+				}
+				if value, buf, err = itemCodec.nativeFromBinaryReusing(buf, recycledItem); err != nil {
+					return nil, nil, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				}
+				arrayValues = append(arrayValues, value)
+			}
+			// Decode next blockCount from buffer, because there may be more blocks
 			if value, buf, err = longNativeFromBinary(buf); err != nil {
 				return nil, nil, fmt.Errorf("cannot decode binary array block count: %s", err)
 			}
-			blockCount := value.(int64)
+			blockCount = value.(int64)
 			if blockCount < 0 {
-				// NOTE: A negative block count implies there is a long encoded
-				// block size following the negative block count. We have no use
-				// for the block size in this decoder, so we read and discard
-				// the value.
+				// NOTE: A negative block count implies there is a long
+				// encoded block size following the negative block count. We
+				// have no use for the block size in this decoder, so we
+				// read and discard the value.
 				if blockCount == math.MinInt64 {
-					// The minimum number for any signed numerical type can never be made positive
+					// The minimum number for any signed numerical type can
+					// never be made positive
 					return nil, nil, fmt.Errorf("cannot decode binary array with block count: %d", blockCount)
 				}
 				blockCount = -blockCount // convert to its positive equivalent
@@ -57,46 +103,20 @@ func makeArrayCodec(st map[string]*Codec, enclosingNamespace string, schemaMap m
 			if blockCount > MaxBlockCount {
 				return nil, nil, fmt.Errorf("cannot decode binary array when block count exceeds MaxBlockCount: %d > %d", blockCount, MaxBlockCount)
 			}
-			// NOTE: While the attempt of a RAM optimization shown below is not
-			// necessary, many encoders will encode all items in a single block.
-			// We can optimize amount of RAM allocated by runtime for the array
-			// by initializing the array for that number of items.
-			arrayValues := make([]interface{}, 0, blockCount)
-
-			for blockCount != 0 {
-				// Decode `blockCount` datum values from buffer
-				for i := int64(0); i < blockCount; i++ {
-					if value, buf, err = itemCodec.nativeFromBinary(buf); err != nil {
-						return nil, nil, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
-					}
-					arrayValues = append(arrayValues, value)
-				}
-				// Decode next blockCount from buffer, because there may be more blocks
-				if value, buf, err = longNativeFromBinary(buf); err != nil {
-					return nil, nil, fmt.Errorf("cannot decode binary array block count: %s", err)
-				}
-				blockCount = value.(int64)
-				if blockCount < 0 {
-					// NOTE: A negative block count implies there is a long
-					// encoded block size following the negative block count. We
-					// have no use for the block size in this decoder, so we
-					// read and discard the value.
-					if blockCount == math.MinInt64 {
-						// The minimum number for any signed numerical type can
-						// never be made positive
-						return nil, nil, fmt.Errorf("cannot decode binary array with block count: %d", blockCount)
-					}
-					blockCount = -blockCount // convert to its positive equivalent
-					if _, buf, err = longNativeFromBinary(buf); err != nil {
-						return nil, nil, fmt.Errorf("cannot decode binary array block size: %s", err)
-					}
-				}
-				// Ensure block count does not exceed some sane value.
-				if blockCount > MaxBlockCount {
-					return nil, nil, fmt.Errorf("cannot decode binary array when block count exceeds MaxBlockCount: %d > %d", blockCount, MaxBlockCount)
-				}
-			}
-			return arrayValues, buf, nil
+		}
+		return arrayValues, buf, nil
+	}
+
+	return &Codec{
+		generator:     NewArrayCodecGenerator(itemCodec),
+		elementCodecs: []*Codec{itemCodec},
+		typeName:      &name{"array", nullNamespace},
+		nativeFromBinary: func(buf []byte) (interface{}, []byte, error) {
+			return arrayNativeFromBinary(buf, nil)
+		},
+		nativeFromBinaryReuse: func(buf []byte, datum interface{}) (interface{}, []byte, error) {
+			recycled, _ := datum.([]interface{})
+			return arrayNativeFromBinary(buf, recycled)
 		},
 		binaryFromNative: func(buf []byte, datum interface{}) ([]byte, error) {
 			arrayValues, err := convertArray(datum)
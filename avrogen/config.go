@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// config describes what avrogen generates. It is read from the JSON file given
+// by -config, such as:
+//
+//     {
+//         "package": "models",
+//         "import": "example.com/models",
+//         "output": "models",
+//         "inputs": ["schemas"],
+//         "namespaces": {"com.example.billing": "example.com/models/billing"},
+//         "types": {"com.example.user": "Customer"}
+//     }
+//
+// Relative output and input paths are relative to the directory of the file,
+// which is also the output directory when none is specified.
+type config struct {
+	// Package is the name of the package written to the output directory.
+	Package string `json:"package"`
+
+	// Import is the import path of the package written to the output
+	// directory.
+	Import string `json:"import"`
+
+	// Output is the directory in which to write the generated files.
+	Output string `json:"output"`
+
+	// Inputs are the schema files, directories searched recursively for
+	// schema files, and glob patterns matching either, to generate code for.
+	Inputs []string `json:"inputs"`
+
+	// Namespaces maps Avro namespaces to the import paths of the packages to
+	// which their types are written.
+	Namespaces map[string]string `json:"namespaces"`
+
+	// Types maps the full names of Avro named types to the names of the Go
+	// types generated for them.
+	Types map[string]string `json:"types"`
+}
+
+// loadConfig reads the JSON configuration file at pathname.
+func loadConfig(pathname string) (config, error) {
+	var cfg config
+	data, err := ioutil.ReadFile(pathname)
+	if err != nil {
+		return cfg, fmt.Errorf("cannot read config: %s", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&cfg)
+	if err != nil {
+		return cfg, fmt.Errorf("cannot parse config %q: %s", pathname, err)
+	}
+
+	dir := filepath.Dir(pathname)
+	if cfg.Output == "" {
+		cfg.Output = dir
+	} else if !filepath.IsAbs(cfg.Output) {
+		cfg.Output = filepath.Join(dir, cfg.Output)
+	}
+	for i, input := range cfg.Inputs {
+		if !filepath.IsAbs(input) {
+			cfg.Inputs[i] = filepath.Join(dir, input)
+		}
+	}
+	if cfg.Package == "" {
+		cfg.Package = "records"
+	}
+	return cfg, nil
+}
+
+// expandInputs returns the schema files named by inputs, sorted and without
+// duplicates, so that the output does not depend on their order. Each input is
+// either a file, a directory that is searched recursively for files with the
+// ".avsc" extension, or a glob pattern matching any of those.
+func expandInputs(inputs []string) ([]string, error) {
+	seen := make(map[string]struct{})
+	var files []string
+	add := func(pathname string) {
+		pathname = filepath.Clean(pathname)
+		if _, ok := seen[pathname]; !ok {
+			seen[pathname] = struct{}{}
+			files = append(files, pathname)
+		}
+	}
+
+	for _, input := range inputs {
+		matches := []string{input}
+		if strings.ContainsAny(input, "*?[") {
+			var err error
+			if matches, err = filepath.Glob(input); err != nil {
+				return nil, fmt.Errorf("cannot expand input %q: %s", input, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("cannot expand input %q: no files match", input)
+			}
+		}
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, fmt.Errorf("cannot expand input %q: %s", input, err)
+			}
+			if !info.IsDir() {
+				add(match)
+				continue
+			}
+			err = filepath.Walk(match, func(pathname string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if !info.IsDir() && filepath.Ext(pathname) == ".avsc" {
+					add(pathname)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("cannot expand input %q: %s", input, err)
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes each file, creating its directory, within a new temporary
+// directory that it returns.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "avrogen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		pathname := filepath.Join(dir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(pathname), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(pathname, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func ensureError(t *testing.T, err error, substrings ...string) {
+	t.Helper()
+	if len(substrings) == 0 {
+		if err != nil {
+			t.Fatalf("GOT: %v; WANT: %v", err, nil)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("GOT: %v; WANT: %v", err, substrings)
+	}
+	for _, substring := range substrings {
+		if !strings.Contains(err.Error(), substring) {
+			t.Errorf("GOT: %v; WANT: %q", err, substring)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"avrogen.json": `{"package": "models", "import": "example.com/models", "output": "out", "inputs": ["schemas"], "namespaces": {"com.example.billing": "example.com/models/billing"}, "types": {"com.example.user": "Customer"}}`,
+		"minimal.json": `{"import": "example.com/models", "inputs": ["/abs/schemas"]}`,
+		"unknown.json": `{"package": "models", "packages": "other"}`,
+		"invalid.json": `package: models`,
+	})
+	defer os.RemoveAll(dir)
+
+	cfg, err := loadConfig(filepath.Join(dir, "avrogen.json"))
+	ensureError(t, err)
+	expected := config{
+		Package:    "models",
+		Import:     "example.com/models",
+		Output:     filepath.Join(dir, "out"),
+		Inputs:     []string{filepath.Join(dir, "schemas")},
+		Namespaces: map[string]string{"com.example.billing": "example.com/models/billing"},
+		Types:      map[string]string{"com.example.user": "Customer"},
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", cfg, expected)
+	}
+
+	// The output directory defaults to the directory of the file.
+	cfg, err = loadConfig(filepath.Join(dir, "minimal.json"))
+	ensureError(t, err)
+	expected = config{
+		Package: "records",
+		Import:  "example.com/models",
+		Output:  dir,
+		Inputs:  []string{"/abs/schemas"},
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", cfg, expected)
+	}
+
+	_, err = loadConfig(filepath.Join(dir, "unknown.json"))
+	ensureError(t, err, "cannot parse config", "packages")
+	_, err = loadConfig(filepath.Join(dir, "invalid.json"))
+	ensureError(t, err, "cannot parse config")
+	_, err = loadConfig(filepath.Join(dir, "missing.json"))
+	ensureError(t, err, "cannot read config")
+}
+
+func TestExpandInputs(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"schemas/b.avsc":        "{}",
+		"schemas/a.avsc":        "{}",
+		"schemas/nested/c.avsc": "{}",
+		"schemas/README.md":     "",
+		"other/d.avsc":          "{}",
+		"other/e.json":          "{}",
+	})
+	defer os.RemoveAll(dir)
+
+	files, err := expandInputs([]string{
+		filepath.Join(dir, "other", "e.json"),
+		filepath.Join(dir, "schemas"),
+		filepath.Join(dir, "*", "*.avsc"),
+	})
+	ensureError(t, err)
+	expected := []string{
+		filepath.Join(dir, "other", "d.avsc"),
+		filepath.Join(dir, "other", "e.json"),
+		filepath.Join(dir, "schemas", "a.avsc"),
+		filepath.Join(dir, "schemas", "b.avsc"),
+		filepath.Join(dir, "schemas", "nested", "c.avsc"),
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("GOT: %v; WANT: %v", files, expected)
+	}
+
+	_, err = expandInputs([]string{filepath.Join(dir, "*.avdl")})
+	ensureError(t, err, "cannot expand input", "no files match")
+	_, err = expandInputs([]string{filepath.Join(dir, "missing.avsc")})
+	ensureError(t, err, "cannot expand input", "missing.avsc")
+}
@@ -0,0 +1,138 @@
+// Command avrogen generates Go types that decode and encode the binary Avro
+// data of the records, enums and fixed types defined by Avro schema files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/peak6/goavro/v2"
+)
+
+// mappingFlag collects the key=value mappings of a repeated flag.
+type mappingFlag struct {
+	m    map[string]string
+	what string // describes the mapping in errors, e.g. "namespace=importpath"
+}
+
+func (f mappingFlag) String() string {
+	pairs := make([]string, 0, len(f.m))
+	for key, value := range f.m {
+		pairs = append(pairs, key+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f mappingFlag) Set(value string) error {
+	i := strings.IndexByte(value, '=')
+	if i <= 0 || i == len(value)-1 {
+		return fmt.Errorf("mapping ought to be %s: %q", f.what, value)
+	}
+	f.m[value[:i]] = value[i+1:]
+	return nil
+}
+
+func usage() {
+	executable, err := os.Executable()
+	if err != nil {
+		executable = os.Args[0]
+	}
+	base := filepath.Base(executable)
+	fmt.Fprintf(os.Stderr, "Usage of %s:\n", base)
+	fmt.Fprintf(os.Stderr, "\t%s [flags] [schema.avsc | directory | glob ...]\n", base)
+	fmt.Fprintf(os.Stderr, "\tDirectories are searched recursively for .avsc files.\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	var configFile string
+	var check, verbose bool
+	flags := config{
+		Namespaces: make(map[string]string),
+		Types:      make(map[string]string),
+	}
+	flag.Usage = usage
+	flag.StringVar(&configFile, "config", "", "The JSON configuration file, whose settings the other flags override")
+	flag.StringVar(&flags.Output, "o", ".", "The directory in which to write the generated files")
+	flag.StringVar(&flags.Package, "p", "records", "The name of the package for generated files")
+	flag.StringVar(&flags.Import, "import", "", "The import path of the package in the output directory, required by -ns")
+	flag.Var(mappingFlag{flags.Namespaces, "namespace=importpath"}, "ns", "Map an Avro namespace to the import path of its package, as namespace=importpath; may be repeated")
+	flag.Var(mappingFlag{flags.Types, "fullname=GoName"}, "type", "Name the Go type generated for an Avro named type, as fullname=GoName; may be repeated")
+	flag.BoolVar(&check, "check", false, "Report generated files that are out of date, rather than writing them, and exit with status 1 when there are any")
+	flag.BoolVar(&verbose, "v", false, "Enable verbose logging")
+	flag.Parse()
+
+	cfg := config{Package: flags.Package, Output: flags.Output}
+	if configFile != "" {
+		var err error
+		if cfg, err = loadConfig(configFile); err != nil {
+			bail(err)
+		}
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "o":
+			cfg.Output = flags.Output
+		case "p":
+			cfg.Package = flags.Package
+		case "import":
+			cfg.Import = flags.Import
+		}
+	})
+	cfg.Namespaces = merge(cfg.Namespaces, flags.Namespaces)
+	cfg.Types = merge(cfg.Types, flags.Types)
+	cfg.Inputs = append(cfg.Inputs, flag.Args()...)
+
+	files, err := expandInputs(cfg.Inputs)
+	if err != nil {
+		bail(err)
+	}
+	if len(files) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	var log io.Writer
+	if verbose {
+		log = os.Stderr
+	}
+
+	generateConfig := goavro.GenerateConfig{
+		PackageName: cfg.Package,
+		ImportPath:  cfg.Import,
+		Namespaces:  cfg.Namespaces,
+		TypeNames:   cfg.Types,
+	}
+	if check {
+		if err = goavro.CheckGenerated(generateConfig, files, cfg.Output, log); err != nil {
+			bail(err)
+		}
+		return
+	}
+	if err = goavro.Generate(generateConfig, files, cfg.Output, log); err != nil {
+		bail(fmt.Errorf("failed to generate, reason: %v", err))
+	}
+}
+
+// merge returns the mappings of both a and b, preferring those of b.
+func merge(a, b map[string]string) map[string]string {
+	if len(b) == 0 {
+		return a
+	}
+	if a == nil {
+		a = make(map[string]string, len(b))
+	}
+	for key, value := range b {
+		a[key] = value
+	}
+	return a
+}
+
+func bail(err error) {
+	fmt.Fprintf(os.Stderr, "%s\n", err)
+	os.Exit(1)
+}
@@ -10,6 +10,7 @@
 package goavro
 
 import (
+	"bytes"
 	"io/ioutil"
 	"testing"
 )
@@ -85,3 +86,26 @@ func BenchmarkNativeFromTextualUsingV2(b *testing.B) {
 		_ = nativeFromTextUsingV2(b, codec, textData)
 	}
 }
+
+func BenchmarkOCFReaderReadIntoUsingV2(b *testing.B) {
+	avroBlob, err := ioutil.ReadFile("fixtures/quickstop-null.avro")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ocf, err := NewOCFReader(bytes.NewReader(avroBlob))
+		if err != nil {
+			b.Fatal(err)
+		}
+		var datum map[string]interface{}
+		for ocf.Scan() {
+			if err = ocf.ReadInto(&datum); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if err = ocf.Err(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
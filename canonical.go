@@ -10,6 +10,7 @@
 package goavro
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
@@ -20,6 +21,19 @@ import (
 // Parsing Canonical Form according to the Avro specification.
 type pcfProcessor func(s interface{}) (string, error)
 
+// CanonicalForm returns the Parsing Canonical Form of the schema, which is the
+// same as the CanonicalSchema of a Codec for the schema, without building a
+// Codec. Like the other attributes that do not change how data is encoded, the
+// logicalType attribute is stripped, so that {"type":"int","logicalType":"date"}
+// becomes "int". Unlike NewCodec, CanonicalForm does not validate the schema.
+func CanonicalForm(schemaSpecification string) (string, error) {
+	var schema interface{}
+	if err := json.Unmarshal([]byte(schemaSpecification), &schema); err != nil {
+		return "", fmt.Errorf("cannot unmarshal schema JSON: %s", err)
+	}
+	return parsingCanonicalForm(schema)
+}
+
 // parsingCanonialForm returns the "Parsing Canonical Form" (pcf) for a parsed
 // JSON structure of a valid Avro schema, or an error describing the schema
 // error.
@@ -78,20 +92,29 @@ func pcfObject(jsonMap map[string]interface{}) (string, error) {
 		}
 	}
 
-	for k, v := range jsonMap {
-
-		// Reduce primitive schemas to their simple form.
-		if len(jsonMap) == 1 && k == "type" {
-			if t, ok := v.(string); ok {
-				return "\"" + t + "\"", nil
-			}
+	// Reduce primitive schemas to their simple form, dropping the attributes,
+	// such as logicalType, that do not change how the data is encoded. Named
+	// types referred to by name are reduced the same way.
+	if t, ok := jsonMap["type"].(string); ok {
+		switch t {
+		case "array", "enum", "error", "fixed", "map", "record":
+		default:
+			return pcfString(t)
 		}
+	}
+
+	for k, v := range jsonMap {
 
 		// Only keep relevant attributes (strip 'doc', 'alias', 'namespace')
 		if _, ok := fieldOrder[k]; !ok {
 			continue
 		}
 
+		// Errors, which are declared by protocols, are records.
+		if k == "type" && v == "error" {
+			v = "record"
+		}
+
 		// Add namespace to a non-qualified name.
 		if k == "name" && namespace != "" {
 			// Check if the name isn't already qualified.
@@ -117,7 +140,12 @@ func pcfObject(jsonMap map[string]interface{}) (string, error) {
 		if err != nil {
 			return "", err
 		}
-		pv, err := parsingCanonicalForm(v)
+		var pv string
+		if k == "fields" {
+			pv, err = pcfFields(v)
+		} else {
+			pv, err = parsingCanonicalForm(v)
+		}
 		if err != nil {
 			return "", err
 		}
@@ -130,6 +158,32 @@ func pcfObject(jsonMap map[string]interface{}) (string, error) {
 	return "{" + strings.Join(pairs.Bs(), ",") + "}", nil
 }
 
+// pcfFields returns the parsing canonical form for the fields of a record, of
+// which only the name and the type are kept.
+func pcfFields(val interface{}) (string, error) {
+	fields, ok := val.([]interface{})
+	if !ok {
+		return "", fmt.Errorf("cannot parse schema with invalid fields; ought to be []interface{}; received: %T: %v", val, val)
+	}
+	items := make([]string, len(fields))
+	for i, field := range fields {
+		fieldMap, ok := field.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("cannot parse schema with invalid field; ought to be map[string]interface{}; received: %T: %v", field, field)
+		}
+		name, err := parsingCanonicalForm(fieldMap["name"])
+		if err != nil {
+			return "", err
+		}
+		typ, err := parsingCanonicalForm(fieldMap["type"])
+		if err != nil {
+			return "", err
+		}
+		items[i] = `{"name":` + name + `,"type":` + typ + `}`
+	}
+	return "[" + strings.Join(items, ",") + "]", nil
+}
+
 // stringPair represents a pair of string values.
 type stringPair struct {
 	A string
@@ -175,3 +229,82 @@ func (s byAvroFieldOrder) Swap(i, j int) {
 func (s byAvroFieldOrder) Less(i, j int) bool {
 	return fieldOrder[s[i].A] < fieldOrder[s[j].A]
 }
+
+// qualifiedCanonicalForm returns the parsing canonical form of a parsed JSON
+// schema after fully qualifying every name and named type reference with the
+// namespace it inherits from its enclosing named types, so that equivalent
+// schemas that spell their namespaces differently have the same canonical form.
+func qualifiedCanonicalForm(schema interface{}) (string, error) {
+	return parsingCanonicalForm(qualifySchemaNames(nullNamespace, schema))
+}
+
+// qualifySchemaNames returns a copy of schema with every name and named type
+// reference fully qualified, and all namespace attributes removed.
+func qualifySchemaNames(enclosingNamespace string, schema interface{}) interface{} {
+	switch v := schema.(type) {
+	case string:
+		return qualifySchemaReference(enclosingNamespace, v)
+	case []interface{}:
+		members := make([]interface{}, len(v))
+		for i, member := range v {
+			members[i] = qualifySchemaNames(enclosingNamespace, member)
+		}
+		return members
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			result[key] = value
+		}
+		switch t := v["type"]; t {
+		case "record", "error", "enum", "fixed":
+			n, err := newNameFromSchemaMap(enclosingNamespace, v)
+			if err != nil {
+				return v // invalid schemas are reported when building their codecs
+			}
+			result["name"] = n.fullName
+			delete(result, "namespace")
+			if fields, ok := v["fields"].([]interface{}); ok {
+				resultFields := make([]interface{}, len(fields))
+				for i, field := range fields {
+					fieldMap, ok := field.(map[string]interface{})
+					if !ok {
+						resultFields[i] = field
+						continue
+					}
+					resultField := make(map[string]interface{}, len(fieldMap))
+					for key, value := range fieldMap {
+						resultField[key] = value
+					}
+					resultField["type"] = qualifySchemaNames(n.namespace, fieldMap["type"])
+					resultFields[i] = resultField
+				}
+				result["fields"] = resultFields
+			}
+		case "array":
+			result["items"] = qualifySchemaNames(enclosingNamespace, v["items"])
+		case "map":
+			result["values"] = qualifySchemaNames(enclosingNamespace, v["values"])
+		default:
+			// NOTE: The codec builder names the decimal logical type of bytes
+			// "bytes.decimal", which is not a name of the schema.
+			delete(result, "name")
+			result["type"] = qualifySchemaNames(enclosingNamespace, t)
+		}
+		return result
+	default:
+		return schema
+	}
+}
+
+// qualifySchemaReference returns the full name of a named type reference, or
+// the name of a primitive type unchanged.
+func qualifySchemaReference(enclosingNamespace, typeName string) string {
+	switch typeName {
+	case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+		return typeName
+	}
+	if enclosingNamespace == nullNamespace || strings.ContainsRune(typeName, '.') {
+		return typeName
+	}
+	return enclosingNamespace + "." + typeName
+}
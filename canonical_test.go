@@ -253,5 +253,48 @@ func TestCanonicalSchema(t *testing.T) {
 				t.Errorf("Test failed for schema: %s\n\tgot canonical:\t\t%s\n\texpected canonical:\t%s", c.Schema, got, want)
 			}
 		}
+		got, err := CanonicalForm(c.Schema)
+		if err != nil {
+			t.Errorf("Unable to compute canonical form of schema: %s\nwith error: %s", c.Schema, err)
+		} else if want := c.Canonical; got != want {
+			t.Errorf("Test failed for schema: %s\n\tgot canonical form:\t%s\n\texpected canonical:\t%s", c.Schema, got, want)
+		}
+	}
+}
+
+func TestCanonicalFormInvalidSchema(t *testing.T) {
+	_, err := CanonicalForm(`{"type":`)
+	ensureError(t, err, "cannot unmarshal schema JSON")
+
+	_, err = CanonicalForm(`true`)
+	ensureError(t, err, "cannot parse schema")
+}
+
+func TestCanonicalFormLogicalTypes(t *testing.T) {
+	cases := []struct {
+		Schema    string
+		Canonical string
+	}{
+		{`{"type":"bytes","logicalType":"decimal","precision":4,"scale":2}`, `"bytes"`},
+		{`{"type":"fixed","name":"money","size":8,"logicalType":"decimal","precision":18,"scale":2}`, `{"name":"money","type":"fixed","size":8}`},
+		{`{"type":"fixed","size":8,"logicalType":"decimal","precision":18,"scale":2}`, `{"type":"fixed","size":8}`},
+		{`{"type":"string","logicalType":"uuid"}`, `"string"`},
+		{`{"type":"int","logicalType":"date"}`, `"int"`},
+		{`{"type":"long","logicalType":"timestamp-millis"}`, `"long"`},
+		{`{"type":"string","logicalType":"foo"}`, `"string"`},
+		{`{"type":"record","name":"r","fields":[{"name":"d","type":{"type":"int","logicalType":"date"},"doc":"A date."},{"name":"u","type":["null",{"type":"string","logicalType":"uuid"}]}]}`, `{"name":"r","type":"record","fields":[{"name":"d","type":"int"},{"name":"u","type":["null","string"]}]}`},
+	}
+
+	for _, c := range cases {
+		got, err := CanonicalForm(c.Schema)
+		ensureError(t, err)
+		if got != c.Canonical {
+			t.Errorf("CASE: %s; GOT: %s; WANT: %s", c.Schema, got, c.Canonical)
+		}
+		codec, err := NewCodec(c.Schema)
+		ensureError(t, err)
+		if got := codec.CanonicalSchema(); got != c.Canonical {
+			t.Errorf("CASE: %s; GOT: %s; WANT: %s", c.Schema, got, c.Canonical)
+		}
 	}
 }
@@ -10,9 +10,13 @@
 package goavro
 
 import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"math"
+	"time"
 )
 
 var (
@@ -37,6 +41,17 @@ var (
 	// potentially has more bytes in a single block, then this variable may be
 	// modified at your discretion.
 	MaxBlockSize = int64(math.MaxInt32)
+
+	// MaxDecodeDepth is the maximum number of records that code generated by
+	// Generate will decode nested inside one another. Schemas for recursive
+	// records allow data to be nested arbitrarily deep, and this limit is to
+	// ensure decoding binary data will not exhaust the stack, potentially
+	// creating a denial of service on the system.
+	//
+	// If a particular application needs to decode binary Avro data that
+	// potentially has more deeply nested records, then this variable may be
+	// modified at your discretion.
+	MaxDecodeDepth = 10000
 )
 
 // Codec supports decoding binary and text Avro data to Go native data types,
@@ -53,7 +68,18 @@ type Codec struct {
 	nativeFromBinary  func([]byte) (interface{}, []byte, error)
 	textualFromNative func([]byte, interface{}) ([]byte, error)
 
+	// nativeFromBinaryReuse, when not nil, decodes like nativeFromBinary, but
+	// recycles the maps and slices of the previously decoded datum provided
+	// as its second argument.
+	nativeFromBinaryReuse func([]byte, interface{}) (interface{}, []byte, error)
+
 	generator *CodecGenerator
+
+	// fieldCodecs are the codecs of the fields of a record by name, and
+	// elementCodecs are those of the members of a union, or of the items or
+	// values of an array or map, by which FieldProps finds nested fields.
+	fieldCodecs   map[string]*Codec
+	elementCodecs []*Codec
 }
 
 // NewCodec returns a Codec used to translate between a byte slice of either
@@ -83,6 +109,42 @@ type Codec struct {
 //             fmt.Println(err)
 //     }
 func NewCodec(schemaSpecification string) (*Codec, error) {
+	return NewCodecWithOptions(schemaSpecification, nil)
+}
+
+// CodecOption configures how a Codec created by NewCodecWithOptions translates
+// between Avro data and native Go data.
+type CodecOption struct {
+	// TimeLocation is the location of the time.Time values decoded for the
+	// date, timestamp and local timestamp logical types, which is UTC when
+	// nil. Use time.Local to decode local times. Dates decode to midnight in
+	// the location, and local timestamps to their wall clock time in the
+	// location. So that those dates encode to the same day, a codec with a
+	// location encodes the date of a time in the location, whereas other
+	// codecs encode the date of its instant in UTC.
+	TimeLocation *time.Location
+
+	// RawTime, when true, decodes the date, time and timestamp logical types
+	// to the int32 or int64 values of their underlying types, rather than to
+	// time.Time and time.Duration values.
+	RawTime bool
+
+	// UUIDBytes, when true, decodes the uuid logical type to [16]byte values
+	// rather than to their textual form.
+	UUIDBytes bool
+}
+
+// NewCodecWithOptions returns a Codec like NewCodec does, which translates
+// between Avro data and native Go data as configured by o, which may be nil.
+//
+// Whatever the options, the encoders of the date and time logical types accept
+// both the time.Time or time.Duration values and the integer values of their
+// underlying types, and the encoder of the uuid logical type accepts both
+// strings and [16]byte values.
+//
+//     codec, err := goavro.NewCodecWithOptions(`{"type": "long", "logicalType": "timestamp-millis"}`,
+//         &goavro.CodecOption{TimeLocation: time.Local})
+func NewCodecWithOptions(schemaSpecification string, o *CodecOption) (*Codec, error) {
 	var schema interface{}
 
 	if err := json.Unmarshal([]byte(schemaSpecification), &schema); err != nil {
@@ -91,6 +153,10 @@ func NewCodec(schemaSpecification string) (*Codec, error) {
 
 	// bootstrap a symbol table with primitive type codecs for the new codec
 	st := newSymbolTable()
+	if o != nil {
+		applyTimeOption(st, o)
+		applyUUIDOption(st, o)
+	}
 
 	c, err := buildCodec(st, nullNamespace, schema)
 	if err != nil {
@@ -124,6 +190,7 @@ func newSymbolTable() map[string]*Codec {
 			nativeFromBinary:  bytesNativeFromBinary,
 			nativeFromTextual: bytesNativeFromTextual,
 			textualFromNative: bytesTextualFromNative,
+			generator:         NewBytesCodecGenerator(),
 		},
 		"double": {
 			typeName:          &name{"double", nullNamespace},
@@ -173,6 +240,7 @@ func newSymbolTable() map[string]*Codec {
 			nativeFromBinary:  nullNativeFromBinary,
 			nativeFromTextual: nullNativeFromTextual,
 			textualFromNative: nullTextualFromNative,
+			generator:         NewNullCodecGenerator(),
 		},
 		"string": {
 			typeName:          &name{"string", nullNamespace},
@@ -194,6 +262,7 @@ func newSymbolTable() map[string]*Codec {
 			binaryFromNative:  timeStampMillisFromNative(longBinaryFromNative),
 			nativeFromBinary:  nativeFromTimeStampMillis(longNativeFromBinary),
 			textualFromNative: timeStampMillisFromNative(longTextualFromNative),
+			generator:         NewTimeStampMillisCodecGenerator(),
 		},
 		"long.timestamp-micros": {
 			typeName:          &name{"long.timestamp-micros", nullNamespace},
@@ -203,6 +272,7 @@ func newSymbolTable() map[string]*Codec {
 			binaryFromNative:  timeStampMicrosFromNative(longBinaryFromNative),
 			nativeFromBinary:  nativeFromTimeStampMicros(longNativeFromBinary),
 			textualFromNative: timeStampMicrosFromNative(longTextualFromNative),
+			generator:         NewTimeStampMicrosCodecGenerator(),
 		},
 		"int.time-millis": {
 			typeName:          &name{"int.time-millis", nullNamespace},
@@ -212,6 +282,7 @@ func newSymbolTable() map[string]*Codec {
 			binaryFromNative:  timeMillisFromNative(intBinaryFromNative),
 			nativeFromBinary:  nativeFromTimeMillis(intNativeFromBinary),
 			textualFromNative: timeMillisFromNative(intTextualFromNative),
+			generator:         NewTimeMillisCodecGenerator(),
 		},
 		"long.time-micros": {
 			typeName:          &name{"long.time-micros", nullNamespace},
@@ -221,6 +292,37 @@ func newSymbolTable() map[string]*Codec {
 			binaryFromNative:  timeMicrosFromNative(longBinaryFromNative),
 			nativeFromBinary:  nativeFromTimeMicros(longNativeFromBinary),
 			textualFromNative: timeMicrosFromNative(longTextualFromNative),
+			generator:         NewTimeMicrosCodecGenerator(),
+		},
+		"long.local-timestamp-millis": {
+			typeName:          &name{"long.local-timestamp-millis", nullNamespace},
+			schemaOriginal:    "long",
+			schemaCanonical:   "long",
+			nativeFromTextual: nativeFromTimeStampMillis(longNativeFromTextual),
+			binaryFromNative:  localTimeStampMillisFromNative(longBinaryFromNative),
+			nativeFromBinary:  nativeFromTimeStampMillis(longNativeFromBinary),
+			textualFromNative: localTimeStampMillisFromNative(longTextualFromNative),
+			generator:         NewLocalTimeStampMillisCodecGenerator(),
+		},
+		"long.local-timestamp-micros": {
+			typeName:          &name{"long.local-timestamp-micros", nullNamespace},
+			schemaOriginal:    "long",
+			schemaCanonical:   "long",
+			nativeFromTextual: nativeFromTimeStampMicros(longNativeFromTextual),
+			binaryFromNative:  localTimeStampMicrosFromNative(longBinaryFromNative),
+			nativeFromBinary:  nativeFromTimeStampMicros(longNativeFromBinary),
+			textualFromNative: localTimeStampMicrosFromNative(longTextualFromNative),
+			generator:         NewLocalTimeStampMicrosCodecGenerator(),
+		},
+		"string.uuid": {
+			typeName:          &name{"string.uuid", nullNamespace},
+			schemaOriginal:    "string",
+			schemaCanonical:   "string",
+			nativeFromTextual: nativeFromUUID(stringNativeFromTextual),
+			binaryFromNative:  uuidFromNative(stringBinaryFromNative),
+			nativeFromBinary:  nativeFromUUID(stringNativeFromBinary),
+			textualFromNative: uuidFromNative(stringTextualFromNative),
+			generator:         NewUUIDCodecGenerator(),
 		},
 		"int.date": {
 			typeName:          &name{"int.date", nullNamespace},
@@ -407,6 +509,16 @@ func (c *Codec) TextualFromNative(buf []byte, datum interface{}) ([]byte, error)
 	return newBuf, nil
 }
 
+// nativeFromBinaryReusing decodes a datum value from buf like nativeFromBinary,
+// recycling the maps and slices of the previously decoded datum value when the
+// codec supports doing so.
+func (c *Codec) nativeFromBinaryReusing(buf []byte, datum interface{}) (interface{}, []byte, error) {
+	if datum == nil || c.nativeFromBinaryReuse == nil {
+		return c.nativeFromBinary(buf)
+	}
+	return c.nativeFromBinaryReuse(buf, datum)
+}
+
 // Schema returns the original schema used to create the Codec.
 func (c *Codec) Schema() string {
 	return c.schemaOriginal
@@ -432,11 +544,12 @@ func initCRC64AvroTable() [256]uint64 {
 	return crc64Table
 }
 
+var crc64AvroTable = initCRC64AvroTable()
+
 func calculateCRC64Avro(b []byte) uint64 {
-	crc64Table := initCRC64AvroTable()
 	fp := crc64Empty
 	for i := 0; i < len(b); i++ {
-		fp = (fp >> 8) ^ crc64Table[(byte(fp)^b[i])&0xff] // unsigned right shift >>>
+		fp = (fp >> 8) ^ crc64AvroTable[(byte(fp)^b[i])&0xff] // unsigned right shift >>>
 	}
 	return fp
 }
@@ -449,6 +562,84 @@ func (c *Codec) SchemaCRC64Avro() int64 {
 	return int64(calculateCRC64Avro([]byte(c.schemaCanonical)))
 }
 
+// The fingerprint algorithms the Avro specification recommends, named the way
+// Avro's own implementation names them.
+const (
+	FingerprintCRC64Avro = "CRC-64-AVRO" // the 64-bit Rabin fingerprint
+	FingerprintMD5       = "MD5"
+	FingerprintSHA256    = "SHA-256"
+)
+
+// Fingerprint returns the fingerprint of the canonical schema computed with the
+// algorithm, which is one of FingerprintCRC64Avro, FingerprintMD5 or
+// FingerprintSHA256. Like Avro's own implementation, the 64-bit Rabin
+// fingerprint is returned as 8 bytes in little-endian order.
+func (c *Codec) Fingerprint(algorithm string) ([]byte, error) {
+	return fingerprint([]byte(c.schemaCanonical), algorithm)
+}
+
+// FingerprintSchema returns the fingerprint of the Parsing Canonical Form of
+// the schema, as returned by CanonicalForm, computed with the algorithm like
+// Codec.Fingerprint computes it, without building a Codec.
+func FingerprintSchema(schemaSpecification, algorithm string) ([]byte, error) {
+	canonical, err := CanonicalForm(schemaSpecification)
+	if err != nil {
+		return nil, err
+	}
+	return fingerprint([]byte(canonical), algorithm)
+}
+
+func fingerprint(canonical []byte, algorithm string) ([]byte, error) {
+	switch algorithm {
+	case FingerprintCRC64Avro:
+		buf := make([]byte, 8)
+		binary.LittleEndian.PutUint64(buf, calculateCRC64Avro(canonical))
+		return buf, nil
+	case FingerprintMD5:
+		sum := md5.Sum(canonical)
+		return sum[:], nil
+	case FingerprintSHA256:
+		sum := sha256.Sum256(canonical)
+		return sum[:], nil
+	}
+	return nil, fmt.Errorf("cannot compute fingerprint: algorithm ought to be %q, %q or %q; received: %q", FingerprintCRC64Avro, FingerprintMD5, FingerprintSHA256, algorithm)
+}
+
+// CheckSchema returns an error unless data encoded with the schema of other can
+// be decoded with c. That is the case when both schemas have the same Rabin
+// fingerprint, or when they resolve to the same schema once every name is fully
+// qualified with the namespace it inherits from its enclosing types. This allows
+// generated types to read and write the OCF files of schemas that declare their
+// namespaces differently.
+func (c *Codec) CheckSchema(other *Codec) error {
+	if c.SchemaCRC64Avro() == other.SchemaCRC64Avro() {
+		return nil
+	}
+	want, err := c.qualifiedCanonicalSchema()
+	if err != nil {
+		return fmt.Errorf("cannot resolve schema: %s", err)
+	}
+	got, err := other.qualifiedCanonicalSchema()
+	if err != nil {
+		return fmt.Errorf("cannot resolve schema: %s", err)
+	}
+	if got != want {
+		return fmt.Errorf("cannot resolve schema: fingerprint ought to be %d; received: %d: %s", c.SchemaCRC64Avro(), other.SchemaCRC64Avro(), other.schemaCanonical)
+	}
+	return nil
+}
+
+// qualifiedCanonicalSchema returns the parsing canonical form of the original
+// schema of the codec, with every name fully qualified.
+func (c *Codec) qualifiedCanonicalSchema() (string, error) {
+	var schema interface{}
+	if err := json.Unmarshal([]byte(c.schemaOriginal), &schema); err != nil {
+		// primitive codecs are created from their bare type names
+		schema = c.schemaOriginal
+	}
+	return qualifiedCanonicalForm(schema)
+}
+
 // convert a schema data structure to a codec, prefixing with specified
 // namespace
 func buildCodec(st map[string]*Codec, enclosingNamespace string, schema interface{}) (*Codec, error) {
@@ -521,16 +712,32 @@ func buildCodecForTypeDescribedByString(st map[string]*Codec, enclosingNamespace
 		return makeFixedCodec(st, enclosingNamespace, schemaMap)
 	case "map":
 		return makeMapCodec(st, enclosingNamespace, schemaMap)
-	case "record":
+	case "record", "error":
+		// NOTE: Errors, which are declared by protocols, are records.
 		return makeRecordCodec(st, enclosingNamespace, schemaMap)
 	case "bytes.decimal":
 		return makeDecimalBytesCodec(st, enclosingNamespace, schemaMap)
 	case "fixed.decimal":
 		return makeDecimalFixedCodec(st, enclosingNamespace, schemaMap)
+	case "fixed.duration":
+		return makeDurationFixedCodec(st, enclosingNamespace, schemaMap)
 	default:
 		if isLogicalType {
+			logicalType := schemaMap["logicalType"]
 			delete(schemaMap, "logicalType")
-			return buildCodecForTypeDescribedByString(st, enclosingNamespace, typeName, schemaMap)
+			lt, ok := lookupCustomLogicalType(searchType)
+			if !ok {
+				return buildCodecForTypeDescribedByString(st, enclosingNamespace, typeName, schemaMap)
+			}
+			c, err := buildCodecForTypeDescribedByString(st, enclosingNamespace, typeName, schemaMap)
+			// NOTE: The attribute is restored so that the schemas of named
+			// types, and of the code generated for them, keep the logical
+			// type. The parsing canonical form strips it.
+			schemaMap["logicalType"] = logicalType
+			if err != nil {
+				return nil, err
+			}
+			return makeCustomLogicalTypeCodec(c, searchType, lt), nil
 		}
 		return nil, fmt.Errorf("unknown type name: %q", searchType)
 	}
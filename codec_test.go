@@ -10,6 +10,7 @@
 package goavro
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 )
@@ -135,10 +136,110 @@ func TestSchemaCRC64Avro(t *testing.T) {
 	for _, c := range cases {
 		codec, err := NewCodec(c.Schema)
 		if err != nil {
-			t.Fatalf("CASE: %s; cannot create code: %s", c.Schema, err)
+			t.Fatalf("CASE: %s; cannot create codec: %s", c.Schema, err)
 		}
 		if got, want := codec.SchemaCRC64Avro(), c.Fingerprint; got != want {
 			t.Errorf("CASE: %s; GOT: %#x; WANT: %#x", c.Schema, got, want)
 		}
 	}
 }
+
+func TestCodecCheckSchema(t *testing.T) {
+	newCodec := func(schema string) *Codec {
+		t.Helper()
+		codec, err := NewCodec(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return codec
+	}
+
+	declared := newCodec(`{"type":"record","name":"r1","namespace":"com.example","fields":[{"name":"next","type":["null","r1"]},{"name":"e","type":{"type":"enum","name":"e1","symbols":["A"]}},{"name":"other","type":"e1"}]}`)
+	qualified := newCodec(`{"type":"record","name":"com.example.r1","fields":[{"name":"next","type":["null","com.example.r1"]},{"name":"e","type":{"type":"enum","name":"com.example.e1","symbols":["A"]}},{"name":"other","type":"com.example.e1"}],"doc":"ignored"}`)
+
+	// Same fingerprint.
+	if err := declared.CheckSchema(declared); err != nil {
+		t.Errorf("GOT: %v; WANT: %v", err, nil)
+	}
+	// Different fingerprints, but the same schema once names are qualified.
+	if declared.SchemaCRC64Avro() == qualified.SchemaCRC64Avro() {
+		t.Fatalf("GOT: %v; WANT: different fingerprints", declared.SchemaCRC64Avro())
+	}
+	if err := declared.CheckSchema(qualified); err != nil {
+		t.Errorf("GOT: %v; WANT: %v", err, nil)
+	}
+	if err := qualified.CheckSchema(declared); err != nil {
+		t.Errorf("GOT: %v; WANT: %v", err, nil)
+	}
+
+	// Different schemas.
+	err := declared.CheckSchema(newCodec(`{"type":"record","name":"com.other.r1","fields":[{"name":"next","type":["null","com.other.r1"]},{"name":"e","type":{"type":"enum","name":"com.other.e1","symbols":["A"]}},{"name":"other","type":"com.other.e1"}]}`))
+	ensureError(t, err, "cannot resolve schema", "fingerprint ought to be")
+	err = newCodec(`"long"`).CheckSchema(newCodec(`"int"`))
+	ensureError(t, err, "cannot resolve schema", `"int"`)
+	if err = newCodec(`{"type":"long"}`).CheckSchema(newCodec(`"long"`)); err != nil {
+		t.Errorf("GOT: %v; WANT: %v", err, nil)
+	}
+}
+
+func TestCodecFingerprint(t *testing.T) {
+	cases := []struct {
+		Schema    string
+		Algorithm string
+		Want      string
+	}{
+		{`"int"`, FingerprintCRC64Avro, "8f5c393f1ad57572"},
+		{`"int"`, FingerprintMD5, "ef524ea1b91e73173d938ade36c1db32"},
+		{`"int"`, FingerprintSHA256, "3f2b87a9fe7cc9b13835598c3981cd45e3e355309e5090aa0933d7becb6fba45"},
+		{`{ "values":"string", "type":"map"}`, FingerprintCRC64Avro, "724586925d96ce86"},
+		{`{ "values":"string", "type":"map"}`, FingerprintMD5, "a236344d3b2bbe2cc09439868bfc7168"},
+		{`{ "values":"string", "type":"map"}`, FingerprintSHA256, "52bf173d92fa85cec682a0ad4186c96c9f5147e4881bd1c7154dba8bb4515297"},
+	}
+
+	for _, c := range cases {
+		codec, err := NewCodec(c.Schema)
+		if err != nil {
+			t.Fatalf("CASE: %s; cannot create codec: %s", c.Schema, err)
+		}
+		got, err := codec.Fingerprint(c.Algorithm)
+		ensureError(t, err)
+		if got := fmt.Sprintf("%x", got); got != c.Want {
+			t.Errorf("CASE: %s %s; GOT: %s; WANT: %s", c.Schema, c.Algorithm, got, c.Want)
+		}
+		got, err = FingerprintSchema(c.Schema, c.Algorithm)
+		ensureError(t, err)
+		if got := fmt.Sprintf("%x", got); got != c.Want {
+			t.Errorf("CASE: %s %s; GOT: %s; WANT: %s", c.Schema, c.Algorithm, got, c.Want)
+		}
+	}
+
+	codec, err := NewCodec(`"int"`)
+	ensureError(t, err)
+	_, err = codec.Fingerprint("SHA-1")
+	ensureError(t, err, "cannot compute fingerprint", `"SHA-1"`)
+	_, err = FingerprintSchema(`{`, FingerprintMD5)
+	ensureError(t, err, "cannot unmarshal schema JSON")
+}
+
+func TestFingerprintSchemaMatchesCodec(t *testing.T) {
+	schemas := []string{
+		`{"type":"bytes","logicalType":"decimal","precision":4,"scale":2}`,
+		`{"type":"fixed","size":8,"logicalType":"decimal","precision":18,"scale":2}`,
+		`{"type":"string","logicalType":"uuid"}`,
+		`{"type":"int","logicalType":"date"}`,
+		`{"type":"string","logicalType":"foo"}`,
+	}
+	for _, schema := range schemas {
+		codec, err := NewCodec(schema)
+		ensureError(t, err)
+		for _, algorithm := range []string{FingerprintCRC64Avro, FingerprintMD5, FingerprintSHA256} {
+			want, err := codec.Fingerprint(algorithm)
+			ensureError(t, err)
+			got, err := FingerprintSchema(schema, algorithm)
+			ensureError(t, err)
+			if !bytes.Equal(got, want) {
+				t.Errorf("CASE: %s %s; GOT: %x; WANT: %x", schema, algorithm, got, want)
+			}
+		}
+	}
+}
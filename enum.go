@@ -43,6 +43,25 @@ func makeEnumCodec(st map[string]*Codec, enclosingNamespace string, schemaMap ma
 		symbols[i] = symbol
 	}
 
+	// NOTE: The optional default symbol is decoded in place of a symbol whose
+	// index is not in the schema, such as one written using a later version of
+	// the schema with more symbols.
+	defaultIndex := -1
+	if d, ok := schemaMap["default"]; ok {
+		defaultSymbol, ok := d.(string)
+		if ok {
+			for i, symbol := range symbols {
+				if symbol == defaultSymbol {
+					defaultIndex = i
+					break
+				}
+			}
+		}
+		if defaultIndex < 0 {
+			return nil, fmt.Errorf("Enum %q default ought to be member of symbols: %v; received: %v", c.typeName, symbols, d)
+		}
+	}
+
 	c.nativeFromBinary = func(buf []byte) (interface{}, []byte, error) {
 		var value interface{}
 		var err error
@@ -53,6 +72,9 @@ func makeEnumCodec(st map[string]*Codec, enclosingNamespace string, schemaMap ma
 		}
 		index = value.(int64)
 		if index < 0 || index >= int64(len(symbols)) {
+			if defaultIndex >= 0 {
+				return symbols[defaultIndex], buf, nil
+			}
 			return nil, nil, fmt.Errorf("cannot decode binary enum %q: index ought to be between 0 and %d; read index: %d", c.typeName, len(symbols)-1, index)
 		}
 		return symbols[index], buf, nil
@@ -102,7 +124,8 @@ func makeEnumCodec(st map[string]*Codec, enclosingNamespace string, schemaMap ma
 	}
 
 	enumName, _ := newNameFromSchemaMap(enclosingNamespace, schemaMap)
-	c.generator = NewEnumCodecGenerator(enumName, symbols)
+	c.generator = NewEnumCodecGenerator(enumName, symbols, defaultIndex)
+	c.generator.schema, c.generator.enclosingNamespace = schemaMap, enclosingNamespace
 
 	return c, nil
 }
@@ -43,6 +43,15 @@ func TestEnumDecodeError(t *testing.T) {
 	testBinaryDecodeFail(t, `{"type":"enum","name":"e1","symbols":["alpha","bravo"]}`, []byte("\x04"), `cannot decode binary enum "e1": index ought to be between 0 and 1`)
 }
 
+func TestEnumDefault(t *testing.T) {
+	testSchemaInvalid(t, `{"type":"enum","name":"e1","symbols":["alpha","bravo"],"default":"charlie"}`, `Enum "e1" default ought to be member of symbols`)
+	testSchemaInvalid(t, `{"type":"enum","name":"e1","symbols":["alpha","bravo"],"default":1}`, `Enum "e1" default ought to be member of symbols`)
+	testBinaryDecodePass(t, `{"type":"enum","name":"e1","symbols":["alpha","bravo"],"default":"bravo"}`, "alpha", []byte("\x00"))
+	// Indexes outside the symbols decode as the default symbol.
+	testBinaryDecodePass(t, `{"type":"enum","name":"e1","symbols":["alpha","bravo"],"default":"bravo"}`, "bravo", []byte("\x04"))
+	testBinaryDecodePass(t, `{"type":"enum","name":"e1","symbols":["alpha","bravo"],"default":"alpha"}`, "alpha", []byte("\x01"))
+}
+
 func TestEnumEncodeError(t *testing.T) {
 	testBinaryEncodeFail(t, `{"type":"enum","name":"e1","symbols":["alpha","bravo"]}`, 13, `cannot encode binary enum "e1": expected string; received: int`)
 	testBinaryEncodeFail(t, `{"type":"enum","name":"e1","symbols":["alpha","bravo"]}`, "charlie", `cannot encode binary enum "e1": value ought to be member of symbols`)
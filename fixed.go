@@ -82,6 +82,9 @@ func makeFixedCodec(st map[string]*Codec, enclosingNamespace string, schemaMap m
 		return bytesTextualFromNative(buf, someBytes)
 	}
 
+	c.generator = NewFixedCodecGenerator(c.typeName, size)
+	c.generator.schema, c.generator.enclosingNamespace = schemaMap, enclosingNamespace
+
 	return c, nil
 }
 
@@ -3,100 +3,621 @@ package goavro
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go/format"
+	"go/token"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
-func Generate(packageName string, inputFiles []string, outputDir string, verbose bool) error {
+// GenerateError describes why code could not be generated from a schema file.
+type GenerateError struct {
+	// File is the schema file that could not be generated.
+	File string
+
+	// SchemaPath is the JSON pointer to the location within the schema where
+	// the problem was found, such as "/fields/2/type", or the empty string
+	// when the problem is not specific to one location.
+	SchemaPath string
+
+	// Err is the reason code could not be generated.
+	Err error
+}
+
+func (e *GenerateError) Error() string {
+	if e.SchemaPath == "" {
+		return fmt.Sprintf("cannot generate code from %q: %s", e.File, e.Err)
+	}
+	return fmt.Sprintf("cannot generate code from %q at schema path %q: %s", e.File, e.SchemaPath, e.Err)
+}
+
+// GenerateConfig describes the Go packages to which Generate writes the types
+// defined by schemas.
+type GenerateConfig struct {
+	// PackageName is the name of the package written to the output directory.
+	PackageName string
+
+	// ImportPath is the import path of the package written to the output
+	// directory. It is only required when Namespaces is not empty.
+	ImportPath string
+
+	// Namespaces maps Avro namespaces to the import paths of the packages to
+	// which their types are written. A type is written to the package mapped
+	// from the longest namespace that is either its own namespace or one of
+	// its parents, so "com.example" maps the types in both "com.example" and
+	// "com.example.billing". Types in namespaces that are not mapped are
+	// written to the package in the output directory.
+	//
+	// Each import path ought to be within ImportPath. Its package is written
+	// to the corresponding subdirectory of the output directory, and is named
+	// by the last element of the import path. For instance, with an ImportPath
+	// of "example.com/models", types mapped to "example.com/models/billing"
+	// are written to the "billing" subdirectory, in package billing.
+	Namespaces map[string]string
+
+	// TypeNames maps the full names of Avro named types to the names of the
+	// Go types generated for them, overriding the names derived from the Avro
+	// names. The identifiers derived from the name of a type, such as its
+	// constructors and enum constants, and the name of its file, follow it.
+	TypeNames map[string]string
+}
+
+// Generate writes Go source code for the types defined by the schemas in the
+// specified input files to outputDir, one file per generated type, in the
+// packages described by config. Schemas may refer to named types defined in
+// other input files, regardless of the order of the files. When log is not
+// nil, a description of each step is written to it.
+//
+// Generated files that Generate would no longer write, because their types
+// were removed or renamed, are removed from the directories of the packages
+// described by config. Generated files are recognized by their header, so other
+// files in those directories are left alone.
+//
+// When code cannot be generated for a file, Generate returns a *GenerateError
+// and writes no files.
+func Generate(config GenerateConfig, inputFiles []string, outputDir string, log io.Writer) error {
+	sources, err := GenerateSources(config, inputFiles, log)
+	if err != nil {
+		return err
+	}
+
+	for _, outFile := range sortedSourceNames(sources) {
+		outPath := filepath.Join(outputDir, filepath.FromSlash(outFile))
+		if err = os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+			return fmt.Errorf("cannot write generated code: %s", err)
+		}
+		if err = ioutil.WriteFile(outPath, sources[outFile], 0644); err != nil {
+			return fmt.Errorf("cannot write generated code: %s", err)
+		}
+		logf(log, "wrote %s\n", outPath)
+	}
+
+	stale, err := staleGeneratedFiles(config, sources, outputDir)
+	if err != nil {
+		return err
+	}
+	for _, outFile := range stale {
+		outPath := filepath.Join(outputDir, filepath.FromSlash(outFile))
+		if err = os.Remove(outPath); err != nil {
+			return fmt.Errorf("cannot remove stale generated code: %s", err)
+		}
+		logf(log, "removed %s\n", outPath)
+	}
+
+	return nil
+}
+
+// CheckGenerated returns an error naming each file in outputDir that differs
+// from what Generate would write for the same arguments: files that would be
+// changed or written, and stale generated files that would be removed. It
+// writes nothing, so it can be used to ensure that committed generated code is
+// up to date.
+//
+// When code cannot be generated for a file, CheckGenerated returns a
+// *GenerateError.
+func CheckGenerated(config GenerateConfig, inputFiles []string, outputDir string, log io.Writer) error {
+	sources, err := GenerateSources(config, inputFiles, log)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for _, outFile := range sortedSourceNames(sources) {
+		existing, err := ioutil.ReadFile(filepath.Join(outputDir, filepath.FromSlash(outFile)))
+		switch {
+		case os.IsNotExist(err):
+			problems = append(problems, outFile+" is missing")
+		case err != nil:
+			return fmt.Errorf("cannot check generated code: %s", err)
+		case !bytes.Equal(existing, sources[outFile]):
+			problems = append(problems, outFile+" is out of date")
+		}
+	}
+
+	stale, err := staleGeneratedFiles(config, sources, outputDir)
+	if err != nil {
+		return err
+	}
+	for _, outFile := range stale {
+		problems = append(problems, outFile+" is stale")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("generated code in %q ought to be regenerated: %s", outputDir, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// generatedFileHeader begins each file written by Generate.
+const generatedFileHeader = "//******************************************\n" +
+	"//* This file is is generated, DO NOT EDIT *\n" +
+	"//******************************************\n"
+
+// staleGeneratedFiles returns the slash separated paths, relative to
+// outputDir, of the generated files in the directories of the packages
+// described by config, that are not among sources.
+func staleGeneratedFiles(config GenerateConfig, sources map[string][]byte, outputDir string) ([]string, error) {
+	packages, err := newGenPackages(config)
+	if err != nil {
+		return nil, err
+	}
+	dirs := map[string]struct{}{packages.root.dir: {}}
+	for _, pkg := range packages.byNamespace {
+		dirs[pkg.dir] = struct{}{}
+	}
+
+	var stale []string
+	for dir := range dirs {
+		matches, err := filepath.Glob(filepath.Join(outputDir, filepath.FromSlash(dir), "*.go"))
+		if err != nil {
+			return nil, fmt.Errorf("cannot find stale generated code: %s", err)
+		}
+		for _, match := range matches {
+			outFile := path.Join(dir, filepath.Base(match))
+			if _, ok := sources[outFile]; ok {
+				continue
+			}
+			src, err := ioutil.ReadFile(match)
+			if err != nil {
+				return nil, fmt.Errorf("cannot find stale generated code: %s", err)
+			}
+			if bytes.HasPrefix(src, []byte(generatedFileHeader)) {
+				stale = append(stale, outFile)
+			}
+		}
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
+// GenerateSources is like Generate, but rather than writing files, it returns
+// the generated source code for each file that Generate would write, keyed by
+// its slash separated path relative to the output directory.
+func GenerateSources(config GenerateConfig, inputFiles []string, log io.Writer) (map[string][]byte, error) {
+	packages, err := newGenPackages(config)
+	if err != nil {
+		return nil, err
+	}
+
 	symbolTable := newSymbolTable()
 	filesLeftToProcess := inputFiles
-	var err error
 
-	for {
-		prevLen := len(filesLeftToProcess)
-		filesLeftToProcess, err = generateCodecs(symbolTable, filesLeftToProcess, verbose)
+	for len(filesLeftToProcess) > 0 {
+		failures, err := generateCodecs(symbolTable, filesLeftToProcess, log)
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(-1)
+			return nil, err
 		}
-		newLen := len(filesLeftToProcess)
-		if newLen == 0 {
-			break
-		} else if prevLen == newLen {
-			fmt.Println("Not making progress, bailing")
-			os.Exit(-1)
+		if len(failures) == len(filesLeftToProcess) {
+			// Not making progress; report the first file that failed.
+			return nil, failures[0]
+		}
+		filesLeftToProcess = filesLeftToProcess[:0:0]
+		for _, failure := range failures {
+			filesLeftToProcess = append(filesLeftToProcess, failure.File)
 		}
 	}
 
-	for _, c := range symbolTable {
-		if c.generator != nil && c.generator.isWritable {
-			outFile := fmt.Sprintf("%s.go", toSnake(c.typeName.short()))
-			outPath := path.Join(outputDir, outFile)
-			if verbose {
-				fmt.Println("Will write", c.typeName.String(), "as", outPath)
+	if err = renameTypes(symbolTable, config.TypeNames); err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string][]byte)
+	written := make(map[*Codec]struct{})
+	declared := make(map[*genPackage]map[string]string) // file that declares each identifier
+	var unionsToWrite []unionInPackage
+
+	writeType := func(c *Codec, pkg *genPackage) error {
+		outFile := path.Join(pkg.dir, fmt.Sprintf("%s.go", toSnake(c.generator.goTypeName)))
+		logf(log, "generating %s as %s\n", c.typeName, outFile)
+
+		var body bytes.Buffer
+		if err := c.generator.writeSrc(&body); err != nil {
+			return &GenerateError{File: outFile, Err: fmt.Errorf("cannot write source for %q: %s", c.typeName, err)}
+		}
+		if declared[pkg] == nil {
+			declared[pkg] = make(map[string]string)
+		}
+		for _, ident := range c.generator.declaredIdents {
+			if other, ok := declared[pkg][ident]; ok {
+				return &GenerateError{File: outFile, Err: fmt.Errorf("cannot declare %q for %q in package %q: already declared in %s", ident, c.typeName, pkg.name, other)}
 			}
+			declared[pkg][ident] = outFile
+		}
+		if _, ok := sources[outFile]; ok {
+			return &GenerateError{File: outFile, Err: fmt.Errorf("more than one type would be written to the same file: %q", c.typeName)}
+		}
+		resolved, unions, err := packages.resolveRefs(body.Bytes(), pkg)
+		if err != nil {
+			return &GenerateError{File: outFile, Err: fmt.Errorf("cannot write source for %q: %s", c.typeName, err)}
+		}
+		for _, key := range unions {
+			unionsToWrite = append(unionsToWrite, unionInPackage{key: key, pkg: pkg})
+		}
 
-			var writer bytes.Buffer
+		var writer bytes.Buffer
 
-			writer.WriteString("//******************************************\n")
-			writer.WriteString("//* This file is is generated, DO NOT EDIT *\n")
-			writer.WriteString("//******************************************\n\n")
+		writer.WriteString(generatedFileHeader)
+		writer.WriteString("\n")
 
-			writer.WriteString(fmt.Sprintf("package %s\n\n", packageName))
-			c.generator.writeDecoderSrc(&writer)
+		writer.WriteString(fmt.Sprintf("package %s\n\n", pkg.name))
+		writer.Write(resolved)
 
-			//fmt.Println(writer.String())
+		src, err := format.Source(writer.Bytes())
+		if err != nil {
+			return &GenerateError{File: outFile, Err: fmt.Errorf("cannot format source for %q: %s", c.typeName, err)}
+		}
+		sources[outFile] = src
+		return nil
+	}
 
-			src, err := format.Source(writer.Bytes())
-			if err != nil {
-				fmt.Println(err)
-				os.Exit(-1)
+	// Named types are written to the package for their namespace.
+	var unionKeys []string
+	for _, key := range sortedCodecNames(symbolTable) {
+		c := symbolTable[key]
+		if c.generator == nil || !c.generator.isWritable {
+			continue
+		}
+		if strings.HasPrefix(key, unionSymbolTablePrefix) {
+			unionKeys = append(unionKeys, key)
+			continue
+		}
+		if _, ok := written[c]; ok {
+			continue
+		}
+		written[c] = struct{}{}
+		if c.generator.schema != nil {
+			c.generator.standaloneSchema = standaloneSchema(symbolTable, c)
+		}
+		if err := writeType(c, packages.forNamespace(c.typeName.namespace)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Unions are written to each package that uses them, and to the package
+	// in the output directory when no package uses them.
+	writtenUnions := make(map[unionInPackage]struct{})
+	used := make(map[string]struct{})
+	for {
+		for len(unionsToWrite) > 0 {
+			u := unionsToWrite[0]
+			unionsToWrite = unionsToWrite[1:]
+			used[u.key] = struct{}{}
+			if _, ok := writtenUnions[u]; ok {
+				continue
 			}
+			writtenUnions[u] = struct{}{}
+			if err := writeType(symbolTable[u.key], u.pkg); err != nil {
+				return nil, err
+			}
+		}
+		for _, key := range unionKeys {
+			if _, ok := used[key]; !ok {
+				// Writing the union may reveal other unions that it uses.
+				unionsToWrite = append(unionsToWrite, unionInPackage{key: key, pkg: packages.root})
+				break
+			}
+		}
+		if len(unionsToWrite) == 0 {
+			break
+		}
+	}
 
-			f, err := os.Create(outPath)
-			if err != nil {
-				fmt.Println(err)
-			} else {
-				_, err := f.Write(src)
-				if err != nil {
-					fmt.Println(err)
+	if err := packages.checkImportCycles(); err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+// renameTypes overrides the names of the Go types generated for the named
+// types in the symbol table, as described by GenerateConfig.TypeNames.
+func renameTypes(st map[string]*Codec, typeNames map[string]string) error {
+	fullNames := make([]string, 0, len(typeNames))
+	for fullName := range typeNames {
+		fullNames = append(fullNames, fullName)
+	}
+	sort.Strings(fullNames)
+
+	for _, fullName := range fullNames {
+		goTypeName := typeNames[fullName]
+		c, ok := st[fullName]
+		if !ok {
+			return fmt.Errorf("cannot name Go type for %q: type ought to be defined by an input file", fullName)
+		}
+		if c.generator == nil || c.generator.rename == nil {
+			return fmt.Errorf("cannot name Go type for %q: type ought to be a record, enum, or fixed", fullName)
+		}
+		if r, _ := utf8.DecodeRuneInString(goTypeName); !isGoIdentifier(goTypeName) || !unicode.IsUpper(r) {
+			return fmt.Errorf("cannot name Go type for %q: name ought to be an exported Go identifier: %q", fullName, goTypeName)
+		}
+		c.generator.rename(goTypeName)
+	}
+	return nil
+}
+
+// unionInPackage identifies a union type to be written to a package.
+type unionInPackage struct {
+	key string // symbol table key of the union
+	pkg *genPackage
+}
+
+// genPackage describes a Go package to which generated code is written.
+type genPackage struct {
+	name       string
+	importPath string
+	dir        string // slash separated, relative to the output directory
+}
+
+// genPackages maps Avro namespaces to the Go packages to which their types are
+// written, and records the imports between those packages.
+type genPackages struct {
+	root        *genPackage
+	byNamespace map[string]*genPackage
+	imports     map[*genPackage]map[*genPackage]struct{}
+}
+
+func newGenPackages(config GenerateConfig) (*genPackages, error) {
+	if !isGoIdentifier(config.PackageName) {
+		return nil, fmt.Errorf("cannot generate code: package name ought to be a Go identifier: %q", config.PackageName)
+	}
+	if len(config.Namespaces) > 0 && config.ImportPath == "" {
+		return nil, errors.New("cannot generate code: namespaces cannot be mapped to packages without the import path of the output directory")
+	}
+
+	root := &genPackage{name: config.PackageName, importPath: config.ImportPath}
+	packages := &genPackages{
+		root:        root,
+		byNamespace: make(map[string]*genPackage, len(config.Namespaces)),
+		imports:     make(map[*genPackage]map[*genPackage]struct{}),
+	}
+	byImportPath := map[string]*genPackage{root.importPath: root}
+
+	namespaces := make([]string, 0, len(config.Namespaces))
+	for namespace := range config.Namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		importPath := config.Namespaces[namespace]
+		pkg, ok := byImportPath[importPath]
+		if !ok {
+			if !strings.HasPrefix(importPath, config.ImportPath+"/") {
+				return nil, fmt.Errorf("cannot map namespace %q to package: import path ought to be within %q: %q", namespace, config.ImportPath, importPath)
+			}
+			name := path.Base(importPath)
+			if token.Lookup(name).IsKeyword() {
+				name += "_"
+			}
+			pkg = &genPackage{
+				name:       name,
+				importPath: importPath,
+				dir:        strings.TrimPrefix(importPath, config.ImportPath+"/"),
+			}
+			if !isGoIdentifier(pkg.name) {
+				return nil, fmt.Errorf("cannot map namespace %q to package: last element of import path ought to be a Go identifier: %q", namespace, importPath)
+			}
+			byImportPath[importPath] = pkg
+		}
+		packages.byNamespace[namespace] = pkg
+	}
+
+	return packages, nil
+}
+
+// forNamespace returns the package to which types in namespace are written.
+func (p *genPackages) forNamespace(namespace string) *genPackage {
+	for {
+		if pkg, ok := p.byNamespace[namespace]; ok {
+			return pkg
+		}
+		i := strings.LastIndexByte(namespace, '.')
+		if i < 0 {
+			return p.root
+		}
+		namespace = namespace[:i]
+	}
+}
+
+// namedRefPattern matches the references returned by namedRefSrc.
+var namedRefPattern = regexp.MustCompile(namedRefMark + "([^" + namedRefMark + "]*)" + namedRefMark + "([A-Za-z_][A-Za-z0-9_]*)")
+
+// generatedSrcNames are the names of the packages imported by, and the local
+// variables declared by, generated source code.
+var generatedSrcNames = []string{
+	"fmt", "goavro", "big", "io", "json", "sync", "time",
+	"alreadyEncoded", "arrayValues", "blockCount", "buf", "buflen", "codec",
+	"config", "depth", "e", "err", "f", "i", "idx", "ior", "k", "key", "keyCount",
+	"mapValues", "newBuf", "ocfr", "ocfw", "r", "remainingInBlock", "result",
+	"ret", "tmp", "tmpBuf", "u", "v", "value", "values", "w",
+}
+
+// resolveRefs replaces the references to named types in src, which is written
+// to pkg, qualifying those in other packages and adding their imports to the
+// first import declaration of src. It returns the resulting source, and the
+// symbol table keys of the unions that src uses.
+func (p *genPackages) resolveRefs(src []byte, pkg *genPackage) ([]byte, []string, error) {
+	var unions []string
+	var importSpecs bytes.Buffer
+	aliases := make(map[*genPackage]string)
+	// NOTE: Package names used by generated code, and the names of its local
+	// variables, would shadow or be shadowed by an import of the same name.
+	taken := map[string]struct{}{pkg.name: {}}
+	for _, name := range generatedSrcNames {
+		taken[name] = struct{}{}
+	}
+
+	resolved := namedRefPattern.ReplaceAllFunc(src, func(ref []byte) []byte {
+		m := namedRefPattern.FindSubmatch(ref)
+		namespace, ident := string(m[1]), string(m[2])
+		if strings.HasPrefix(namespace, unionSymbolTablePrefix) {
+			unions = append(unions, namespace)
+			return m[2]
+		}
+		target := p.forNamespace(namespace)
+		if target == pkg {
+			return m[2]
+		}
+		alias, ok := aliases[target]
+		if !ok {
+			alias = target.name
+			for i := 2; ; i++ {
+				if _, ok := taken[alias]; !ok {
+					break
 				}
-				f.Sync()
-				f.Close()
+				alias = fmt.Sprintf("%s%d", target.name, i)
 			}
+			taken[alias] = struct{}{}
+			aliases[target] = alias
+			if alias == target.name {
+				importSpecs.WriteString(fmt.Sprintf("%q\n", target.importPath))
+			} else {
+				importSpecs.WriteString(fmt.Sprintf("%s %q\n", alias, target.importPath))
+			}
+
+			if p.imports[pkg] == nil {
+				p.imports[pkg] = make(map[*genPackage]struct{})
+			}
+			p.imports[pkg][target] = struct{}{}
 		}
+		return []byte(alias + "." + ident)
+	})
+
+	if importSpecs.Len() > 0 {
+		decl := []byte("import (\n")
+		i := bytes.Index(resolved, decl)
+		if i < 0 {
+			return nil, nil, errors.New("cannot add imports: source has no import declaration")
+		}
+		i += len(decl)
+		resolved = append(resolved[:i:i], append(importSpecs.Bytes(), resolved[i:]...)...)
 	}
 
+	return resolved, unions, nil
+}
+
+// checkImportCycles returns an error when the generated packages would import
+// each other.
+func (p *genPackages) checkImportCycles() error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[*genPackage]int)
+	var stack []string
+
+	var visit func(pkg *genPackage) error
+	visit = func(pkg *genPackage) error {
+		stack = append(stack, pkg.importPath)
+		switch state[pkg] {
+		case visiting:
+			return fmt.Errorf("cannot generate code: packages would import each other: %s", strings.Join(stack, " -> "))
+		case visited:
+			stack = stack[:len(stack)-1]
+			return nil
+		}
+		state[pkg] = visiting
+		imported := make([]*genPackage, 0, len(p.imports[pkg]))
+		for dep := range p.imports[pkg] {
+			imported = append(imported, dep)
+		}
+		sort.Slice(imported, func(i, j int) bool { return imported[i].importPath < imported[j].importPath })
+		for _, dep := range imported {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[pkg] = visited
+		stack = stack[:len(stack)-1]
+		return nil
+	}
+
+	importers := make([]*genPackage, 0, len(p.imports))
+	for pkg := range p.imports {
+		importers = append(importers, pkg)
+	}
+	sort.Slice(importers, func(i, j int) bool { return importers[i].importPath < importers[j].importPath })
+	for _, pkg := range importers {
+		if err := visit(pkg); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func generateCodecs(symbolTable map[string]*Codec, inputFiles []string, verbose bool) ([]string, error) {
-	errFiles := make([]string, 0)
+// isGoIdentifier returns true when s is a Go identifier.
+func isGoIdentifier(s string) bool {
+	if s == "" || token.Lookup(s).IsKeyword() {
+		return false
+	}
+	for i, r := range s {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return true
+}
+
+// generateCodecs builds the codecs for each of the specified input files,
+// adding the types they define to the symbol table. It returns a
+// *GenerateError for each file whose codec could not be built, which may
+// succeed on a later attempt after other files define the types it refers to.
+// It only returns an error when a file cannot be read, or its JSON cannot be
+// unmarshaled, because other files cannot help with those.
+func generateCodecs(symbolTable map[string]*Codec, inputFiles []string, log io.Writer) ([]*GenerateError, error) {
+	var failures []*GenerateError
 
 	for _, f := range inputFiles {
 		schemaSpec, err := ioutil.ReadFile(f)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read file: %s", f)
+			return nil, &GenerateError{File: f, Err: fmt.Errorf("cannot read schema: %s", err)}
 		}
 
-		_, err = newCodecWithSymbolTable(string(schemaSpec), symbolTable)
-		if err != nil {
-			if verbose {
-				fmt.Printf("failed to build codec, file: %s, reason: %v\n", f, err)
-			}
-			errFiles = append(errFiles, f)
-		} else {
-			if verbose {
-				fmt.Println("parsed", f)
-			}
+		var schema interface{}
+		if err = json.Unmarshal(schemaSpec, &schema); err != nil {
+			return nil, &GenerateError{File: f, Err: fmt.Errorf("cannot unmarshal schema JSON: %s", err)}
+		}
+
+		if _, err = newCodecWithSymbolTable(string(schemaSpec), symbolTable); err != nil {
+			failure := &GenerateError{File: f, SchemaPath: unresolvedTypePath(symbolTable, nullNamespace, schema, ""), Err: err}
+			logf(log, "%s\n", failure)
+			failures = append(failures, failure)
+			continue
 		}
+		logf(log, "parsed %s\n", f)
 	}
 
-	return errFiles, nil
+	return failures, nil
 }
 
 func newCodecWithSymbolTable(schemaSpecification string, st map[string]*Codec) (*Codec, error) {
@@ -118,6 +639,188 @@ func newCodecWithSymbolTable(schemaSpecification string, st map[string]*Codec) (
 	return c, nil
 }
 
+// standaloneSchema returns the schema that defines the named type of the
+// codec, with every name fully qualified, and with the definition of each named
+// type it refers to inlined where that type is first referred to, so that the
+// schema may be used without the schemas that define those types.
+func standaloneSchema(st map[string]*Codec, c *Codec) interface{} {
+	schema, _ := inlineNamedTypes(st, c.generator.enclosingNamespace, c.generator.schema)
+	return schema
+}
+
+// inlineNamedTypes returns the schema, within the namespace enclosingNamespace,
+// with every name fully qualified, and with the definition of each named type
+// of the symbol table it refers to without defining it inlined where that type
+// is first referred to. It also returns whether it inlined any definition.
+func inlineNamedTypes(st map[string]*Codec, enclosingNamespace string, schema interface{}) (interface{}, bool) {
+	var inlined bool
+	defined := make(map[string]struct{})
+	var walk func(enclosingNamespace string, schema interface{}) interface{}
+	walk = func(enclosingNamespace string, schema interface{}) interface{} {
+		switch v := schema.(type) {
+		case string:
+			candidates := []string{v}
+			if enclosingNamespace != nullNamespace && !strings.ContainsRune(v, '.') {
+				candidates = []string{enclosingNamespace + "." + v, v}
+			}
+			for _, candidate := range candidates {
+				named, ok := st[candidate]
+				if !ok || named.generator == nil || named.generator.schema == nil {
+					continue
+				}
+				if _, ok = defined[named.typeName.fullName]; ok {
+					return named.typeName.fullName
+				}
+				inlined = true
+				return walk(named.generator.enclosingNamespace, named.generator.schema)
+			}
+			return v // primitive type
+		case []interface{}:
+			members := make([]interface{}, len(v))
+			for i, member := range v {
+				members[i] = walk(enclosingNamespace, member)
+			}
+			return members
+		case map[string]interface{}:
+			result := make(map[string]interface{}, len(v))
+			for key, value := range v {
+				result[key] = value
+			}
+			switch t := v["type"]; t {
+			case "record", "error", "enum", "fixed":
+				n, err := newNameFromSchemaMap(enclosingNamespace, v)
+				if err != nil {
+					return v // should not get here because schema was validated by codec
+				}
+				defined[n.fullName] = struct{}{}
+				result["name"] = n.fullName
+				delete(result, "namespace")
+				if fields, ok := v["fields"].([]interface{}); ok {
+					resultFields := make([]interface{}, len(fields))
+					for i, field := range fields {
+						fieldMap, ok := field.(map[string]interface{})
+						if !ok {
+							resultFields[i] = field
+							continue
+						}
+						resultField := make(map[string]interface{}, len(fieldMap))
+						for key, value := range fieldMap {
+							resultField[key] = value
+						}
+						resultField["type"] = walk(n.namespace, fieldMap["type"])
+						resultFields[i] = resultField
+					}
+					result["fields"] = resultFields
+				}
+			case "array":
+				result["items"] = walk(enclosingNamespace, v["items"])
+			case "map":
+				result["values"] = walk(enclosingNamespace, v["values"])
+			default:
+				result["type"] = walk(enclosingNamespace, t)
+			}
+			return result
+		}
+		return schema
+	}
+	result := walk(enclosingNamespace, schema)
+	return result, inlined
+}
+
+// unresolvedTypePath returns the JSON pointer to the first reference in the
+// schema to a type name that is neither in the symbol table nor defined earlier
+// in the schema, or the empty string when every type name is resolved.
+func unresolvedTypePath(st map[string]*Codec, enclosingNamespace string, schema interface{}, pointer string) string {
+	defined := make(map[string]struct{})
+	var walk func(enclosingNamespace string, schema interface{}, pointer string) string
+	resolved := func(enclosingNamespace, typeName string) bool {
+		candidates := []string{typeName}
+		if enclosingNamespace != nullNamespace {
+			candidates = append(candidates, enclosingNamespace+"."+typeName)
+		}
+		for _, candidate := range candidates {
+			if _, ok := st[candidate]; ok {
+				return true
+			}
+			if _, ok := defined[candidate]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	walk = func(enclosingNamespace string, schema interface{}, pointer string) string {
+		switch v := schema.(type) {
+		case string:
+			if !resolved(enclosingNamespace, v) {
+				return pointer
+			}
+		case []interface{}:
+			for i, member := range v {
+				if p := walk(enclosingNamespace, member, pointer+"/"+strconv.Itoa(i)); p != "" {
+					return p
+				}
+			}
+		case map[string]interface{}:
+			switch t := v["type"].(type) {
+			case string:
+				switch t {
+				case "record", "error", "enum", "fixed":
+					n, err := newNameFromSchemaMap(enclosingNamespace, v)
+					if err != nil {
+						return ""
+					}
+					defined[n.fullName] = struct{}{}
+					fields, _ := v["fields"].([]interface{})
+					for i, field := range fields {
+						if fieldMap, ok := field.(map[string]interface{}); ok {
+							if p := walk(n.namespace, fieldMap["type"], fmt.Sprintf("%s/fields/%d/type", pointer, i)); p != "" {
+								return p
+							}
+						}
+					}
+				case "array":
+					return walk(enclosingNamespace, v["items"], pointer+"/items")
+				case "map":
+					return walk(enclosingNamespace, v["values"], pointer+"/values")
+				default:
+					return walk(enclosingNamespace, t, pointer+"/type")
+				}
+			default:
+				return walk(enclosingNamespace, t, pointer+"/type")
+			}
+		}
+		return ""
+	}
+	return walk(enclosingNamespace, schema, pointer)
+}
+
+// sortedCodecNames returns the keys of the symbol table in order.
+func sortedCodecNames(st map[string]*Codec) []string {
+	names := make([]string, 0, len(st))
+	for name := range st {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedSourceNames returns the file names of the generated sources in order.
+func sortedSourceNames(sources map[string][]byte) []string {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// logf writes the formatted message to log, when log is not nil.
+func logf(log io.Writer, format string, a ...interface{}) {
+	if log != nil {
+		fmt.Fprintf(log, format, a...)
+	}
+}
+
 func toSnake(in string) string {
 	runes := []rune(in)
 	length := len(runes)
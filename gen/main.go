@@ -1,26 +1,62 @@
+// Command gen generates Go types for Avro schema files.
+//
+// Deprecated: gen is kept so that existing scripts keep working, and accepts
+// only a list of schema files. Use the avrogen command instead, which accepts
+// the same flags as well as directories, globs and a configuration file.
 package main
 
 import (
-	"fmt"
-
 	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/peak6/goavro/v2"
 )
 
+// namespaceFlag collects the namespace=importpath mappings of repeated -ns
+// flags.
+type namespaceFlag map[string]string
+
+func (f namespaceFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for namespace, importPath := range f {
+		pairs = append(pairs, namespace+"="+importPath)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f namespaceFlag) Set(value string) error {
+	i := strings.IndexByte(value, '=')
+	if i <= 0 || i == len(value)-1 {
+		return fmt.Errorf("namespace mapping ought to be namespace=importpath: %q", value)
+	}
+	f[value[:i]] = value[i+1:]
+	return nil
+}
+
 func main() {
 	var outputDir string
-	var packageName string
 	var verbose bool
+	config := goavro.GenerateConfig{Namespaces: make(namespaceFlag)}
 	flag.StringVar(&outputDir, "o", ".", "The directory in which to write the generated files")
-	flag.StringVar(&packageName, "p", "records", "The name of the package for generated files")
+	flag.StringVar(&config.PackageName, "p", "records", "The name of the package for generated files")
+	flag.StringVar(&config.ImportPath, "import", "", "The import path of the package in the output directory, required by -ns")
+	flag.Var(namespaceFlag(config.Namespaces), "ns", "Map an Avro namespace to the import path of its package, as namespace=importpath; may be repeated")
 	flag.BoolVar(&verbose, "v", false, "Enable verbose logging")
 	flag.Parse()
 
+	fmt.Fprintln(os.Stderr, "gen is deprecated: use avrogen, which accepts the same flags")
 	files := flag.Args()
 
-	err := goavro.Generate(packageName, files, outputDir, verbose)
-	if err != nil {
-		fmt.Printf("failed to generate, reason: %v", err)
+	var log io.Writer
+	if verbose {
+		log = os.Stderr
+	}
+
+	if err := goavro.Generate(config, files, outputDir, log); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to generate, reason: %v\n", err)
+		os.Exit(1)
 	}
 }
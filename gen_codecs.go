@@ -2,20 +2,201 @@ package goavro
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type CodecGenerator struct {
-	writeDecoderSrc          func(w io.Writer) error
+	writeSrc                 func(w io.Writer) error
 	genDecodeInstanceSrc     func() string
 	genNativeTypeNameSrc     func() string
 	genNativeDefaultValueSrc func() string
 	getImports               func() []string
 	isWritable               bool
-	genDecodePtrInstanceSrc func() string
-	genNativeTypeNamePtrSrc func() string
+	genDecodePtrInstanceSrc  func() string
+	genNativeTypeNamePtrSrc  func() string
+
+	// genEncodeInstanceSrc and genEncodePtrInstanceSrc return the source of a
+	// function that appends the binary encoding of a value of the native type,
+	// or of the native pointer type, to a byte slice.
+	genEncodeInstanceSrc    func() string
+	genEncodePtrInstanceSrc func() string
+
+	// goTypeName is the name of the Go type declared by writeSrc, and
+	// declaredIdents are all of the package level identifiers it declares,
+	// for generators that are writable.
+	goTypeName     string
+	declaredIdents []string
+
+	// rename, for generators of named types, changes the name of the Go type
+	// declared by writeSrc, and the identifiers derived from it, from the name
+	// derived from the Avro name of the type.
+	rename func(goTypeName string)
+
+	// schema is the schema that defines a named type, within the namespace
+	// enclosingNamespace, and standaloneSchema is that schema with the
+	// definitions of the named types it refers to inlined. The codec builder
+	// sets schema, and Generate sets standaloneSchema before writing the
+	// source for the named type.
+	schema             map[string]interface{}
+	enclosingNamespace string
+	standaloneSchema   interface{}
+}
+
+// stringLiteralSrc returns the source of a Go string literal for s, which is a
+// raw string literal unless s contains characters that cannot be in one.
+func stringLiteralSrc(s string) string {
+	if strings.ContainsAny(s, "`\r") || !utf8.ValidString(s) {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// writeSchemaMethodsSrc writes the source of the Schema and Codec methods of a
+// named type, which return the canonical form of its standalone schema, and a
+// codec for it.
+func writeSchemaMethodsSrc(w io.Writer, receiver, typeName string, standaloneSchema interface{}) error {
+	schemaJSON, err := json.Marshal(standaloneSchema)
+	if err != nil {
+		return fmt.Errorf("cannot marshal schema: %s", err)
+	}
+	canonical, err := parsingCanonicalForm(standaloneSchema)
+	if err != nil {
+		return fmt.Errorf("cannot determine canonical form of schema: %s", err)
+	}
+
+	w.Write([]byte(fmt.Sprintf("// Schema returns the canonical form of the schema for %s.\n", typeName)))
+	w.Write([]byte(fmt.Sprintf("func (%s) Schema() string {\nreturn %s\n}\n\n", receiver, stringLiteralSrc(canonical))))
+
+	w.Write([]byte(fmt.Sprintf("var codecFor%s struct {\nonce sync.Once\ncodec *goavro.Codec\nerr error\n}\n\n", typeName)))
+	w.Write([]byte(fmt.Sprintf("// Codec returns the codec for the schema of %s, which is built the first time\n", typeName)))
+	w.Write([]byte("// it is requested.\n"))
+	w.Write([]byte(fmt.Sprintf("func (%s) Codec() (*goavro.Codec, error) {\n", receiver)))
+	w.Write([]byte(fmt.Sprintf("codecFor%s.once.Do(func() {\n", typeName)))
+	w.Write([]byte(fmt.Sprintf("codecFor%s.codec, codecFor%s.err = goavro.NewCodec(%s)\n", typeName, typeName, stringLiteralSrc(string(schemaJSON)))))
+	w.Write([]byte("})\n"))
+	w.Write([]byte(fmt.Sprintf("return codecFor%s.codec, codecFor%s.err\n", typeName, typeName)))
+	w.Write([]byte("}\n"))
+	return nil
+}
+
+// writeOCFWrappersSrc writes the source of the types that read and write the
+// records of a generated type from and to Avro Object Container Files, using
+// the generated decoder and encoder rather than the codec of the file.
+func writeOCFWrappersSrc(w io.Writer, typeName, fullName string) error {
+	w.Write([]byte(fmt.Sprintf("// %sOCFReader reads %s records from an Avro Object Container File.\n", typeName, typeName)))
+	w.Write([]byte(fmt.Sprintf("type %sOCFReader struct {\nocfr *goavro.OCFReader\n}\n\n", typeName)))
+
+	w.Write([]byte(fmt.Sprintf("// New%sOCFReader returns a reader of the %s records of the OCF read from\n", typeName, typeName)))
+	w.Write([]byte(fmt.Sprintf("// ior. It returns an error unless the schema of the file is the schema of %s,\n", typeName)))
+	w.Write([]byte("// or resolves to it once its names are fully qualified.\n"))
+	w.Write([]byte(fmt.Sprintf("func New%sOCFReader(ior io.Reader) (*%sOCFReader, error) {\n", typeName, typeName)))
+	w.Write([]byte("ocfr, err := goavro.NewOCFReader(ior)\n"))
+	w.Write([]byte("if err != nil {\nreturn nil, err\n}\n"))
+	w.Write([]byte(fmt.Sprintf("codec, err := (*%s)(nil).Codec()\n", typeName)))
+	w.Write([]byte("if err != nil {\nreturn nil, err\n}\n"))
+	w.Write([]byte("if err = codec.CheckSchema(ocfr.Codec()); err != nil {\n"))
+	w.Write([]byte(fmt.Sprintf("return nil, fmt.Errorf(\"cannot read %%q records from OCF: %%s\", %q, err)\n", fullName)))
+	w.Write([]byte("}\n"))
+	w.Write([]byte(fmt.Sprintf("return &%sOCFReader{ocfr: ocfr}, nil\n", typeName)))
+	w.Write([]byte("}\n\n"))
+
+	w.Write([]byte("// Scan returns true when there is at least one more record to be read, and\n"))
+	w.Write([]byte("// false when there are no more records or an error occurred.\n"))
+	w.Write([]byte(fmt.Sprintf("func (r *%sOCFReader) Scan() bool {\nreturn r.ocfr.Scan()\n}\n\n", typeName)))
+
+	w.Write([]byte(fmt.Sprintf("// Read decodes and returns the next %s record of the OCF. It ought to be\n", typeName)))
+	w.Write([]byte("// called once after each successful call to Scan.\n"))
+	w.Write([]byte(fmt.Sprintf("func (r *%sOCFReader) Read() (*%s, error) {\n", typeName, typeName)))
+	w.Write([]byte(fmt.Sprintf("var result *%s\n", typeName)))
+	w.Write([]byte("err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {\n"))
+	w.Write([]byte("var err error\n"))
+	w.Write([]byte(fmt.Sprintf("result, buf, err = New%s(buf)\n", typeName)))
+	w.Write([]byte("return buf, err\n"))
+	w.Write([]byte("})\n"))
+	w.Write([]byte("return result, err\n"))
+	w.Write([]byte("}\n\n"))
+
+	w.Write([]byte("// Err returns the last error encountered while reading the OCF.\n"))
+	w.Write([]byte(fmt.Sprintf("func (r *%sOCFReader) Err() error {\nreturn r.ocfr.Err()\n}\n\n", typeName)))
+
+	w.Write([]byte("// OCFReader returns the underlying OCFReader.\n"))
+	w.Write([]byte(fmt.Sprintf("func (r *%sOCFReader) OCFReader() *goavro.OCFReader {\nreturn r.ocfr\n}\n\n", typeName)))
+
+	w.Write([]byte(fmt.Sprintf("// %sOCFWriter appends %s records to an Avro Object Container File.\n", typeName, typeName)))
+	w.Write([]byte(fmt.Sprintf("type %sOCFWriter struct {\nocfw *goavro.OCFWriter\n}\n\n", typeName)))
+
+	w.Write([]byte(fmt.Sprintf("// New%sOCFWriter returns a writer of %s records to the OCF specified by\n", typeName, typeName)))
+	w.Write([]byte(fmt.Sprintf("// config. When config specifies neither a Codec nor a Schema, the codec of %s\n", typeName)))
+	w.Write([]byte("// is used. It returns an error unless the schema of the file is the schema of\n"))
+	w.Write([]byte(fmt.Sprintf("// %s, or resolves to it once its names are fully qualified.\n", typeName)))
+	w.Write([]byte(fmt.Sprintf("func New%sOCFWriter(config goavro.OCFConfig) (*%sOCFWriter, error) {\n", typeName, typeName)))
+	w.Write([]byte(fmt.Sprintf("codec, err := (*%s)(nil).Codec()\n", typeName)))
+	w.Write([]byte("if err != nil {\nreturn nil, err\n}\n"))
+	w.Write([]byte("if config.Codec == nil && config.Schema == \"\" {\nconfig.Codec = codec\n}\n"))
+	w.Write([]byte("ocfw, err := goavro.NewOCFWriter(config)\n"))
+	w.Write([]byte("if err != nil {\nreturn nil, err\n}\n"))
+	w.Write([]byte("if err = codec.CheckSchema(ocfw.Codec()); err != nil {\n"))
+	w.Write([]byte(fmt.Sprintf("return nil, fmt.Errorf(\"cannot write %%q records to OCF: %%s\", %q, err)\n", fullName)))
+	w.Write([]byte("}\n"))
+	w.Write([]byte(fmt.Sprintf("return &%sOCFWriter{ocfw: ocfw}, nil\n", typeName)))
+	w.Write([]byte("}\n\n"))
+
+	w.Write([]byte(fmt.Sprintf("// Append encodes the %s records and appends them to the OCF, as a single\n", typeName)))
+	w.Write([]byte("// block unless there are more than goavro.MaxBlockCount records.\n"))
+	w.Write([]byte(fmt.Sprintf("func (w *%sOCFWriter) Append(values ...*%s) error {\n", typeName, typeName)))
+	w.Write([]byte("return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {\n"))
+	w.Write([]byte("return values[i].MarshalAvro(buf)\n"))
+	w.Write([]byte("})\n"))
+	w.Write([]byte("}\n\n"))
+
+	w.Write([]byte("// OCFWriter returns the underlying OCFWriter.\n"))
+	w.Write([]byte(fmt.Sprintf("func (w *%sOCFWriter) OCFWriter() *goavro.OCFWriter {\nreturn w.ocfw\n}\n", typeName)))
+	return nil
+}
+
+// namedRefSrc returns the source of a reference to the Go identifier ident,
+// declared for a type in the specified Avro namespace. Because the package a
+// namespace is written to is only known when the source is written to a file,
+// the reference is marked with the namespace, and Generate replaces the mark
+// with the package qualifier, if any, for the file being written.
+func namedRefSrc(namespace, ident string) string {
+	return namedRefMark + namespace + namedRefMark + ident
+}
+
+// namedRefMark delimits the namespace of references returned by namedRefSrc.
+// It cannot be part of an Avro namespace or of generated source code.
+const namedRefMark = "\x1b"
+
+// derefEncoderSrc returns the source of a function that encodes the value
+// referenced by a pointer, using the source of the encoder for the value.
+func derefEncoderSrc(ptrTypeName, encoderSrc string) string {
+	return fmt.Sprintf("func(buf []byte, v %s) ([]byte, error) {\nreturn %s(buf, *v)\n}", ptrTypeName, encoderSrc)
+}
+
+// refDecoderSrc returns the source of a function that decodes a value and
+// returns a pointer to it, using the source of the decoder for the value.
+func refDecoderSrc(ptrTypeName, decoderSrc string) string {
+	return fmt.Sprintf("func(buf []byte) (%s, []byte, error) {\nv, newBuf, err := %s(buf)\nif err != nil {\nreturn nil, buf, err\n}\nreturn &v, newBuf, nil\n}",
+		ptrTypeName, decoderSrc)
+}
+
+func NewNullCodecGenerator() *CodecGenerator {
+	return &CodecGenerator{
+		genDecodeInstanceSrc:     func() string { return "func(buf []byte) (struct{}, []byte, error) {\nreturn struct{}{}, buf, nil\n}" },
+		genNativeTypeNameSrc:     func() string { return "struct{}" },
+		genNativeDefaultValueSrc: func() string { return "struct{}{}" },
+		getImports:               func() []string { return []string{} },
+		genDecodePtrInstanceSrc:  func() string { return "func(buf []byte) (*struct{}, []byte, error) {\nreturn &struct{}{}, buf, nil\n}" },
+		genNativeTypeNamePtrSrc:  func() string { return "*struct{}" },
+		genEncodeInstanceSrc:     func() string { return "func(buf []byte, _ struct{}) ([]byte, error) {\nreturn buf, nil\n}" },
+		genEncodePtrInstanceSrc:  func() string { return "func(buf []byte, _ *struct{}) ([]byte, error) {\nreturn buf, nil\n}" },
+	}
 }
 
 func NewBoolCodecGenerator() *CodecGenerator {
@@ -24,8 +205,10 @@ func NewBoolCodecGenerator() *CodecGenerator {
 		genNativeTypeNameSrc:     func() string { return "bool" },
 		genNativeDefaultValueSrc: func() string { return "false" },
 		getImports:               func() []string { return []string{} },
-		genDecodePtrInstanceSrc: func() string { return "goavro.BoolNativePtrFromBinary" },
-		genNativeTypeNamePtrSrc: func() string { return "*bool" },
+		genDecodePtrInstanceSrc:  func() string { return "goavro.BoolNativePtrFromBinary" },
+		genNativeTypeNamePtrSrc:  func() string { return "*bool" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BoolBinaryFromNative" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*bool", "goavro.BoolBinaryFromNative") },
 	}
 }
 
@@ -35,8 +218,10 @@ func NewDoubleCodecGenerator() *CodecGenerator {
 		genNativeTypeNameSrc:     func() string { return "float64" },
 		genNativeDefaultValueSrc: func() string { return "0.0" },
 		getImports:               func() []string { return []string{} },
-		genDecodePtrInstanceSrc: func() string { return "goavro.DoubleNativePtrFromBinary" },
-		genNativeTypeNamePtrSrc: func() string { return "*float64" },
+		genDecodePtrInstanceSrc:  func() string { return "goavro.DoubleNativePtrFromBinary" },
+		genNativeTypeNamePtrSrc:  func() string { return "*float64" },
+		genEncodeInstanceSrc:     func() string { return "goavro.DoubleBinaryFromNative" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*float64", "goavro.DoubleBinaryFromNative") },
 	}
 }
 
@@ -46,8 +231,10 @@ func NewFloatCodecGenerator() *CodecGenerator {
 		genNativeTypeNameSrc:     func() string { return "float32" },
 		genNativeDefaultValueSrc: func() string { return "0.0" },
 		getImports:               func() []string { return []string{} },
-		genDecodePtrInstanceSrc: func() string { return "goavro.FloatNativePtrFromBinary" },
-		genNativeTypeNamePtrSrc: func() string { return "*float32" },
+		genDecodePtrInstanceSrc:  func() string { return "goavro.FloatNativePtrFromBinary" },
+		genNativeTypeNamePtrSrc:  func() string { return "*float32" },
+		genEncodeInstanceSrc:     func() string { return "goavro.FloatBinaryFromNative" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*float32", "goavro.FloatBinaryFromNative") },
 	}
 }
 
@@ -57,8 +244,10 @@ func NewLongCodecGenerator() *CodecGenerator {
 		genNativeTypeNameSrc:     func() string { return "int64" },
 		genNativeDefaultValueSrc: func() string { return "0" },
 		getImports:               func() []string { return []string{} },
-		genDecodePtrInstanceSrc: func() string { return "goavro.LongNativePtrFromBinary" },
-		genNativeTypeNamePtrSrc: func() string { return "*int64" },
+		genDecodePtrInstanceSrc:  func() string { return "goavro.LongNativePtrFromBinary" },
+		genNativeTypeNamePtrSrc:  func() string { return "*int64" },
+		genEncodeInstanceSrc:     func() string { return "goavro.LongBinaryFromNative" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*int64", "goavro.LongBinaryFromNative") },
 	}
 }
 
@@ -68,8 +257,10 @@ func NewIntCodecGenerator() *CodecGenerator {
 		genNativeTypeNameSrc:     func() string { return "int32" },
 		genNativeDefaultValueSrc: func() string { return "0" },
 		getImports:               func() []string { return []string{} },
-		genDecodePtrInstanceSrc: func() string { return "goavro.IntNativePtrFromBinary" },
-		genNativeTypeNamePtrSrc: func() string { return "*int32" },
+		genDecodePtrInstanceSrc:  func() string { return "goavro.IntNativePtrFromBinary" },
+		genNativeTypeNamePtrSrc:  func() string { return "*int32" },
+		genEncodeInstanceSrc:     func() string { return "goavro.IntBinaryFromNative" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*int32", "goavro.IntBinaryFromNative") },
 	}
 }
 
@@ -79,8 +270,127 @@ func NewStringCodecGenerator() *CodecGenerator {
 		genNativeTypeNameSrc:     func() string { return "string" },
 		genNativeDefaultValueSrc: func() string { return "\"\"" },
 		getImports:               func() []string { return []string{} },
-		genDecodePtrInstanceSrc: func() string { return "goavro.StringNativePtrFromBinary" },
-		genNativeTypeNamePtrSrc: func() string { return "*string" },
+		genDecodePtrInstanceSrc:  func() string { return "goavro.StringNativePtrFromBinary" },
+		genNativeTypeNamePtrSrc:  func() string { return "*string" },
+		genEncodeInstanceSrc:     func() string { return "goavro.StringBinaryFromNative" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*string", "goavro.StringBinaryFromNative") },
+	}
+}
+
+func NewBytesCodecGenerator() *CodecGenerator {
+	return &CodecGenerator{
+		genDecodeInstanceSrc:     func() string { return "goavro.BytesNativeFromBinary" },
+		genNativeTypeNameSrc:     func() string { return "[]byte" },
+		genNativeDefaultValueSrc: func() string { return "[]byte{}" },
+		getImports:               func() []string { return []string{} },
+		genDecodePtrInstanceSrc:  func() string { return "goavro.BytesNativeFromBinary" },
+		genNativeTypeNamePtrSrc:  func() string { return "[]byte" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BytesBinaryFromNative" },
+		genEncodePtrInstanceSrc:  func() string { return "goavro.BytesBinaryFromNative" },
+	}
+}
+
+func NewTimeStampMillisCodecGenerator() *CodecGenerator {
+	return &CodecGenerator{
+		genDecodeInstanceSrc:     func() string { return "goavro.NativeFromBinaryTimeStampMillis" },
+		genNativeTypeNameSrc:     func() string { return "time.Time" },
+		genNativeDefaultValueSrc: func() string { return "time.Time{}" },
+		getImports:               func() []string { return []string{"time"} },
+		genDecodePtrInstanceSrc:  func() string { return refDecoderSrc("*time.Time", "goavro.NativeFromBinaryTimeStampMillis") },
+		genNativeTypeNamePtrSrc:  func() string { return "*time.Time" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BinaryFromNativeTimeStampMillis" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*time.Time", "goavro.BinaryFromNativeTimeStampMillis") },
+	}
+}
+
+func NewTimeStampMicrosCodecGenerator() *CodecGenerator {
+	return &CodecGenerator{
+		genDecodeInstanceSrc:     func() string { return "goavro.NativeFromBinaryTimeStampMicros" },
+		genNativeTypeNameSrc:     func() string { return "time.Time" },
+		genNativeDefaultValueSrc: func() string { return "time.Time{}" },
+		getImports:               func() []string { return []string{"time"} },
+		genDecodePtrInstanceSrc:  func() string { return refDecoderSrc("*time.Time", "goavro.NativeFromBinaryTimeStampMicros") },
+		genNativeTypeNamePtrSrc:  func() string { return "*time.Time" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BinaryFromNativeTimeStampMicros" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*time.Time", "goavro.BinaryFromNativeTimeStampMicros") },
+	}
+}
+
+func NewLocalTimeStampMillisCodecGenerator() *CodecGenerator {
+	return &CodecGenerator{
+		genDecodeInstanceSrc:     func() string { return "goavro.NativeFromBinaryTimeStampMillis" },
+		genNativeTypeNameSrc:     func() string { return "time.Time" },
+		genNativeDefaultValueSrc: func() string { return "time.Time{}" },
+		getImports:               func() []string { return []string{"time"} },
+		genDecodePtrInstanceSrc:  func() string { return refDecoderSrc("*time.Time", "goavro.NativeFromBinaryTimeStampMillis") },
+		genNativeTypeNamePtrSrc:  func() string { return "*time.Time" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BinaryFromNativeLocalTimeStampMillis" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*time.Time", "goavro.BinaryFromNativeLocalTimeStampMillis") },
+	}
+}
+
+func NewLocalTimeStampMicrosCodecGenerator() *CodecGenerator {
+	return &CodecGenerator{
+		genDecodeInstanceSrc:     func() string { return "goavro.NativeFromBinaryTimeStampMicros" },
+		genNativeTypeNameSrc:     func() string { return "time.Time" },
+		genNativeDefaultValueSrc: func() string { return "time.Time{}" },
+		getImports:               func() []string { return []string{"time"} },
+		genDecodePtrInstanceSrc:  func() string { return refDecoderSrc("*time.Time", "goavro.NativeFromBinaryTimeStampMicros") },
+		genNativeTypeNamePtrSrc:  func() string { return "*time.Time" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BinaryFromNativeLocalTimeStampMicros" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*time.Time", "goavro.BinaryFromNativeLocalTimeStampMicros") },
+	}
+}
+
+func NewUUIDCodecGenerator() *CodecGenerator {
+	return &CodecGenerator{
+		genDecodeInstanceSrc:     func() string { return "goavro.NativeFromBinaryUUID" },
+		genNativeTypeNameSrc:     func() string { return "string" },
+		genNativeDefaultValueSrc: func() string { return "\"\"" },
+		getImports:               func() []string { return []string{} },
+		genDecodePtrInstanceSrc:  func() string { return refDecoderSrc("*string", "goavro.NativeFromBinaryUUID") },
+		genNativeTypeNamePtrSrc:  func() string { return "*string" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BinaryFromNativeUUID" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*string", "goavro.BinaryFromNativeUUID") },
+	}
+}
+
+func NewDurationCodecGenerator() *CodecGenerator {
+	return &CodecGenerator{
+		genDecodeInstanceSrc:     func() string { return "goavro.NativeFromBinaryDuration" },
+		genNativeTypeNameSrc:     func() string { return "goavro.Duration" },
+		genNativeDefaultValueSrc: func() string { return "goavro.Duration{}" },
+		getImports:               func() []string { return []string{} },
+		genDecodePtrInstanceSrc:  func() string { return refDecoderSrc("*goavro.Duration", "goavro.NativeFromBinaryDuration") },
+		genNativeTypeNamePtrSrc:  func() string { return "*goavro.Duration" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BinaryFromNativeDuration" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*goavro.Duration", "goavro.BinaryFromNativeDuration") },
+	}
+}
+
+func NewTimeMillisCodecGenerator() *CodecGenerator {
+	return &CodecGenerator{
+		genDecodeInstanceSrc:     func() string { return "goavro.NativeFromBinaryTimeMillis" },
+		genNativeTypeNameSrc:     func() string { return "time.Duration" },
+		genNativeDefaultValueSrc: func() string { return "0" },
+		getImports:               func() []string { return []string{"time"} },
+		genDecodePtrInstanceSrc:  func() string { return refDecoderSrc("*time.Duration", "goavro.NativeFromBinaryTimeMillis") },
+		genNativeTypeNamePtrSrc:  func() string { return "*time.Duration" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BinaryFromNativeTimeMillis" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*time.Duration", "goavro.BinaryFromNativeTimeMillis") },
+	}
+}
+
+func NewTimeMicrosCodecGenerator() *CodecGenerator {
+	return &CodecGenerator{
+		genDecodeInstanceSrc:     func() string { return "goavro.NativeFromBinaryTimeMicros" },
+		genNativeTypeNameSrc:     func() string { return "time.Duration" },
+		genNativeDefaultValueSrc: func() string { return "0" },
+		getImports:               func() []string { return []string{"time"} },
+		genDecodePtrInstanceSrc:  func() string { return refDecoderSrc("*time.Duration", "goavro.NativeFromBinaryTimeMicros") },
+		genNativeTypeNamePtrSrc:  func() string { return "*time.Duration" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BinaryFromNativeTimeMicros" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*time.Duration", "goavro.BinaryFromNativeTimeMicros") },
 	}
 }
 
@@ -90,13 +400,15 @@ func NewIntDateCodecGenerator() *CodecGenerator {
 		genNativeTypeNameSrc:     func() string { return "time.Time" },
 		genNativeDefaultValueSrc: func() string { return "time.Time{}" },
 		getImports:               func() []string { return []string{"time"} },
-		genDecodePtrInstanceSrc: func() string { return "goavro.NativePtrFromBinaryDate" },
-		genNativeTypeNamePtrSrc: func() string { return "*time.Time" },
+		genDecodePtrInstanceSrc:  func() string { return "goavro.NativePtrFromBinaryDate" },
+		genNativeTypeNamePtrSrc:  func() string { return "*time.Time" },
+		genEncodeInstanceSrc:     func() string { return "goavro.BinaryFromNativeDate" },
+		genEncodePtrInstanceSrc:  func() string { return derefEncoderSrc("*time.Time", "goavro.BinaryFromNativeDate") },
 	}
 }
 
 func NewDecimalBytesCodecGenerator(precision, scale int) *CodecGenerator {
-	return &CodecGenerator{
+	gen := &CodecGenerator{
 		getImports:               func() []string { return []string{"math/big"} },
 		genNativeTypeNameSrc:     func() string { return "*big.Rat" },
 		genNativeDefaultValueSrc: func() string { return "&big.Rat{}" },
@@ -109,28 +421,59 @@ func NewDecimalBytesCodecGenerator(precision, scale int) *CodecGenerator {
 				precision, scale)
 		},
 		genNativeTypeNamePtrSrc: func() string { return "*big.Rat" },
+		genEncodeInstanceSrc: func() string {
+			return fmt.Sprintf("func (buf []byte, r *big.Rat) ([]byte, error) {\nreturn goavro.BinaryFromNativeDecimalBytes(buf, r, %d, %d)\n}",
+				precision, scale)
+		},
+	}
+	gen.genEncodePtrInstanceSrc = gen.genEncodeInstanceSrc
+	return gen
+}
+
+func NewDecimalFixedCodecGenerator(size uint, precision, scale int) *CodecGenerator {
+	gen := &CodecGenerator{
+		getImports:               func() []string { return []string{"math/big"} },
+		genNativeTypeNameSrc:     func() string { return "*big.Rat" },
+		genNativeDefaultValueSrc: func() string { return "&big.Rat{}" },
+		genDecodeInstanceSrc: func() string {
+			return fmt.Sprintf("func (buf []byte) (*big.Rat, []byte, error) {\nreturn goavro.NativeFromBinaryDecimalFixed(buf, %d, %d, %d)\n}",
+				size, precision, scale)
+		},
+		genNativeTypeNamePtrSrc: func() string { return "*big.Rat" },
+		genEncodeInstanceSrc: func() string {
+			return fmt.Sprintf("func (buf []byte, r *big.Rat) ([]byte, error) {\nreturn goavro.BinaryFromNativeDecimalFixed(buf, r, %d, %d, %d)\n}",
+				size, precision, scale)
+		},
 	}
+	gen.genDecodePtrInstanceSrc = gen.genDecodeInstanceSrc
+	gen.genEncodePtrInstanceSrc = gen.genEncodeInstanceSrc
+	return gen
 }
 
 func NewUnionCodecGenerator(codecFromIndex []*Codec) (*CodecGenerator, error) {
 	var realCodec *Codec
+	var realIndex, nullIndex int
 
+	// Only unions with 2 values, one of which is null, are represented by a
+	// pointer to the other value. Every other union is represented by a
+	// generated tagged struct.
 	if len(codecFromIndex) != 2 {
-		return nil, fmt.Errorf("the codec generator ONLY supports unions with 2 values, one of which must be null")
+		return newTaggedUnionCodecGenerator(codecFromIndex), nil
 	}
 	if codecFromIndex[0].typeName.fullName != "null" && codecFromIndex[1].typeName.fullName == "null" {
-		realCodec = codecFromIndex[0]
+		realIndex, nullIndex = 0, 1
 	} else if codecFromIndex[1].typeName.fullName != "null" && codecFromIndex[0].typeName.fullName == "null" {
-		realCodec = codecFromIndex[1]
+		realIndex, nullIndex = 1, 0
 	} else {
-		return nil, fmt.Errorf("invalid union configuration for codec generator %s -- %s", codecFromIndex[0].typeName.fullName, codecFromIndex[1].typeName.fullName)
+		return newTaggedUnionCodecGenerator(codecFromIndex), nil
 	}
+	realCodec = codecFromIndex[realIndex]
 
 	gen := &CodecGenerator{
 		getImports:               func() []string { return append([]string{"fmt"}, realCodec.generator.getImports()...) },
 		genNativeTypeNameSrc:     func() string { return realCodec.generator.genNativeTypeNamePtrSrc() },
 		genNativeDefaultValueSrc: func() string { return realCodec.generator.genNativeDefaultValueSrc() },
-		genNativeTypeNamePtrSrc: func() string { return realCodec.generator.genNativeTypeNamePtrSrc() },
+		genNativeTypeNamePtrSrc:  func() string { return realCodec.generator.genNativeTypeNamePtrSrc() },
 	}
 
 	gen.genDecodeInstanceSrc = func() string {
@@ -159,9 +502,230 @@ func NewUnionCodecGenerator(codecFromIndex []*Codec) (*CodecGenerator, error) {
 		return w.String()
 	}
 
+	gen.genEncodeInstanceSrc = func() string {
+		var w bytes.Buffer
+
+		w.WriteString(fmt.Sprintf("func(buf []byte, v %s) ([]byte, error) {\n", gen.genNativeTypeNamePtrSrc()))
+		w.WriteString("if v == nil {\n")
+		w.WriteString(fmt.Sprintf("return goavro.LongBinaryFromNative(buf, %d)\n", nullIndex))
+		w.WriteString("}\n")
+		w.WriteString(fmt.Sprintf("buf, _ = goavro.LongBinaryFromNative(buf, %d)\n", realIndex))
+		w.WriteString(fmt.Sprintf("return %s(buf, v)\n", realCodec.generator.genEncodePtrInstanceSrc()))
+		w.WriteString("}")
+
+		return w.String()
+	}
+	gen.genEncodePtrInstanceSrc = gen.genEncodeInstanceSrc
+
 	return gen, nil
 }
 
+// unionBranchName returns the Go identifier used to name the union branch for
+// the specified codec, e.g. "Null", "String", "Address", or
+// "LongTimestampMillis".
+func unionBranchName(c *Codec) string {
+	if c.typeName.namespace != nullNamespace {
+		return goIdentifier(c.typeName.short())
+	}
+	return goIdentifier(c.typeName.fullName)
+}
+
+// goIdentifier returns the exported Go identifier for the Avro name, by
+// removing the characters that separate its words and capitalizing the first
+// letter of each word, e.g. "Order" for "order", "OrderId" for "order_id", and
+// "Id" for "_id". Because exported identifiers begin with an upper case letter,
+// they never collide with Go keywords; a name without a leading letter, e.g.
+// "_1", is prefixed with "X".
+func goIdentifier(avroName string) string {
+	var w bytes.Buffer
+	for _, part := range strings.FieldsFunc(avroName, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	}) {
+		r, size := utf8.DecodeRuneInString(part)
+		w.WriteRune(unicode.ToUpper(r))
+		w.WriteString(part[size:])
+	}
+	if r, _ := utf8.DecodeRune(w.Bytes()); !unicode.IsUpper(r) {
+		return "X" + w.String()
+	}
+	return w.String()
+}
+
+// ensureDistinctIdents returns an error when two of the Avro names, of the kind
+// described by what, map to the same Go identifier.
+func ensureDistinctIdents(what string, avroNames, idents []string) error {
+	nameFromIdent := make(map[string]string, len(idents))
+	for i, ident := range idents {
+		if other, ok := nameFromIdent[ident]; ok {
+			return fmt.Errorf("%s %q and %q would both be named %q", what, other, avroNames[i], ident)
+		}
+		nameFromIdent[ident] = avroNames[i]
+	}
+	return nil
+}
+
+// newTaggedUnionCodecGenerator returns a generator for a union that is not
+// merely a nullable value. The union is represented by a generated struct that
+// records the index of the branch it holds, along with the value for that
+// branch. Each branch has type-safe methods to test for and set its value.
+func newTaggedUnionCodecGenerator(codecFromIndex []*Codec) *CodecGenerator {
+	branchNames := make([]string, len(codecFromIndex))
+	for i, c := range codecFromIndex {
+		branchNames[i] = unionBranchName(c)
+	}
+	typeName := "Union" + strings.Join(branchNames, "")
+	// NOTE: Generate writes the type for a union to each package that uses
+	// it, so its references are marked with the symbol table key of the union
+	// rather than with a namespace.
+	ref := func(ident string) string { return namedRefSrc(unionSymbolTablePrefix+typeName, ident) }
+
+	gen := &CodecGenerator{
+		getImports:               func() []string { return []string{} },
+		genNativeTypeNameSrc:     func() string { return ref(typeName) },
+		genNativeTypeNamePtrSrc:  func() string { return "*" + ref(typeName) },
+		genNativeDefaultValueSrc: func() string { return ref(typeName) + "{}" },
+		isWritable:               true,
+		goTypeName:               typeName,
+		declaredIdents:           []string{typeName, "New" + typeName, "New" + typeName + "AtDepth"},
+	}
+
+	gen.genDecodeInstanceSrc = func() string {
+		return fmt.Sprintf("func(buf []byte) (%s, []byte, error) {\nreturn %s(buf, depth)\n}", ref(typeName), ref("New"+typeName+"AtDepth"))
+	}
+
+	gen.writeSrc = func(w io.Writer) error {
+		allowedTypes := unionAllowedTypes(codecFromIndex)
+		if err := ensureDistinctIdents("members", allowedTypes, branchNames); err != nil {
+			return err
+		}
+
+		imports := make([]string, 0)
+		for _, c := range codecFromIndex {
+			if c.generator.getImports != nil {
+				imports = append(imports, c.generator.getImports()...)
+			}
+		}
+		imports = append(imports, "fmt", "github.com/peak6/goavro/v2")
+
+		w.Write([]byte("import (\n"))
+		for _, imp := range imports {
+			w.Write([]byte(fmt.Sprintf("\"%s\"\n", imp)))
+		}
+		w.Write([]byte(")\n\n"))
+
+		// Write the struct
+		w.Write([]byte(fmt.Sprintf("// %s holds exactly one of the values allowed by the union %v.\n", typeName, allowedTypes)))
+		w.Write([]byte("// Its zero value holds the zero value of the first member of the union.\n"))
+		w.Write([]byte(fmt.Sprintf("type %s struct {\n", typeName)))
+		w.Write([]byte("index int\n"))
+		for i, c := range codecFromIndex {
+			if c.typeName.fullName != "null" {
+				w.Write([]byte(fmt.Sprintf("value%s %s\n", branchNames[i], c.generator.genNativeTypeNameSrc())))
+			}
+		}
+		w.Write([]byte("}\n\n"))
+
+		// Write the accessors
+		w.Write([]byte("// Index returns the index of the union member held by the union.\n"))
+		w.Write([]byte(fmt.Sprintf("func (u %s) Index() int {\nreturn u.index\n}\n\n", typeName)))
+		for i, c := range codecFromIndex {
+			if c.typeName.fullName == "null" {
+				w.Write([]byte("// IsNull returns true when the union holds null.\n"))
+				w.Write([]byte(fmt.Sprintf("func (u %s) IsNull() bool {\nreturn u.index == %d\n}\n\n", typeName, i)))
+				w.Write([]byte("// SetNull sets the union to hold null.\n"))
+				w.Write([]byte(fmt.Sprintf("func (u *%s) SetNull() {\n*u = %s{index: %d}\n}\n\n", typeName, typeName, i)))
+				continue
+			}
+			branch, nativeType := branchNames[i], c.generator.genNativeTypeNameSrc()
+			w.Write([]byte(fmt.Sprintf("// As%s returns the %s value held by the union, and true when the union\n", branch, c.typeName.fullName)))
+			w.Write([]byte(fmt.Sprintf("// holds a %s value.\n", c.typeName.fullName)))
+			w.Write([]byte(fmt.Sprintf("func (u %s) As%s() (%s, bool) {\nreturn u.value%s, u.index == %d\n}\n\n",
+				typeName, branch, nativeType, branch, i)))
+			w.Write([]byte(fmt.Sprintf("// Set%s sets the union to hold the specified %s value.\n", branch, c.typeName.fullName)))
+			w.Write([]byte(fmt.Sprintf("func (u *%s) Set%s(v %s) {\n*u = %s{index: %d, value%s: v}\n}\n\n",
+				typeName, branch, nativeType, typeName, i, branch)))
+		}
+
+		// Write the decoder
+		w.Write([]byte(fmt.Sprintf("func New%s(buf []byte) (%s, []byte, error) {\n", typeName, typeName)))
+		w.Write([]byte(fmt.Sprintf("return New%sAtDepth(buf, 0)\n", typeName)))
+		w.Write([]byte("}\n\n"))
+
+		w.Write([]byte(fmt.Sprintf("// New%sAtDepth is like New%s, for a union nested within depth records.\n", typeName, typeName)))
+		w.Write([]byte("// It is used by generated code to limit how deeply records are nested.\n"))
+		w.Write([]byte(fmt.Sprintf("func New%sAtDepth(buf []byte, depth int) (%s, []byte, error) {\n", typeName, typeName)))
+		w.Write([]byte(fmt.Sprintf("var result %s\n", typeName)))
+		w.Write([]byte("idx, newBuf, err := goavro.LongNativeFromBinary(buf)\n"))
+		w.Write([]byte("if err != nil {\nreturn result, buf, err\n}\n"))
+		w.Write([]byte("switch idx {\n"))
+		for i, c := range codecFromIndex {
+			w.Write([]byte(fmt.Sprintf("case %d:\n", i)))
+			if c.typeName.fullName == "null" {
+				w.Write([]byte("// Null case, nothing to decode\n"))
+				continue
+			}
+			w.Write([]byte(fmt.Sprintf("if result.value%s, newBuf, err = %s(newBuf); err != nil {\n", branchNames[i], c.generator.genDecodeInstanceSrc())))
+			w.Write([]byte(fmt.Sprintf("return %s{}, buf, fmt.Errorf(\"cannot decode binary union item %d: %%s\", err)\n", typeName, i+1)))
+			w.Write([]byte("}\n"))
+		}
+		w.Write([]byte("default:\n"))
+		w.Write([]byte(fmt.Sprintf("return result, buf, fmt.Errorf(\"cannot decode binary union: index ought to be between 0 and %d; read index: %%d\", idx)\n",
+			len(codecFromIndex)-1)))
+		w.Write([]byte("}\n"))
+		w.Write([]byte("result.index = int(idx)\n"))
+		w.Write([]byte("return result, newBuf, nil\n"))
+		w.Write([]byte("}\n\n"))
+
+		// Write the encoder
+		w.Write([]byte(fmt.Sprintf("func (u %s) MarshalAvro(buf []byte) ([]byte, error) {\n", typeName)))
+		w.Write([]byte("var err error\n"))
+		w.Write([]byte("newBuf := buf\n"))
+		w.Write([]byte("switch u.index {\n"))
+		for i, c := range codecFromIndex {
+			w.Write([]byte(fmt.Sprintf("case %d:\n", i)))
+			w.Write([]byte(fmt.Sprintf("newBuf, _ = goavro.LongBinaryFromNative(newBuf, %d)\n", i)))
+			if c.typeName.fullName == "null" {
+				continue
+			}
+			w.Write([]byte(fmt.Sprintf("if newBuf, err = %s(newBuf, u.value%s); err != nil {\n", c.generator.genEncodeInstanceSrc(), branchNames[i])))
+			w.Write([]byte(fmt.Sprintf("return buf, fmt.Errorf(\"cannot encode binary union item %d: %%s\", err)\n", i+1)))
+			w.Write([]byte("}\n"))
+		}
+		w.Write([]byte("default:\n"))
+		w.Write([]byte(fmt.Sprintf("return buf, fmt.Errorf(\"cannot encode binary union: index ought to be between 0 and %d; received: %%d\", u.index)\n",
+			len(codecFromIndex)-1)))
+		w.Write([]byte("}\n"))
+		w.Write([]byte("return newBuf, nil\n"))
+		w.Write([]byte("}\n"))
+
+		return nil
+	}
+
+	gen.genDecodePtrInstanceSrc = func() string {
+		return refDecoderSrc(gen.genNativeTypeNamePtrSrc(), gen.genDecodeInstanceSrc())
+	}
+
+	gen.genEncodeInstanceSrc = func() string {
+		return fmt.Sprintf("func(buf []byte, u %s) ([]byte, error) {\nreturn u.MarshalAvro(buf)\n}", gen.genNativeTypeNameSrc())
+	}
+
+	gen.genEncodePtrInstanceSrc = func() string {
+		return derefEncoderSrc(gen.genNativeTypeNamePtrSrc(), gen.genEncodeInstanceSrc())
+	}
+
+	return gen
+}
+
+// unionAllowedTypes returns the full names of the union members, for use in
+// generated comments.
+func unionAllowedTypes(codecFromIndex []*Codec) []string {
+	allowedTypes := make([]string, len(codecFromIndex))
+	for i, c := range codecFromIndex {
+		allowedTypes[i] = c.typeName.fullName
+	}
+	return allowedTypes
+}
+
 func NewArrayCodecGenerator(realCodec *Codec) *CodecGenerator {
 	gen := &CodecGenerator{
 		getImports:               func() []string { return append([]string{"fmt"}, realCodec.generator.getImports()...) },
@@ -205,96 +769,407 @@ func NewArrayCodecGenerator(realCodec *Codec) *CodecGenerator {
 		return w.String()
 	}
 
+	gen.genEncodeInstanceSrc = func() string {
+		var w bytes.Buffer
+		w.WriteString(fmt.Sprintf("func(buf []byte, values %s) ([]byte, error) {\n", gen.genNativeTypeNameSrc()))
+		w.WriteString("var err error\n")
+		w.WriteString("var remainingInBlock int64\n\n")
+		w.WriteString(`
+				for i, value := range values {
+					if remainingInBlock == 0 { // start a new block
+						remainingInBlock = int64(len(values) - i)
+						if remainingInBlock > goavro.MaxBlockCount {
+							// limit block count to MaxBlockCount
+							remainingInBlock = goavro.MaxBlockCount
+						}
+						buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+					}
+`)
+		w.WriteString(fmt.Sprintf("if buf, err = %s(buf, value); err != nil {\n", realCodec.generator.genEncodeInstanceSrc()))
+		w.WriteString("return nil, fmt.Errorf(\"cannot encode binary array item %d: %v: %s\", i+1, value, err)\n")
+		w.WriteString("}\n")
+		w.WriteString("remainingInBlock--\n")
+		w.WriteString("}\n") // End "for i, value := ..."
+
+		w.WriteString("return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array\n")
+		w.WriteString("}")
+
+		return w.String()
+	}
+
+	gen.genDecodePtrInstanceSrc = gen.genDecodeInstanceSrc
+	gen.genNativeTypeNamePtrSrc = gen.genNativeTypeNameSrc
+	gen.genEncodePtrInstanceSrc = gen.genEncodeInstanceSrc
+
+	return gen
+}
+
+func NewMapCodecGenerator(valueCodec *Codec) *CodecGenerator {
+	gen := &CodecGenerator{
+		getImports:               func() []string { return append([]string{"fmt"}, valueCodec.generator.getImports()...) },
+		genNativeTypeNameSrc:     func() string { return fmt.Sprintf("map[string]%s", valueCodec.generator.genNativeTypeNameSrc()) },
+		genNativeDefaultValueSrc: func() string { return fmt.Sprintf("map[string]%s{}", valueCodec.generator.genNativeTypeNameSrc()) },
+	}
+
+	gen.genDecodeInstanceSrc = func() string {
+		var w bytes.Buffer
+		w.WriteString(fmt.Sprintf("func(buf []byte) (%s, []byte, error) {\n", gen.genNativeTypeNameSrc()))
+		w.WriteString("var key string\n")
+		w.WriteString(fmt.Sprintf("var value %s\n", valueCodec.generator.genNativeTypeNameSrc()))
+		w.WriteString("var err error\n")
+		w.WriteString("var blockCount int64\n")
+		w.WriteString("tmpBuf := buf\n\n")
+		w.WriteString("blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)\n")
+		w.WriteString(fmt.Sprintf("if err != nil { return %s, buf, err }\n\n", gen.genNativeDefaultValueSrc()))
+
+		w.WriteString(fmt.Sprintf("mapValues := make(%s, blockCount)\n\n", gen.genNativeTypeNameSrc()))
+
+		w.WriteString(`
+				for blockCount != 0 {
+					// Decode 'blockCount' datum values
+					for i := int64(0); i < blockCount; i++ {
+`)
+		w.WriteString("if key, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {\n")
+		w.WriteString(fmt.Sprintf("return %s, buf, fmt.Errorf(\"cannot decode binary map key: %%s\", err)\n", gen.genNativeDefaultValueSrc()))
+		w.WriteString("}\n")
+		w.WriteString("if _, ok := mapValues[key]; ok {\n")
+		w.WriteString(fmt.Sprintf("return %s, buf, fmt.Errorf(\"cannot decode binary map: duplicate key: %%q\", key)\n", gen.genNativeDefaultValueSrc()))
+		w.WriteString("}\n")
+		w.WriteString(fmt.Sprintf("if value, tmpBuf, err = %s(tmpBuf); err != nil {\n", valueCodec.generator.genDecodeInstanceSrc()))
+		w.WriteString(fmt.Sprintf("return %s, buf, fmt.Errorf(\"cannot decode binary map value for key %%q: %%s\", key, err)\n", gen.genNativeDefaultValueSrc()))
+		w.WriteString("}\n")
+		w.WriteString("mapValues[key] = value\n")
+		w.WriteString("}\n") // End "for i := ..."
+
+		w.WriteString("blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)\n")
+		w.WriteString(fmt.Sprintf("if err != nil { return %s, buf, err }\n\n", gen.genNativeDefaultValueSrc()))
+
+		w.WriteString("}\n") // End "for BlockCount != 0"
+
+		w.WriteString("return mapValues, tmpBuf, nil\n")
+		w.WriteString("}")
+
+		return w.String()
+	}
+
+	gen.genEncodeInstanceSrc = func() string {
+		var w bytes.Buffer
+		w.WriteString(fmt.Sprintf("func(buf []byte, values %s) ([]byte, error) {\n", gen.genNativeTypeNameSrc()))
+		w.WriteString("var err error\n")
+		w.WriteString("keyCount := int64(len(values))\n")
+		w.WriteString("var alreadyEncoded, remainingInBlock int64\n\n")
+		w.WriteString(`
+				for k, v := range values {
+					if remainingInBlock == 0 { // start a new block
+						remainingInBlock = keyCount - alreadyEncoded
+						if remainingInBlock > goavro.MaxBlockCount {
+							// limit block count to MaxBlockCount
+							remainingInBlock = goavro.MaxBlockCount
+						}
+						buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+					}
+					buf, _ = goavro.StringBinaryFromNative(buf, k)
+`)
+		w.WriteString(fmt.Sprintf("if buf, err = %s(buf, v); err != nil {\n", valueCodec.generator.genEncodeInstanceSrc()))
+		w.WriteString("return nil, fmt.Errorf(\"cannot encode binary map value for key %q: %v: %s\", k, v, err)\n")
+		w.WriteString("}\n")
+		w.WriteString("remainingInBlock--\n")
+		w.WriteString("alreadyEncoded++\n")
+		w.WriteString("}\n") // End "for k, v := ..."
+
+		w.WriteString("return goavro.LongBinaryFromNative(buf, 0) // append tailing 0 block count to signal end of Map\n")
+		w.WriteString("}")
+
+		return w.String()
+	}
+
 	gen.genDecodePtrInstanceSrc = gen.genDecodeInstanceSrc
 	gen.genNativeTypeNamePtrSrc = gen.genNativeTypeNameSrc
+	gen.genEncodePtrInstanceSrc = gen.genEncodeInstanceSrc
 
 	return gen
 }
 
-func NewEnumCodecGenerator(enumName *name, symbols []string) *CodecGenerator {
+func NewFixedCodecGenerator(fixedName *name, size uint) *CodecGenerator {
+	typeName := goIdentifier(fixedName.short())
+	ref := func(ident string) string { return namedRefSrc(fixedName.namespace, ident) }
+
 	gen := &CodecGenerator{
 		getImports:               func() []string { return []string{} },
-		genNativeTypeNameSrc:     func() string { return enumName.short() },
-		genNativeTypeNamePtrSrc: func() string { return "*" + enumName.short() },
+		genNativeTypeNameSrc:     func() string { return ref(typeName) },
+		genNativeTypeNamePtrSrc:  func() string { return "*" + ref(typeName) },
+		genNativeDefaultValueSrc: func() string { return ref(typeName) + "{}" },
+		genDecodeInstanceSrc:     func() string { return ref("New" + typeName) },
+		isWritable:               true,
+	}
+	gen.rename = func(goTypeName string) {
+		typeName = goTypeName
+		gen.goTypeName = typeName
+		gen.declaredIdents = []string{typeName, "New" + typeName, "codecFor" + typeName}
+	}
+	gen.rename(typeName)
+
+	gen.writeSrc = func(w io.Writer) error {
+		w.Write([]byte("import (\n"))
+		w.Write([]byte("\"fmt\"\n"))
+		w.Write([]byte("\"sync\"\n"))
+		w.Write([]byte("\"github.com/peak6/goavro/v2\"\n"))
+		w.Write([]byte(")\n\n"))
+
+		// Write the type
+		w.Write([]byte(fmt.Sprintf("type %s [%d]byte\n\n", typeName, size)))
+
+		// Write the decoder
+		w.Write([]byte(fmt.Sprintf("func New%s(buf []byte) (%s, []byte, error) {\n", typeName, typeName)))
+		w.Write([]byte(fmt.Sprintf("var result %s\n", typeName)))
+		w.Write([]byte(fmt.Sprintf("if buflen := len(buf); buflen < %d {\n", size)))
+		w.Write([]byte(fmt.Sprintf("return result, buf, fmt.Errorf(\"cannot decode binary fixed %%q: schema size exceeds remaining buffer size: %d > %%d (short buffer)\", %q, buflen)\n",
+			size, fixedName.fullName)))
+		w.Write([]byte("}\n"))
+		w.Write([]byte("copy(result[:], buf)\n"))
+		w.Write([]byte(fmt.Sprintf("return result, buf[%d:], nil\n", size)))
+		w.Write([]byte("}\n\n"))
+
+		// Write the encoder
+		w.Write([]byte(fmt.Sprintf("func (f %s) MarshalAvro(buf []byte) ([]byte, error) {\n", typeName)))
+		w.Write([]byte("return append(buf, f[:]...), nil\n"))
+		w.Write([]byte("}\n\n"))
+
+		return writeSchemaMethodsSrc(w, typeName, typeName, gen.standaloneSchema)
+	}
+
+	gen.genDecodePtrInstanceSrc = func() string {
+		return refDecoderSrc(gen.genNativeTypeNamePtrSrc(), gen.genDecodeInstanceSrc())
+	}
+
+	gen.genEncodeInstanceSrc = func() string {
+		return fmt.Sprintf("func(buf []byte, f %s) ([]byte, error) {\nreturn f.MarshalAvro(buf)\n}", gen.genNativeTypeNameSrc())
+	}
+
+	gen.genEncodePtrInstanceSrc = func() string {
+		return derefEncoderSrc(gen.genNativeTypeNamePtrSrc(), gen.genEncodeInstanceSrc())
+	}
+
+	return gen
+}
+
+// NewEnumCodecGenerator returns a generator for an enum with the specified
+// symbols. Decoding an index outside the symbols yields the symbol at
+// defaultIndex, or an error when defaultIndex is negative.
+func NewEnumCodecGenerator(enumName *name, symbols []string, defaultIndex int) *CodecGenerator {
+	typeName := goIdentifier(enumName.short())
+	constNames := make([]string, len(symbols))
+	ref := func(ident string) string { return namedRefSrc(enumName.namespace, ident) }
+
+	gen := &CodecGenerator{
+		getImports:               func() []string { return []string{} },
+		genNativeTypeNameSrc:     func() string { return ref(typeName) },
+		genNativeTypeNamePtrSrc:  func() string { return "*" + ref(typeName) },
 		genNativeDefaultValueSrc: func() string { return "0" },
+		genDecodeInstanceSrc:     func() string { return ref("New" + typeName) },
 		isWritable:               true,
 	}
+	gen.rename = func(goTypeName string) {
+		typeName = goTypeName
+		for i, sym := range symbols {
+			constNames[i] = typeName + goIdentifier(sym)
+		}
+		gen.goTypeName = typeName
+		gen.declaredIdents = append([]string{typeName, "New" + typeName, "Parse" + typeName, "symbolsFor" + typeName, "codecFor" + typeName}, constNames...)
+	}
+	gen.rename(typeName)
 
-	gen.writeDecoderSrc = func(w io.Writer) error {
+	gen.writeSrc = func(w io.Writer) error {
+		if err := ensureDistinctIdents("symbols", symbols, constNames); err != nil {
+			return err
+		}
+
+		w.Write([]byte("import (\n"))
+		w.Write([]byte("\"encoding/json\"\n"))
+		w.Write([]byte("\"fmt\"\n"))
+		w.Write([]byte("\"sync\"\n"))
+		w.Write([]byte("\"github.com/peak6/goavro/v2\"\n"))
+		w.Write([]byte(")\n\n"))
 
 		// Write the type
-		w.Write([]byte(fmt.Sprintf("type %s int\n\n", enumName.short())))
+		w.Write([]byte(fmt.Sprintf("type %s int\n\n", typeName)))
 
 		// Write the const
 		w.Write([]byte("const (\n"))
 
 		// Write the actual constants
-		for i, sym := range symbols {
+		for i, constName := range constNames {
 			if i == 0 {
-				w.Write([]byte(fmt.Sprintf("%s %s = iota\n", sym, enumName.short())))
+				w.Write([]byte(fmt.Sprintf("%s %s = iota\n", constName, typeName)))
 			} else {
-				w.Write([]byte(fmt.Sprintf("%s\n", sym)))
+				w.Write([]byte(fmt.Sprintf("%s\n", constName)))
 			}
 		}
 
 		// Write the close const
-		w.Write([]byte(")\n"))
+		w.Write([]byte(")\n\n"))
 
-		return nil
-	}
+		// Write the symbols
+		w.Write([]byte(fmt.Sprintf("var symbolsFor%s = [...]string{", typeName)))
+		for _, sym := range symbols {
+			w.Write([]byte(fmt.Sprintf("%q, ", sym)))
+		}
+		w.Write([]byte("}\n\n"))
 
-	gen.genDecodeInstanceSrc = func() string {
-		var w bytes.Buffer
+		// Write the decoder
+		w.Write([]byte(fmt.Sprintf("func New%s(buf []byte) (%s, []byte, error) {\n", typeName, typeName)))
+		w.Write([]byte("index, newBuf, err := goavro.LongNativeFromBinary(buf)\n"))
+		w.Write([]byte("if err != nil {\n"))
+		w.Write([]byte(fmt.Sprintf("return 0, buf, fmt.Errorf(\"cannot decode binary enum %%q index: %%s\", %q, err)\n", enumName.fullName)))
+		w.Write([]byte("}\n"))
+		w.Write([]byte(fmt.Sprintf("if index < 0 || index >= %d {\n", len(symbols))))
+		if defaultIndex >= 0 {
+			w.Write([]byte(fmt.Sprintf("// Unknown symbols decode as the default symbol, %s.\n", symbols[defaultIndex])))
+			w.Write([]byte(fmt.Sprintf("return %s, newBuf, nil\n", constNames[defaultIndex])))
+		} else {
+			w.Write([]byte(fmt.Sprintf("return 0, buf, fmt.Errorf(\"cannot decode binary enum %%q: index ought to be between 0 and %d; read index: %%d\", %q, index)\n",
+				len(symbols)-1, enumName.fullName)))
+		}
+		w.Write([]byte("}\n"))
+		w.Write([]byte(fmt.Sprintf("return %s(index), newBuf, nil\n", typeName)))
+		w.Write([]byte("}\n\n"))
 
-		w.WriteString(fmt.Sprintf("func(buf []byte) (%s, []byte, error) {\n", gen.genNativeTypeNameSrc()))
-		w.WriteString("tmpBuf := buf\n")
-		w.WriteString("if tmp, tmpBuf, err := goavro.IntEnumNativeFromBinary(tmpBuf); err != nil {\n")
-		w.WriteString("return 0, buf, err\n")
-		w.WriteString("} else {\n")
-		w.WriteString(fmt.Sprintf("return %s(tmp), tmpBuf, nil\n", gen.genNativeTypeNameSrc()))
-		w.WriteString("}\n")
-		w.WriteString("}")
+		// Write the encoder
+		w.Write([]byte(fmt.Sprintf("func (e %s) MarshalAvro(buf []byte) ([]byte, error) {\n", typeName)))
+		w.Write([]byte(fmt.Sprintf("if e < 0 || e >= %d {\n", len(symbols))))
+		w.Write([]byte(fmt.Sprintf("return buf, fmt.Errorf(\"cannot encode binary enum %%q: index ought to be between 0 and %d; received: %%d\", %q, e)\n",
+			len(symbols)-1, enumName.fullName)))
+		w.Write([]byte("}\n"))
+		w.Write([]byte("return goavro.IntEnumBinaryFromNative(buf, int(e))\n"))
+		w.Write([]byte("}\n\n"))
 
-		return w.String()
+		// Write the conversions to and from symbols
+		w.Write([]byte(fmt.Sprintf("// String returns the symbol of the %s, or its number when it is not one of\n", typeName)))
+		w.Write([]byte("// the symbols.\n"))
+		w.Write([]byte(fmt.Sprintf("func (e %s) String() string {\n", typeName)))
+		w.Write([]byte(fmt.Sprintf("if e < 0 || e >= %d {\n", len(symbols))))
+		w.Write([]byte(fmt.Sprintf("return fmt.Sprintf(\"%s(%%d)\", int(e))\n", typeName)))
+		w.Write([]byte("}\n"))
+		w.Write([]byte(fmt.Sprintf("return symbolsFor%s[e]\n", typeName)))
+		w.Write([]byte("}\n\n"))
+
+		w.Write([]byte(fmt.Sprintf("// Parse%s returns the %s with the specified symbol.\n", typeName, typeName)))
+		w.Write([]byte(fmt.Sprintf("func Parse%s(symbol string) (%s, error) {\n", typeName, typeName)))
+		w.Write([]byte(fmt.Sprintf("for i, s := range symbolsFor%s {\n", typeName)))
+		w.Write([]byte("if s == symbol {\n"))
+		w.Write([]byte(fmt.Sprintf("return %s(i), nil\n", typeName)))
+		w.Write([]byte("}\n"))
+		w.Write([]byte("}\n"))
+		w.Write([]byte(fmt.Sprintf("return 0, fmt.Errorf(\"cannot parse enum %%q: value ought to be member of symbols: %%v; %%q\", %q, symbolsFor%s, symbol)\n",
+			enumName.fullName, typeName)))
+		w.Write([]byte("}\n\n"))
+
+		w.Write([]byte("// MarshalText returns the symbol of the enum.\n"))
+		w.Write([]byte(fmt.Sprintf("func (e %s) MarshalText() ([]byte, error) {\n", typeName)))
+		w.Write([]byte(fmt.Sprintf("if e < 0 || e >= %d {\n", len(symbols))))
+		w.Write([]byte(fmt.Sprintf("return nil, fmt.Errorf(\"cannot encode textual enum %%q: index ought to be between 0 and %d; received: %%d\", %q, e)\n",
+			len(symbols)-1, enumName.fullName)))
+		w.Write([]byte("}\n"))
+		w.Write([]byte(fmt.Sprintf("return []byte(symbolsFor%s[e]), nil\n", typeName)))
+		w.Write([]byte("}\n\n"))
+
+		w.Write([]byte("// UnmarshalText sets the enum to the value with the symbol in text.\n"))
+		w.Write([]byte(fmt.Sprintf("func (e *%s) UnmarshalText(text []byte) error {\n", typeName)))
+		w.Write([]byte(fmt.Sprintf("value, err := Parse%s(string(text))\n", typeName)))
+		w.Write([]byte("if err != nil {\nreturn err\n}\n"))
+		w.Write([]byte("*e = value\n"))
+		w.Write([]byte("return nil\n"))
+		w.Write([]byte("}\n\n"))
+
+		w.Write([]byte("// MarshalJSON returns the symbol of the enum as a JSON string.\n"))
+		w.Write([]byte(fmt.Sprintf("func (e %s) MarshalJSON() ([]byte, error) {\n", typeName)))
+		w.Write([]byte("text, err := e.MarshalText()\n"))
+		w.Write([]byte("if err != nil {\nreturn nil, err\n}\n"))
+		w.Write([]byte("return json.Marshal(string(text))\n"))
+		w.Write([]byte("}\n\n"))
+
+		w.Write([]byte("// UnmarshalJSON sets the enum to the value with the symbol in the JSON string.\n"))
+		w.Write([]byte(fmt.Sprintf("func (e *%s) UnmarshalJSON(data []byte) error {\n", typeName)))
+		w.Write([]byte("var symbol string\n"))
+		w.Write([]byte("if err := json.Unmarshal(data, &symbol); err != nil {\n"))
+		w.Write([]byte(fmt.Sprintf("return fmt.Errorf(\"cannot decode textual enum %%q: %%s\", %q, err)\n", enumName.fullName)))
+		w.Write([]byte("}\n"))
+		w.Write([]byte("return e.UnmarshalText([]byte(symbol))\n"))
+		w.Write([]byte("}\n\n"))
+
+		return writeSchemaMethodsSrc(w, typeName, typeName, gen.standaloneSchema)
 	}
 
 	gen.genDecodePtrInstanceSrc = func() string {
-		var w bytes.Buffer
+		return refDecoderSrc(gen.genNativeTypeNamePtrSrc(), gen.genDecodeInstanceSrc())
+	}
 
-		w.WriteString(fmt.Sprintf("func(buf []byte) (%s, []byte, error) {\n", gen.genNativeTypeNamePtrSrc()))
-		w.WriteString("tmpBuf := buf\n")
-		w.WriteString("if tmp, tmpBuf, err := goavro.IntEnumNativeFromBinary(tmpBuf); err != nil {\n")
-		w.WriteString("return nil, buf, err\n")
-		w.WriteString("} else {\n")
-		w.WriteString(fmt.Sprintf("ret := %s(tmp)\n", gen.genNativeTypeNameSrc()))
-		w.WriteString("return &ret, tmpBuf, nil\n")
-		w.WriteString("}\n")
-		w.WriteString("}")
+	gen.genEncodeInstanceSrc = func() string {
+		return fmt.Sprintf("func(buf []byte, e %s) ([]byte, error) {\nreturn e.MarshalAvro(buf)\n}", gen.genNativeTypeNameSrc())
+	}
 
-		return w.String()
+	gen.genEncodePtrInstanceSrc = func() string {
+		return derefEncoderSrc(gen.genNativeTypeNamePtrSrc(), gen.genEncodeInstanceSrc())
 	}
 
 	return gen
-
 }
 
-func NewRecordCodecGenerator(recordTypeName *name, codecFromIndex []*Codec, nameFromIndex []string) *CodecGenerator {
+// generatedRecordMethods are the names of the methods written for the type of
+// a record, which none of its fields may have.
+var generatedRecordMethods = []string{"Codec", "MarshalAvro", "Schema"}
+
+func NewRecordCodecGenerator(recordTypeName *name, codecFromIndex []*Codec, nameFromIndex []string, binaryDefaultFromIndex [][]byte) *CodecGenerator {
+	typeName := goIdentifier(recordTypeName.short())
+	fieldNames := make([]string, len(nameFromIndex))
+	for i, name := range nameFromIndex {
+		fieldNames[i] = goIdentifier(name)
+	}
+
 	gen := &CodecGenerator{
 		getImports:               func() []string { return []string{} },
-		genNativeTypeNameSrc:     func() string { return "*" + recordTypeName.short() },
+		genNativeTypeNameSrc:     func() string { return "*" + namedRefSrc(recordTypeName.namespace, typeName) },
 		genNativeDefaultValueSrc: func() string { return "nil" },
-		genDecodeInstanceSrc:     func() string { return fmt.Sprintf("New%s", recordTypeName.short()) },
 		isWritable:               true,
 	}
+	gen.rename = func(goTypeName string) {
+		typeName = goTypeName
+		gen.goTypeName = typeName
+		gen.declaredIdents = []string{
+			typeName, "New" + typeName, "New" + typeName + "AtDepth", "New" + typeName + "WithDefaults", "codecFor" + typeName,
+			typeName + "OCFReader", "New" + typeName + "OCFReader", typeName + "OCFWriter", "New" + typeName + "OCFWriter",
+		}
+	}
+	gen.rename(typeName)
+
+	// NOTE: Records are decoded by a function that tracks how deeply they are
+	// nested, because recursive records may be nested arbitrarily deep. The
+	// decoders of all types are only used within such functions, where depth
+	// is the nesting depth of the enclosing record.
+	gen.genDecodeInstanceSrc = func() string {
+		return fmt.Sprintf("func(buf []byte) (%s, []byte, error) {\nreturn %s(buf, depth+1)\n}",
+			gen.genNativeTypeNameSrc(), namedRefSrc(recordTypeName.namespace, "New"+typeName+"AtDepth"))
+	}
+
+	gen.writeSrc = func(w io.Writer) error {
+		if err := ensureDistinctIdents("fields", nameFromIndex, fieldNames); err != nil {
+			return err
+		}
+		for i, fieldName := range fieldNames {
+			for _, method := range generatedRecordMethods {
+				if fieldName == method {
+					return fmt.Errorf("field %q would be named %q, which is the name of a generated method", nameFromIndex[i], fieldName)
+				}
+			}
+		}
 
-	gen.writeDecoderSrc = func(w io.Writer) error {
 		imports := make([]string, 0)
 		for _, fieldCodec := range codecFromIndex {
 			if fieldCodec.generator.getImports != nil {
 				imports = append(imports, fieldCodec.generator.getImports()...)
 			}
 		}
-		imports = append(imports, "github.com/peak6/goavro/v2")
+		imports = append(imports, "fmt", "io", "sync", "github.com/peak6/goavro/v2")
 
 		w.Write([]byte("import (\n"))
 		for _, imp := range imports {
@@ -303,31 +1178,95 @@ func NewRecordCodecGenerator(recordTypeName *name, codecFromIndex []*Codec, name
 		w.Write([]byte(")\n\n"))
 
 		// Write the struct
-		w.Write([]byte(fmt.Sprintf("type %s struct {\n", recordTypeName.short())))
+		w.Write([]byte(fmt.Sprintf("type %s struct {\n", typeName)))
 		for i, fieldCodec := range codecFromIndex {
-			w.Write([]byte(fmt.Sprintf("%s %s\n", strings.Title(nameFromIndex[i]), fieldCodec.generator.genNativeTypeNameSrc())))
+			w.Write([]byte(fmt.Sprintf("%s %s `avro:%q`\n", fieldNames[i], fieldCodec.generator.genNativeTypeNameSrc(), nameFromIndex[i])))
 		}
 		w.Write([]byte("}\n\n"))
 
 		// Write the full decoder
 		w.Write([]byte(fmt.Sprintf("func New%s(buf []byte) (*%s, []byte, error) {\n",
-			recordTypeName.short(), recordTypeName.short())))
-		w.Write([]byte(fmt.Sprintf("result := &%s{}\n", recordTypeName.short())))
+			typeName, typeName)))
+		w.Write([]byte(fmt.Sprintf("return New%sAtDepth(buf, 0)\n", typeName)))
+		w.Write([]byte("}\n\n"))
+
+		w.Write([]byte(fmt.Sprintf("// New%sAtDepth is like New%s, for a record nested within depth records.\n",
+			typeName, typeName)))
+		w.Write([]byte("// It is used by generated code to limit how deeply records are nested.\n"))
+		w.Write([]byte(fmt.Sprintf("func New%sAtDepth(buf []byte, depth int) (*%s, []byte, error) {\n",
+			typeName, typeName)))
+		w.Write([]byte("if depth >= goavro.MaxDecodeDepth {\n"))
+		w.Write([]byte(fmt.Sprintf("return nil, buf, fmt.Errorf(\"cannot decode binary record %%q: nested deeper than MaxDecodeDepth: %%d\", %q, goavro.MaxDecodeDepth)\n",
+			recordTypeName.fullName)))
+		w.Write([]byte("}\n"))
+		w.Write([]byte(fmt.Sprintf("result := &%s{}\n", typeName)))
+		w.Write([]byte("newBuf := buf\n"))
+		w.Write([]byte("var err error\n\n"))
+
+		for i, fieldCodec := range codecFromIndex {
+			w.Write([]byte(fmt.Sprintf(
+				"if result.%s, newBuf, err = %s(newBuf); err != nil {\nreturn nil, buf, fmt.Errorf(\"cannot decode binary record %%q field %%q: %%s\", %q, %q, err)\n\t}\n\n",
+				fieldNames[i], fieldCodec.generator.genDecodeInstanceSrc(), recordTypeName.fullName, nameFromIndex[i])))
+		}
+		w.Write([]byte("return result, newBuf, nil\n}\n\n"))
+
+		// Write the full encoder
+		w.Write([]byte(fmt.Sprintf("func (r *%s) MarshalAvro(buf []byte) ([]byte, error) {\n", typeName)))
+		w.Write([]byte("if r == nil {\n"))
+		w.Write([]byte(fmt.Sprintf("return buf, fmt.Errorf(\"cannot encode binary record %%q: received nil\", %q)\n", recordTypeName.fullName)))
+		w.Write([]byte("}\n"))
 		w.Write([]byte("newBuf := buf\n"))
 		w.Write([]byte("var err error\n\n"))
 
 		for i, fieldCodec := range codecFromIndex {
 			name := nameFromIndex[i]
 			w.Write([]byte(fmt.Sprintf(
-				"if result.%s, newBuf, err = %s(newBuf); err != nil {\nreturn nil, newBuf, err\n\t}\n\n",
-				strings.Title(name), fieldCodec.generator.genDecodeInstanceSrc())))
+				"if newBuf, err = %s(newBuf, r.%s); err != nil {\nreturn buf, fmt.Errorf(\"cannot encode binary record %%q field %%q: value does not match its schema: %%s\", %q, %q, err)\n\t}\n\n",
+				fieldCodec.generator.genEncodeInstanceSrc(), fieldNames[i], recordTypeName.fullName, name)))
 		}
-		w.Write([]byte("return result, newBuf, nil\n}\n"))
-		return nil
+		w.Write([]byte("return newBuf, nil\n}\n\n"))
+
+		// Write the constructor that applies the field defaults
+		w.Write([]byte(fmt.Sprintf("// New%sWithDefaults returns a new %s, with each field that has a default\n", typeName, typeName)))
+		w.Write([]byte("// value in the schema set to that value.\n"))
+		w.Write([]byte(fmt.Sprintf("func New%sWithDefaults() *%s {\n", typeName, typeName)))
+		w.Write([]byte(fmt.Sprintf("r := &%s{}\n", typeName)))
+		var hasDefaults bool
+		for i, fieldCodec := range codecFromIndex {
+			binaryDefault := binaryDefaultFromIndex[i]
+			if binaryDefault == nil {
+				continue
+			}
+			if !hasDefaults {
+				// NOTE: Defaults are decoded from their binary encoding, made
+				// by the codec when it validated them, so that every type is
+				// handled by its generated decoder.
+				w.Write([]byte("var err error\n"))
+				w.Write([]byte("const depth = 0\n"))
+				hasDefaults = true
+			}
+			w.Write([]byte(fmt.Sprintf("if r.%s, _, err = %s(%#v); err != nil {\n", fieldNames[i], fieldCodec.generator.genDecodeInstanceSrc(), binaryDefault)))
+			w.Write([]byte(fmt.Sprintf("panic(fmt.Sprintf(\"cannot decode default value of record %%q field %%q: %%s\", %q, %q, err))\n",
+				recordTypeName.fullName, nameFromIndex[i])))
+			w.Write([]byte("}\n"))
+		}
+		w.Write([]byte("return r\n"))
+		w.Write([]byte("}\n\n"))
+
+		if err := writeSchemaMethodsSrc(w, "r *"+typeName, typeName, gen.standaloneSchema); err != nil {
+			return err
+		}
+		w.Write([]byte("\n"))
+		return writeOCFWrappersSrc(w, typeName, recordTypeName.fullName)
+	}
+
+	gen.genEncodeInstanceSrc = func() string {
+		return fmt.Sprintf("func(buf []byte, r %s) ([]byte, error) {\nreturn r.MarshalAvro(buf)\n}", gen.genNativeTypeNameSrc())
 	}
 
 	gen.genDecodePtrInstanceSrc = gen.genDecodeInstanceSrc
 	gen.genNativeTypeNamePtrSrc = gen.genNativeTypeNameSrc
+	gen.genEncodePtrInstanceSrc = gen.genEncodeInstanceSrc
 
 	return gen
 }
@@ -0,0 +1,410 @@
+package goavro
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestGenerateMatchesCommittedCode ensures the generated code committed in
+// internal/gentest is what the generator currently emits for its schemas.
+func TestGenerateMatchesCommittedCode(t *testing.T) {
+	t.Run("gentest", func(t *testing.T) {
+		ensureGeneratedCodeCommitted(t, GenerateConfig{PackageName: "gentest"}, "internal/gentest")
+	})
+	t.Run("nsmap", func(t *testing.T) {
+		const importPath = "github.com/peak6/goavro/v2/internal/gentest/nsmap"
+		ensureGeneratedCodeCommitted(t, GenerateConfig{
+			PackageName: "nsmap",
+			ImportPath:  importPath,
+			Namespaces:  map[string]string{"com.a": importPath + "/a", "com.b": importPath + "/b"},
+			TypeNames:   map[string]string{"com.b.Token": "AccessToken"},
+		}, "internal/gentest/nsmap")
+	})
+}
+
+// ensureGeneratedCodeCommitted generates code from the schemas in the testdata
+// directory of committedDir, and reports the generated files that differ from
+// those committed.
+func ensureGeneratedCodeCommitted(t *testing.T, config GenerateConfig, committedDir string) {
+	t.Helper()
+	schemas, err := filepath.Glob(filepath.Join(committedDir, "testdata", "*.avsc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schemas) == 0 {
+		t.Fatal("GOT: no schemas; WANT: at least one schema")
+	}
+
+	if err = CheckGenerated(config, schemas, committedDir, nil); err != nil {
+		t.Errorf("GOT: %v; WANT: current generator output; run go generate in %s", err, committedDir)
+	}
+}
+
+// writeSchemaFiles writes each schema to its own file in a new temporary
+// directory, returning the directory and the file names in order.
+func writeSchemaFiles(t *testing.T, schemas ...string) (string, []string) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "goavro-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make([]string, len(schemas))
+	for i, schema := range schemas {
+		files[i] = filepath.Join(dir, fmt.Sprintf("schema%d.avsc", i))
+		if err = ioutil.WriteFile(files[i], []byte(schema), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir, files
+}
+
+func TestGenerateSourcesResolvesTypesAcrossFiles(t *testing.T) {
+	// The first file refers to a type defined in the second file.
+	dir, files := writeSchemaFiles(t,
+		`{"type":"record","name":"outer","namespace":"com.example","fields":[{"name":"inner","type":"inner"}]}`,
+		`{"type":"record","name":"inner","namespace":"com.example","fields":[{"name":"value","type":"int"}]}`,
+	)
+	defer os.RemoveAll(dir)
+
+	var log bytes.Buffer
+	sources, err := GenerateSources(GenerateConfig{PackageName: "example"}, files, &log)
+	ensureError(t, err)
+
+	if actual, expected := sortedSourceNames(sources), []string{"inner.go", "outer.go"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if !bytes.Contains(sources["outer.go"], []byte("package example")) {
+		t.Errorf("GOT: %s; WANT: %s", sources["outer.go"], "package example")
+	}
+	if actual, expected := log.String(), "parsed "+files[0]; !strings.Contains(actual, expected) {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	// Nothing is written to the directory when generating sources.
+	written, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != 0 {
+		t.Errorf("GOT: %v; WANT: %v", written, []string{})
+	}
+}
+
+func TestGenerateWritesSources(t *testing.T) {
+	dir, files := writeSchemaFiles(t,
+		`{"type":"record","name":"point","fields":[{"name":"x","type":"int"},{"name":"y","type":"int"}]}`,
+	)
+	defer os.RemoveAll(dir)
+
+	ensureError(t, Generate(GenerateConfig{PackageName: "example"}, files, dir, nil))
+
+	sources, err := GenerateSources(GenerateConfig{PackageName: "example"}, files, nil)
+	ensureError(t, err)
+	actual, err := ioutil.ReadFile(filepath.Join(dir, "point.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := sources["point.go"]; !bytes.Equal(actual, expected) {
+		t.Errorf("GOT: %s; WANT: %s", actual, expected)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	t.Run("unresolved type", func(t *testing.T) {
+		dir, files := writeSchemaFiles(t,
+			`{"type":"record","name":"point","fields":[{"name":"x","type":"int"},{"name":"y","type":["null","missing"]}]}`,
+		)
+		defer os.RemoveAll(dir)
+
+		_, err := GenerateSources(GenerateConfig{PackageName: "example"}, files, nil)
+		ensureError(t, err, files[0], "/fields/1/type/1", "missing")
+		generateErr, ok := err.(*GenerateError)
+		if !ok {
+			t.Fatalf("GOT: %T; WANT: %T", err, generateErr)
+		}
+		if actual, expected := generateErr.File, files[0]; actual != expected {
+			t.Errorf("GOT: %v; WANT: %v", actual, expected)
+		}
+		if actual, expected := generateErr.SchemaPath, "/fields/1/type/1"; actual != expected {
+			t.Errorf("GOT: %v; WANT: %v", actual, expected)
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		dir, files := writeSchemaFiles(t, `{"type":"record"`)
+		defer os.RemoveAll(dir)
+
+		_, err := GenerateSources(GenerateConfig{PackageName: "example"}, files, nil)
+		ensureError(t, err, files[0], "cannot unmarshal schema JSON")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := GenerateSources(GenerateConfig{PackageName: "example"}, []string{"does-not-exist.avsc"}, nil)
+		ensureError(t, err, "does-not-exist.avsc", "cannot read schema")
+	})
+
+	t.Run("unwritable output directory", func(t *testing.T) {
+		dir, files := writeSchemaFiles(t, `{"type":"enum","name":"color","symbols":["RED"]}`)
+		defer os.RemoveAll(dir)
+
+		err := Generate(GenerateConfig{PackageName: "example"}, files, files[0], nil)
+		ensureError(t, err, "cannot write generated code")
+	})
+}
+
+func TestGenerateSourcesMapsNamespacesToPackages(t *testing.T) {
+	dir, files := writeSchemaFiles(t,
+		`{"type":"record","name":"User","namespace":"com.a","fields":[{"name":"id","type":"long"}]}`,
+		`{"type":"record","name":"User","namespace":"com.b.people","fields":[{"name":"owner","type":["null","com.a.User"]},{"name":"peer","type":["null","com.a.User","string"]}]}`,
+		`{"type":"record","name":"Pair","namespace":"com.example","fields":[{"name":"a","type":"com.a.User"},{"name":"b","type":"com.b.people.User"}]}`,
+	)
+	defer os.RemoveAll(dir)
+
+	config := GenerateConfig{
+		PackageName: "models",
+		ImportPath:  "example.com/models",
+		Namespaces: map[string]string{
+			"com.a": "example.com/models/a",
+			"com.b": "example.com/models/b/people",
+		},
+	}
+	sources, err := GenerateSources(config, files, nil)
+	ensureError(t, err)
+
+	expected := []string{"a/user.go", "b/people/union_null_user_string.go", "b/people/user.go", "pair.go"}
+	if actual := sortedSourceNames(sources); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	for outFile, want := range map[string][]string{
+		"a/user.go":                          {"package a\n"},
+		"b/people/user.go":                   {"package people\n", `"example.com/models/a"`, "Owner *a.User", "Peer  UnionNullUserString"},
+		"b/people/union_null_user_string.go": {"package people\n", `"example.com/models/a"`, "return u.valueUser, u.index == 1"},
+		"pair.go":                            {"package models\n", `"example.com/models/a"`, `"example.com/models/b/people"`, "A *a.User", "B *people.User"},
+	} {
+		for _, substring := range want {
+			if !bytes.Contains(sources[outFile], []byte(substring)) {
+				t.Errorf("GOT: %s; WANT: %s", sources[outFile], substring)
+			}
+		}
+	}
+
+	// Each source is written to the subdirectory for its package.
+	outputDir := filepath.Join(dir, "out")
+	ensureError(t, Generate(config, files, outputDir, nil))
+	if _, err = os.Stat(filepath.Join(outputDir, "b", "people", "user.go")); err != nil {
+		t.Errorf("GOT: %v; WANT: %v", err, nil)
+	}
+}
+
+func TestGenerateSourcesNamespaceErrors(t *testing.T) {
+	t.Run("missing import path", func(t *testing.T) {
+		_, err := GenerateSources(GenerateConfig{PackageName: "models", Namespaces: map[string]string{"com.a": "example.com/a"}}, nil, nil)
+		ensureError(t, err, "without the import path of the output directory")
+	})
+
+	t.Run("import path outside output directory", func(t *testing.T) {
+		_, err := GenerateSources(GenerateConfig{PackageName: "models", ImportPath: "example.com/models", Namespaces: map[string]string{"com.a": "example.com/other"}}, nil, nil)
+		ensureError(t, err, "com.a", "ought to be within", "example.com/other")
+	})
+
+	t.Run("invalid package name", func(t *testing.T) {
+		_, err := GenerateSources(GenerateConfig{PackageName: "models", ImportPath: "example.com/models", Namespaces: map[string]string{"com.a": "example.com/models/my-pkg"}}, nil, nil)
+		ensureError(t, err, "com.a", "ought to be a Go identifier", "my-pkg")
+	})
+
+	t.Run("import cycle", func(t *testing.T) {
+		dir, files := writeSchemaFiles(t,
+			`{"type":"record","name":"Left","namespace":"com.a","fields":[{"name":"right","type":["null",{"type":"record","name":"Right","namespace":"com.b","fields":[{"name":"left","type":["null","com.a.Left"]}]}]}]}`,
+		)
+		defer os.RemoveAll(dir)
+
+		_, err := GenerateSources(GenerateConfig{
+			PackageName: "models",
+			ImportPath:  "example.com/models",
+			Namespaces:  map[string]string{"com.a": "example.com/models/a", "com.b": "example.com/models/b"},
+		}, files, nil)
+		ensureError(t, err, "packages would import each other", "example.com/models/a -> example.com/models/b -> example.com/models/a")
+	})
+}
+
+func TestGoIdentifier(t *testing.T) {
+	for avroName, expected := range map[string]string{
+		"order":                 "Order",
+		"Order":                 "Order",
+		"order_id":              "OrderId",
+		"orderId":               "OrderId",
+		"_id":                   "Id",
+		"type":                  "Type",
+		"RED":                   "RED",
+		"in_progress":           "InProgress",
+		"_1":                    "X1",
+		"long.timestamp-millis": "LongTimestampMillis",
+	} {
+		if actual := goIdentifier(avroName); actual != expected {
+			t.Errorf("%q: GOT: %v; WANT: %v", avroName, actual, expected)
+		}
+	}
+}
+
+func TestGenerateSourcesSanitizesIdentifiers(t *testing.T) {
+	dir, files := writeSchemaFiles(t,
+		`{"type":"record","name":"line_item","fields":[{"name":"type","type":"string"},{"name":"_id","type":"long"},{"name":"unit_price","type":"double"},{"name":"color","type":{"type":"enum","name":"color","symbols":["RED","in_progress"]}}]}`,
+	)
+	defer os.RemoveAll(dir)
+
+	sources, err := GenerateSources(GenerateConfig{PackageName: "example"}, files, nil)
+	ensureError(t, err)
+
+	for outFile, want := range map[string][]string{
+		"line_item.go": {"type LineItem struct", "Type      string  `avro:\"type\"`", "Id        int64   `avro:\"_id\"`", "UnitPrice float64 `avro:\"unit_price\"`", "func NewLineItem("},
+		"color.go":     {"type Color int", "ColorRED Color = iota", "ColorInProgress\n"},
+	} {
+		for _, substring := range want {
+			if !bytes.Contains(sources[outFile], []byte(substring)) {
+				t.Errorf("GOT: %s; WANT: %s", sources[outFile], substring)
+			}
+		}
+	}
+}
+
+func TestGenerateSourcesIdentifierCollisions(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		want   []string
+	}{
+		{
+			name:   "record fields",
+			schema: `{"type":"record","name":"point","fields":[{"name":"order_id","type":"long"},{"name":"orderId","type":"long"}]}`,
+			want:   []string{"point.go", `fields "order_id" and "orderId" would both be named "OrderId"`},
+		},
+		{
+			name:   "generated method",
+			schema: `{"type":"record","name":"point","fields":[{"name":"marshal_avro","type":"long"}]}`,
+			want:   []string{"point.go", `field "marshal_avro" would be named "MarshalAvro"`},
+		},
+		{
+			name:   "generated Schema method",
+			schema: `{"type":"record","name":"point","fields":[{"name":"schema","type":"string"}]}`,
+			want:   []string{"point.go", `field "schema" would be named "Schema"`},
+		},
+		{
+			name:   "generated Codec method",
+			schema: `{"type":"record","name":"point","fields":[{"name":"codec","type":"string"}]}`,
+			want:   []string{"point.go", `field "codec" would be named "Codec"`},
+		},
+		{
+			name:   "enum symbols",
+			schema: `{"type":"enum","name":"color","symbols":["dark_red","darkRed"]}`,
+			want:   []string{"color.go", `symbols "dark_red" and "darkRed" would both be named "ColorDarkRed"`},
+		},
+		{
+			name:   "union members",
+			schema: `{"type":"record","name":"pair","fields":[{"name":"v","type":["null",{"type":"long","logicalType":"timestamp-millis"},{"type":"fixed","name":"long_timestamp_millis","size":1}]}]}`,
+			want:   []string{`members "long.timestamp-millis" and "long_timestamp_millis" would both be named "LongTimestampMillis"`},
+		},
+		{
+			name:   "package declarations",
+			schema: `{"type":"record","name":"color_red","fields":[{"name":"c","type":{"type":"enum","name":"color","symbols":["Red"]}}]}`,
+			want:   []string{`cannot declare "ColorRed"`, "already declared in"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dir, files := writeSchemaFiles(t, c.schema)
+			defer os.RemoveAll(dir)
+
+			_, err := GenerateSources(GenerateConfig{PackageName: "example"}, files, nil)
+			ensureError(t, err, c.want...)
+			if _, ok := err.(*GenerateError); !ok {
+				t.Errorf("GOT: %T; WANT: %T", err, &GenerateError{})
+			}
+		})
+	}
+}
+
+func TestGenerateSourcesRenamesTypes(t *testing.T) {
+	dir, files := writeSchemaFiles(t,
+		`{"type":"record","name":"com.example.user","fields":[{"name":"status","type":{"type":"enum","name":"status","symbols":["ACTIVE"]}},{"name":"key","type":{"type":"fixed","name":"key","size":2}},{"name":"friend","type":["null","user","string"]}]}`,
+	)
+	defer os.RemoveAll(dir)
+
+	sources, err := GenerateSources(GenerateConfig{
+		PackageName: "example",
+		TypeNames:   map[string]string{"com.example.user": "Customer", "com.example.status": "State", "com.example.key": "APIKey"},
+	}, files, nil)
+	ensureError(t, err)
+
+	for outFile, want := range map[string][]string{
+		"customer.go":               {"type Customer struct", "Status State ", "Key    APIKey ", "func NewCustomer(", "func NewCustomerWithDefaults() *Customer", "type CustomerOCFReader struct"},
+		"state.go":                  {"type State int", "StateACTIVE State = iota", "func ParseState("},
+		"api_key.go":                {"type APIKey [2]byte", "func NewAPIKey("},
+		"union_null_user_string.go": {"valueUser   *Customer", "NewCustomerAtDepth(buf, depth+1)"},
+	} {
+		for _, substring := range want {
+			if !bytes.Contains(sources[outFile], []byte(substring)) {
+				t.Errorf("GOT: %s; WANT: %s", sources[outFile], substring)
+			}
+		}
+	}
+	if _, ok := sources["user.go"]; ok {
+		t.Errorf("GOT: %v; WANT: %v", sortedSourceNames(sources), "no user.go")
+	}
+
+	cases := []struct {
+		typeNames map[string]string
+		want      []string
+	}{
+		{map[string]string{"com.example.missing": "Missing"}, []string{`cannot name Go type for "com.example.missing"`, "defined by an input file"}},
+		{map[string]string{"int": "Int"}, []string{`cannot name Go type for "int"`, "record, enum, or fixed"}},
+		{map[string]string{"com.example.user": "customer"}, []string{`cannot name Go type for "com.example.user"`, "exported Go identifier"}},
+		{map[string]string{"com.example.user": "Status"}, []string{`cannot declare "Status"`, "already declared in"}},
+	}
+	for _, c := range cases {
+		_, err = GenerateSources(GenerateConfig{PackageName: "example", TypeNames: c.typeNames}, files, nil)
+		ensureError(t, err, c.want...)
+	}
+}
+
+func TestGenerateRemovesStaleFiles(t *testing.T) {
+	dir, files := writeSchemaFiles(t,
+		`{"type":"record","name":"point","fields":[{"name":"x","type":"int"}]}`,
+	)
+	defer os.RemoveAll(dir)
+	config := GenerateConfig{PackageName: "example"}
+
+	ensureError(t, Generate(config, files, dir, nil))
+	ensureError(t, CheckGenerated(config, files, dir, nil))
+
+	// A file that is not generated is left alone.
+	handWritten := filepath.Join(dir, "doc.go")
+	if err := ioutil.WriteFile(handWritten, []byte("package example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Renaming the type makes its previous file stale.
+	config.TypeNames = map[string]string{"point": "Coordinate"}
+	ensureError(t, CheckGenerated(config, files, dir, nil), "ought to be regenerated", "coordinate.go is missing", "point.go is stale")
+
+	ensureError(t, Generate(config, files, dir, nil))
+	ensureError(t, CheckGenerated(config, files, dir, nil))
+	if _, err := os.Stat(filepath.Join(dir, "point.go")); !os.IsNotExist(err) {
+		t.Errorf("GOT: %v; WANT: %v", err, "point.go removed")
+	}
+	if _, err := os.Stat(handWritten); err != nil {
+		t.Errorf("GOT: %v; WANT: %v", err, nil)
+	}
+
+	// A changed file is out of date.
+	if err := ioutil.WriteFile(filepath.Join(dir, "coordinate.go"), []byte(generatedFileHeader), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ensureError(t, CheckGenerated(config, files, dir, nil), "coordinate.go is out of date")
+}
@@ -132,7 +132,6 @@ func NativeFromBinaryDecimalBytes(buf []byte, precision int, scale int) (*big.Ra
 		return &big.Rat{}, buf, err
 	}
 	return thing.(*big.Rat), newBuf, nil
-
 }
 
 func NativeFromBinaryDate(buf []byte) (time.Time, []byte, error) {
@@ -152,13 +151,194 @@ func NativePtrFromBinaryDate(buf []byte) (*time.Time, []byte, error) {
 	return &tmp, newBuf, nil
 }
 
+func StringBinaryFromNative(buf []byte, datum string) ([]byte, error) {
+	return stringBinaryFromNative(buf, datum)
+}
+
+func LongBinaryFromNative(buf []byte, datum int64) ([]byte, error) {
+	return longBinaryFromNative(buf, datum)
+}
+
+func IntBinaryFromNative(buf []byte, datum int32) ([]byte, error) {
+	return intBinaryFromNative(buf, datum)
+}
+
+func IntEnumBinaryFromNative(buf []byte, datum int) ([]byte, error) {
+	return longBinaryFromNative(buf, datum)
+}
+
+func DoubleBinaryFromNative(buf []byte, datum float64) ([]byte, error) {
+	return doubleBinaryFromNative(buf, datum)
+}
+
+func FloatBinaryFromNative(buf []byte, datum float32) ([]byte, error) {
+	return floatBinaryFromNative(buf, datum)
+}
+
+func BoolBinaryFromNative(buf []byte, datum bool) ([]byte, error) {
+	return booleanBinaryFromNative(buf, datum)
+}
+
+func BinaryFromNativeDecimalBytes(buf []byte, datum *big.Rat, precision int, scale int) ([]byte, error) {
+	if datum == nil {
+		return buf, fmt.Errorf("cannot transform to bytes, expected *big.Rat, received nil")
+	}
+	return decimalBytesFromNative(bytesBinaryFromNative, toSignedBytes, precision, scale)(buf, datum)
+}
+
+func BinaryFromNativeDate(buf []byte, datum time.Time) ([]byte, error) {
+	return dateFromNative(intBinaryFromNative)(buf, datum)
+}
+
+func BytesNativeFromBinary(buf []byte) ([]byte, []byte, error) {
+	thing, newBuf, err := bytesNativeFromBinary(buf)
+	if err != nil {
+		return nil, buf, err
+	}
+	// NOTE: Copy the bytes so the decoded value does not alias the buffer.
+	return append([]byte{}, thing.([]byte)...), newBuf, nil
+}
+
+func BytesBinaryFromNative(buf []byte, datum []byte) ([]byte, error) {
+	return bytesBinaryFromNative(buf, datum)
+}
+
+func NativeFromBinaryTimeStampMillis(buf []byte) (time.Time, []byte, error) {
+	thing, newBuf, err := nativeFromTimeStampMillis(longNativeFromBinary)(buf)
+	if err != nil {
+		return time.Time{}, buf, err
+	}
+	return thing.(time.Time), newBuf, nil
+}
+
+func BinaryFromNativeTimeStampMillis(buf []byte, datum time.Time) ([]byte, error) {
+	return timeStampMillisFromNative(longBinaryFromNative)(buf, datum)
+}
+
+func NativeFromBinaryTimeStampMicros(buf []byte) (time.Time, []byte, error) {
+	thing, newBuf, err := nativeFromTimeStampMicros(longNativeFromBinary)(buf)
+	if err != nil {
+		return time.Time{}, buf, err
+	}
+	return thing.(time.Time), newBuf, nil
+}
+
+func BinaryFromNativeTimeStampMicros(buf []byte, datum time.Time) ([]byte, error) {
+	return timeStampMicrosFromNative(longBinaryFromNative)(buf, datum)
+}
+
+func BinaryFromNativeLocalTimeStampMillis(buf []byte, datum time.Time) ([]byte, error) {
+	return localTimeStampMillisFromNative(longBinaryFromNative)(buf, datum)
+}
+
+func BinaryFromNativeLocalTimeStampMicros(buf []byte, datum time.Time) ([]byte, error) {
+	return localTimeStampMicrosFromNative(longBinaryFromNative)(buf, datum)
+}
+
+func NativeFromBinaryUUID(buf []byte) (string, []byte, error) {
+	thing, newBuf, err := nativeFromUUID(stringNativeFromBinary)(buf)
+	if err != nil {
+		return "", buf, err
+	}
+	return thing.(string), newBuf, nil
+}
+
+func BinaryFromNativeUUID(buf []byte, datum string) ([]byte, error) {
+	return uuidFromNative(stringBinaryFromNative)(buf, datum)
+}
+
+func NativeFromBinaryDuration(buf []byte) (Duration, []byte, error) {
+	thing, newBuf, err := nativeFromDuration(fixedNativeFromBinary(durationSize))(buf)
+	if err != nil {
+		return Duration{}, buf, err
+	}
+	return thing.(Duration), newBuf, nil
+}
+
+func BinaryFromNativeDuration(buf []byte, datum Duration) ([]byte, error) {
+	return durationFromNative(fixedBinaryFromNative(durationSize))(buf, datum)
+}
+
+func NativeFromBinaryTimeMillis(buf []byte) (time.Duration, []byte, error) {
+	thing, newBuf, err := nativeFromTimeMillis(intNativeFromBinary)(buf)
+	if err != nil {
+		return 0, buf, err
+	}
+	return thing.(time.Duration), newBuf, nil
+}
+
+func BinaryFromNativeTimeMillis(buf []byte, datum time.Duration) ([]byte, error) {
+	return timeMillisFromNative(intBinaryFromNative)(buf, datum)
+}
+
+func NativeFromBinaryTimeMicros(buf []byte) (time.Duration, []byte, error) {
+	thing, newBuf, err := nativeFromTimeMicros(longNativeFromBinary)(buf)
+	if err != nil {
+		return 0, buf, err
+	}
+	return thing.(time.Duration), newBuf, nil
+}
+
+func BinaryFromNativeTimeMicros(buf []byte, datum time.Duration) ([]byte, error) {
+	return timeMicrosFromNative(longBinaryFromNative)(buf, datum)
+}
+
+// fixedNativeFromBinary returns a function that decodes a fixed of the
+// specified size, for use by the decimal and duration fixed wrappers.
+func fixedNativeFromBinary(size uint) toNativeFn {
+	return func(buf []byte) (interface{}, []byte, error) {
+		if buflen := uint(len(buf)); size > buflen {
+			return nil, nil, fmt.Errorf("cannot decode binary fixed: schema size exceeds remaining buffer size: %d > %d (short buffer)", size, buflen)
+		}
+		return buf[:size], buf[size:], nil
+	}
+}
+
+// fixedBinaryFromNative returns a function that encodes a fixed of the
+// specified size, for use by the decimal and duration fixed wrappers.
+func fixedBinaryFromNative(size uint) fromNativeFn {
+	return func(buf []byte, datum interface{}) ([]byte, error) {
+		someBytes, ok := datum.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("cannot encode binary fixed: expected []byte; received: %T", datum)
+		}
+		if count := uint(len(someBytes)); count != size {
+			return nil, fmt.Errorf("cannot encode binary fixed: datum size ought to equal schema size: %d != %d", count, size)
+		}
+		return append(buf, someBytes...), nil
+	}
+}
+
+func NativeFromBinaryDecimalFixed(buf []byte, size uint, precision int, scale int) (*big.Rat, []byte, error) {
+	thing, newBuf, err := nativeFromDecimalBytes(fixedNativeFromBinary(size), precision, scale)(buf)
+	if err != nil {
+		return &big.Rat{}, buf, err
+	}
+	return thing.(*big.Rat), newBuf, nil
+}
+
+func BinaryFromNativeDecimalFixed(buf []byte, datum *big.Rat, size uint, precision int, scale int) ([]byte, error) {
+	if datum == nil {
+		return buf, fmt.Errorf("cannot transform to bytes, expected *big.Rat, received nil")
+	}
+	return decimalBytesFromNative(fixedBinaryFromNative(size), toSignedFixedBytes(size), precision, scale)(buf, datum)
+}
+
 func DecodeBlockCount(buf []byte) (int64, []byte, error) {
+	return decodeBlockCount(buf, "array")
+}
+
+func DecodeMapBlockCount(buf []byte) (int64, []byte, error) {
+	return decodeBlockCount(buf, "map")
+}
+
+func decodeBlockCount(buf []byte, kind string) (int64, []byte, error) {
 	// block count and block size
 	var value interface{}
 	var err error
 	newBuf := buf
 	if value, newBuf, err = longNativeFromBinary(newBuf); err != nil {
-		return 0, buf, fmt.Errorf("cannot decode binary array block count: %s", err)
+		return 0, buf, fmt.Errorf("cannot decode binary %s block count: %s", kind, err)
 	}
 	blockCount := value.(int64)
 	if blockCount < 0 {
@@ -168,16 +348,16 @@ func DecodeBlockCount(buf []byte) (int64, []byte, error) {
 		// the value.
 		if blockCount == math.MinInt64 {
 			// The minimum number for any signed numerical type can never be made positive
-			return 0, buf, fmt.Errorf("cannot decode binary array with block count: %d", blockCount)
+			return 0, buf, fmt.Errorf("cannot decode binary %s with block count: %d", kind, blockCount)
 		}
 		blockCount = -blockCount // convert to its positive equivalent
 		if _, newBuf, err = longNativeFromBinary(newBuf); err != nil {
-			return 0, buf, fmt.Errorf("cannot decode binary array block size: %s", err)
+			return 0, buf, fmt.Errorf("cannot decode binary %s block size: %s", kind, err)
 		}
 	}
 	// Ensure block count does not exceed some sane value.
 	if blockCount > MaxBlockCount {
-		return 0, buf, fmt.Errorf("cannot decode binary array when block count exceeds MaxBlockCount: %d > %d", blockCount, MaxBlockCount)
+		return 0, buf, fmt.Errorf("cannot decode binary %s when block count exceeds MaxBlockCount: %d > %d", kind, blockCount, MaxBlockCount)
 	}
 	return blockCount, newBuf, nil
 }
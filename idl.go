@@ -0,0 +1,988 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseIDL returns the protocol declared by Avro IDL source, such as:
+//
+//     @namespace("com.example")
+//     protocol Shop {
+//         /** A customer of the shop. */
+//         record Customer {
+//             string name;
+//             union { null, string } email = null;
+//         }
+//         error NotFound { string message; }
+//         Customer lookup(string name) throws NotFound;
+//     }
+//
+// The files named by import statements, which declare Avro IDL ("import idl"),
+// a JSON protocol ("import protocol"), or a JSON schema ("import schema"), are
+// resolved against the current working directory. Use ParseIDLFile to resolve
+// them against the directory of an IDL file instead.
+func ParseIDL(src string) (*Protocol, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse IDL: %s", err)
+	}
+	p, err := parseIDL(src, dir, make(map[string]*Protocol))
+	if err != nil {
+		return nil, err
+	}
+	if err = p.compile(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ParseIDLFile returns the protocol declared by the Avro IDL file, resolving
+// the files named by its import statements against its directory.
+func ParseIDLFile(pathname string) (*Protocol, error) {
+	src, err := ioutil.ReadFile(pathname)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse IDL: %s", err)
+	}
+	p, err := parseIDL(string(src), filepath.Dir(pathname), make(map[string]*Protocol))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse IDL file %q: %s", pathname, err)
+	}
+	if err = p.compile(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NewCodecFromIDL returns a Codec for the named type declared by Avro IDL
+// source, whose imports are resolved like ParseIDL resolves them. The type
+// name is either a full name, or a name in the namespace of the protocol.
+func NewCodecFromIDL(src, typeName string) (*Codec, error) {
+	p, err := ParseIDL(src)
+	if err != nil {
+		return nil, err
+	}
+	return p.Codec(typeName)
+}
+
+func parseIDL(src, dir string, imported map[string]*Protocol) (*Protocol, error) {
+	tokens, err := lexIDL(src)
+	if err != nil {
+		return nil, err
+	}
+	parser := &idlParser{tokens: tokens, dir: dir, imported: imported, protocol: newProtocol()}
+	if err = parser.parseProtocol(); err != nil {
+		return nil, err
+	}
+	if err = parser.protocol.resolve(); err != nil {
+		return nil, fmt.Errorf("cannot parse IDL: %s", err)
+	}
+	return parser.protocol, nil
+}
+
+type idlTokenKind int
+
+const (
+	idlEOF idlTokenKind = iota
+	idlIdent
+	idlString
+	idlNumber
+	idlPunct
+)
+
+// idlToken is a token of Avro IDL source. Identifiers quoted with backticks
+// are never keywords.
+type idlToken struct {
+	kind   idlTokenKind
+	text   string // of strings, without quotes or escapes
+	quoted bool   // for identifiers quoted with backticks
+	doc    string // the documentation comment preceding the token
+	line   int
+}
+
+func (t idlToken) String() string {
+	switch t.kind {
+	case idlEOF:
+		return "end of input"
+	case idlString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lexIDL returns the tokens of Avro IDL source, dropping comments, except for
+// documentation comments, which are attached to the token that follows them.
+func lexIDL(src string) ([]idlToken, error) {
+	var tokens []idlToken
+	var doc string
+	line := 1
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case r == '\n':
+			line++
+			i++
+		case unicode.IsSpace(r):
+			i += size
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("cannot parse IDL: line %d: unterminated comment", line)
+			}
+			comment := src[i : i+2+end+2]
+			if strings.HasPrefix(comment, "/**") && comment != "/**/" {
+				doc = idlDocComment(comment[3 : len(comment)-2])
+			}
+			line += strings.Count(comment, "\n")
+			i += len(comment)
+		case r == '"':
+			// NOTE: IDL strings are JSON strings.
+			end := i + 1
+			for end < len(src) && src[end] != '"' {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(src) {
+				return nil, fmt.Errorf("cannot parse IDL: line %d: unterminated string", line)
+			}
+			var text string
+			if err := json.Unmarshal([]byte(src[i:end+1]), &text); err != nil {
+				return nil, fmt.Errorf("cannot parse IDL: line %d: invalid string: %s", line, err)
+			}
+			tokens = append(tokens, idlToken{kind: idlString, text: text, doc: doc, line: line})
+			doc = ""
+			i = end + 1
+		case r == '`':
+			end := strings.IndexByte(src[i+1:], '`')
+			if end < 0 {
+				return nil, fmt.Errorf("cannot parse IDL: line %d: unterminated quoted identifier", line)
+			}
+			tokens = append(tokens, idlToken{kind: idlIdent, text: src[i+1 : i+1+end], quoted: true, doc: doc, line: line})
+			doc = ""
+			i += end + 2
+		case r == '-' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9', r >= '0' && r <= '9':
+			end := i + 1
+			for end < len(src) && strings.IndexByte("0123456789.eE+-", src[end]) >= 0 {
+				if (src[end] == '+' || src[end] == '-') && src[end-1] != 'e' && src[end-1] != 'E' {
+					break
+				}
+				end++
+			}
+			tokens = append(tokens, idlToken{kind: idlNumber, text: src[i:end], doc: doc, line: line})
+			doc = ""
+			i = end
+		case unicode.IsLetter(r) || r == '_':
+			end := i + size
+			for end < len(src) {
+				r, size := utf8.DecodeRuneInString(src[end:])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens, idlToken{kind: idlIdent, text: src[i:end], doc: doc, line: line})
+			doc = ""
+			i = end
+		case strings.ContainsRune("{}()[]<>,;:=@.-?", r):
+			tokens = append(tokens, idlToken{kind: idlPunct, text: string(r), doc: doc, line: line})
+			doc = ""
+			i++
+		default:
+			return nil, fmt.Errorf("cannot parse IDL: line %d: unexpected character: %q", line, r)
+		}
+	}
+	return append(tokens, idlToken{kind: idlEOF, doc: doc, line: line}), nil
+}
+
+// idlDocComment returns the text of a documentation comment, without the
+// asterisks and indentation that start its lines after the first.
+func idlDocComment(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	starred := true
+	for _, l := range lines[1:] {
+		if !strings.HasPrefix(strings.TrimLeftFunc(l, unicode.IsSpace), "*") {
+			starred = false
+			break
+		}
+	}
+	for i := 1; i < len(lines); i++ {
+		l := strings.TrimLeftFunc(lines[i], unicode.IsSpace)
+		if starred {
+			l = strings.TrimPrefix(l, "*")
+			if strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t") {
+				l = l[1:]
+			}
+		}
+		lines[i] = l
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// idlParser parses the tokens of Avro IDL source into a protocol.
+type idlParser struct {
+	tokens   []idlToken
+	pos      int
+	doc      string // the documentation comment of the declaration being parsed
+	dir      string // against which imports are resolved
+	imported map[string]*Protocol
+	protocol *Protocol
+}
+
+func (p *idlParser) peek() idlToken {
+	return p.tokens[p.pos]
+}
+
+// next returns the next token, and remembers its documentation comment for the
+// declaration being parsed.
+func (p *idlParser) next() idlToken {
+	t := p.tokens[p.pos]
+	if t.kind != idlEOF {
+		p.pos++
+	}
+	if t.doc != "" {
+		p.doc = t.doc
+	}
+	return t
+}
+
+// takeDoc returns the documentation comment of the declaration being parsed,
+// which is the last one that precedes it, and forgets it.
+func (p *idlParser) takeDoc() string {
+	doc := p.doc
+	p.doc = ""
+	return doc
+}
+
+func (p *idlParser) errorf(t idlToken, format string, a ...interface{}) error {
+	return fmt.Errorf("cannot parse IDL: line %d: %s", t.line, fmt.Sprintf(format, a...))
+}
+
+// isPunct returns true when the next token is the punctuation character.
+func (p *idlParser) isPunct(punct string) bool {
+	t := p.peek()
+	return t.kind == idlPunct && t.text == punct
+}
+
+// isKeyword returns true when the next token is the keyword.
+func (p *idlParser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == idlIdent && !t.quoted && t.text == keyword
+}
+
+func (p *idlParser) expectPunct(punct string) error {
+	if t := p.next(); t.kind != idlPunct || t.text != punct {
+		return p.errorf(t, "expected %q; received: %s", punct, t)
+	}
+	return nil
+}
+
+func (p *idlParser) expectKeyword(keyword string) error {
+	if t := p.next(); t.kind != idlIdent || t.quoted || t.text != keyword {
+		return p.errorf(t, "expected %q; received: %s", keyword, t)
+	}
+	return nil
+}
+
+func (p *idlParser) identifier() (string, error) {
+	t := p.next()
+	if t.kind != idlIdent {
+		return "", p.errorf(t, "expected identifier; received: %s", t)
+	}
+	return t.text, nil
+}
+
+// qualifiedIdentifier returns an identifier, which may be qualified with a
+// namespace, such as "com.example.Customer".
+func (p *idlParser) qualifiedIdentifier() (string, error) {
+	name, err := p.identifier()
+	if err != nil {
+		return "", err
+	}
+	for p.isPunct(".") {
+		p.next()
+		part, err := p.identifier()
+		if err != nil {
+			return "", err
+		}
+		name += "." + part
+	}
+	return name, nil
+}
+
+func (p *idlParser) stringLiteral() (string, error) {
+	t := p.next()
+	if t.kind != idlString {
+		return "", p.errorf(t, "expected string; received: %s", t)
+	}
+	return t.text, nil
+}
+
+func (p *idlParser) intLiteral() (int, error) {
+	t := p.next()
+	if t.kind != idlNumber {
+		return 0, p.errorf(t, "expected integer; received: %s", t)
+	}
+	i, err := strconv.Atoi(t.text)
+	if err != nil {
+		return 0, p.errorf(t, "expected integer; received: %s", t)
+	}
+	return i, nil
+}
+
+// properties returns the properties declared by annotations such as
+// @namespace("com.example") and @java-class("java.util.ArrayList").
+func (p *idlParser) properties() (jsonObject, error) {
+	var props jsonObject
+	for p.isPunct("@") {
+		t := p.next()
+		name, err := p.identifier()
+		if err != nil {
+			return nil, err
+		}
+		for p.isPunct("-") || p.isPunct(".") {
+			separator := p.next().text
+			part, err := p.identifier()
+			if err != nil {
+				return nil, err
+			}
+			name += separator + part
+		}
+		if err = p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		value, err := p.jsonValue()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		if _, ok := props.get(name); ok {
+			return nil, p.errorf(t, "duplicate property: %q", name)
+		}
+		props = append(props, jsonMember{name, value})
+	}
+	return props, nil
+}
+
+// jsonValue returns the JSON value, such as a default value or the value of a
+// property.
+func (p *idlParser) jsonValue() (interface{}, error) {
+	t := p.next()
+	switch t.kind {
+	case idlString:
+		return t.text, nil
+	case idlNumber:
+		if !json.Valid([]byte(t.text)) {
+			return nil, p.errorf(t, "invalid number: %s", t)
+		}
+		return json.Number(t.text), nil
+	case idlIdent:
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	case idlPunct:
+		switch t.text {
+		case "[":
+			array := []interface{}{}
+			for !p.isPunct("]") {
+				if len(array) > 0 {
+					if err := p.expectPunct(","); err != nil {
+						return nil, err
+					}
+				}
+				value, err := p.jsonValue()
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, value)
+			}
+			p.next()
+			return array, nil
+		case "{":
+			object := jsonObject{}
+			for !p.isPunct("}") {
+				if len(object) > 0 {
+					if err := p.expectPunct(","); err != nil {
+						return nil, err
+					}
+				}
+				key, err := p.stringLiteral()
+				if err != nil {
+					return nil, err
+				}
+				if err = p.expectPunct(":"); err != nil {
+					return nil, err
+				}
+				value, err := p.jsonValue()
+				if err != nil {
+					return nil, err
+				}
+				object = append(object, jsonMember{key, value})
+			}
+			p.next()
+			return object, nil
+		}
+	}
+	return nil, p.errorf(t, "expected JSON value; received: %s", t)
+}
+
+// textProperty removes the property from props, and returns its string value.
+func (p *idlParser) textProperty(props *jsonObject, name string) (string, bool, error) {
+	for i, m := range *props {
+		if m.key == name {
+			*props = append((*props)[:i:i], (*props)[i+1:]...)
+			s, ok := m.value.(string)
+			if !ok {
+				return "", false, p.errorf(p.peek(), "property %q ought to be string; received: %T", name, m.value)
+			}
+			return s, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// textsProperty removes the property from props, and returns its array of
+// strings value.
+func (p *idlParser) textsProperty(props *jsonObject, name string) ([]string, error) {
+	for i, m := range *props {
+		if m.key == name {
+			*props = append((*props)[:i:i], (*props)[i+1:]...)
+			strs, err := stringsFromJSON(m.value)
+			if err != nil {
+				return nil, p.errorf(p.peek(), "property %q %s", name, err)
+			}
+			return strs, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *idlParser) parseProtocol() error {
+	props, err := p.properties()
+	if err != nil {
+		return err
+	}
+	if err = p.expectKeyword("protocol"); err != nil {
+		return err
+	}
+	if p.protocol.name, err = p.identifier(); err != nil {
+		return err
+	}
+	p.protocol.doc = p.takeDoc()
+	if p.protocol.namespace, _, err = p.textProperty(&props, "namespace"); err != nil {
+		return err
+	}
+	p.protocol.props = props
+	if err = p.expectPunct("{"); err != nil {
+		return err
+	}
+	for !p.isPunct("}") {
+		if p.peek().kind == idlEOF {
+			return p.errorf(p.peek(), "expected %q; received: %s", "}", p.peek())
+		}
+		if p.isKeyword("import") {
+			if err = p.parseImport(); err != nil {
+				return err
+			}
+			continue
+		}
+		props, err := p.properties()
+		if err != nil {
+			return err
+		}
+		switch {
+		case p.isKeyword("record"), p.isKeyword("error"):
+			err = p.parseRecord(props)
+		case p.isKeyword("enum"):
+			err = p.parseEnum(props)
+		case p.isKeyword("fixed"):
+			err = p.parseFixed(props)
+		default:
+			err = p.parseMessage(props)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	p.next()
+	if t := p.next(); t.kind != idlEOF {
+		return p.errorf(t, "expected end of input; received: %s", t)
+	}
+	return nil
+}
+
+func (p *idlParser) parseImport() error {
+	p.next()
+	kind, err := p.identifier()
+	if err != nil {
+		return err
+	}
+	t := p.peek()
+	file, err := p.stringLiteral()
+	if err != nil {
+		return err
+	}
+	if err = p.expectPunct(";"); err != nil {
+		return err
+	}
+	p.takeDoc()
+	pathname := file
+	if !filepath.IsAbs(pathname) {
+		pathname = filepath.Join(p.dir, pathname)
+	}
+	if abs, err := filepath.Abs(pathname); err == nil {
+		pathname = abs
+	}
+
+	imported, ok := p.imported[pathname]
+	if !ok {
+		var data []byte
+		if data, err = ioutil.ReadFile(pathname); err != nil {
+			return p.errorf(t, "cannot import %s: %s", kind, err)
+		}
+		// NOTE: Mark the file before parsing it, so that files importing each
+		// other are parsed once.
+		p.imported[pathname] = nil
+		switch kind {
+		case "idl":
+			imported, err = parseIDL(string(data), filepath.Dir(pathname), p.imported)
+		case "protocol":
+			var v interface{}
+			if v, err = parseOrderedJSON(data); err == nil {
+				imported, err = protocolFromJSON(v)
+			}
+		case "schema":
+			var v interface{}
+			if v, err = parseOrderedJSON(data); err == nil {
+				imported = newProtocol()
+				imported.name = file
+				_, err = imported.schemaFromJSON(nullNamespace, v)
+			}
+		default:
+			return p.errorf(t, "cannot import %q: unknown kind of import: %q", file, kind)
+		}
+		if err != nil {
+			return p.errorf(t, "cannot import %s %q: %s", kind, file, err)
+		}
+		p.imported[pathname] = imported
+	}
+	if imported == nil {
+		return nil
+	}
+	if err = p.protocol.merge(imported); err != nil {
+		return p.errorf(t, "cannot import %s %q: %s", kind, file, err)
+	}
+	return nil
+}
+
+// namedType returns the named type declared by the next name, whose namespace
+// is given by its namespace property, or is the namespace of the protocol.
+func (p *idlParser) namedType(typ string, props *jsonObject) (*protocolSchema, error) {
+	t := p.peek()
+	name, err := p.qualifiedIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	s := &protocolSchema{typ: typ, doc: p.takeDoc(), fullName: name}
+	namespace, ok, err := p.textProperty(props, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		namespace = p.protocol.namespace
+	}
+	if namespace != nullNamespace && !strings.ContainsRune(name, '.') {
+		s.fullName = namespace + "." + name
+	}
+	aliases, err := p.textsProperty(props, "aliases")
+	if err != nil {
+		return nil, err
+	}
+	for _, alias := range aliases {
+		if ns := namespaceOf(s.fullName); ns != nullNamespace && !strings.ContainsRune(alias, '.') {
+			alias = ns + "." + alias
+		}
+		s.aliases = append(s.aliases, alias)
+	}
+	s.props = *props
+	if err = p.protocol.define(s); err != nil {
+		return nil, p.errorf(t, "%s", err)
+	}
+	return s, nil
+}
+
+func (p *idlParser) parseFixed(props jsonObject) error {
+	p.next()
+	s, err := p.namedType("fixed", &props)
+	if err != nil {
+		return err
+	}
+	if err = p.expectPunct("("); err != nil {
+		return err
+	}
+	if s.size, err = p.intLiteral(); err != nil {
+		return err
+	}
+	if err = p.expectPunct(")"); err != nil {
+		return err
+	}
+	return p.expectPunct(";")
+}
+
+func (p *idlParser) parseEnum(props jsonObject) error {
+	p.next()
+	s, err := p.namedType("enum", &props)
+	if err != nil {
+		return err
+	}
+	if err = p.expectPunct("{"); err != nil {
+		return err
+	}
+	for !p.isPunct("}") {
+		if len(s.symbols) > 0 {
+			if err = p.expectPunct(","); err != nil {
+				return err
+			}
+		}
+		t := p.peek()
+		symbol, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		for _, other := range s.symbols {
+			if other == symbol {
+				return p.errorf(t, "enum %q symbol ought to be unique: %q", s.fullName, symbol)
+			}
+		}
+		s.symbols = append(s.symbols, symbol)
+	}
+	p.next()
+	if p.isPunct("=") {
+		p.next()
+		if s.enumDflt, err = p.identifier(); err != nil {
+			return err
+		}
+		if err = p.expectPunct(";"); err != nil {
+			return err
+		}
+	} else if p.isPunct(";") {
+		p.next()
+	}
+	p.takeDoc()
+	return nil
+}
+
+func (p *idlParser) parseRecord(props jsonObject) error {
+	typ := p.next().text
+	s, err := p.namedType(typ, &props)
+	if err != nil {
+		return err
+	}
+	if err = p.expectPunct("{"); err != nil {
+		return err
+	}
+	for !p.isPunct("}") {
+		fields, err := p.parseFieldDeclaration(namespaceOf(s.fullName))
+		if err != nil {
+			return err
+		}
+		s.fields = append(s.fields, fields...)
+	}
+	p.next()
+	p.takeDoc()
+	return nil
+}
+
+// parseFieldDeclaration returns the fields declared by a type followed by one
+// or more variables, such as "int x = 1, y = 2;".
+func (p *idlParser) parseFieldDeclaration(namespace string) ([]*protocolField, error) {
+	typ, err := p.parseType(namespace)
+	if err != nil {
+		return nil, err
+	}
+	var fields []*protocolField
+	for {
+		f, err := p.parseVariable(typ)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+		if !p.isPunct(",") {
+			break
+		}
+		p.next()
+	}
+	return fields, p.expectPunct(";")
+}
+
+// parseVariable returns a field of the type, or a message parameter, whose
+// name may be preceded by its properties and followed by its default value.
+func (p *idlParser) parseVariable(typ *protocolSchema) (*protocolField, error) {
+	props, err := p.properties()
+	if err != nil {
+		return nil, err
+	}
+	f := &protocolField{typ: typ}
+	if f.name, err = p.identifier(); err != nil {
+		return nil, err
+	}
+	f.doc = p.takeDoc()
+	if p.isPunct("=") {
+		p.next()
+		if f.dflt, err = p.jsonValue(); err != nil {
+			return nil, err
+		}
+		f.hasDefault = true
+	}
+	if f.order, _, err = p.textProperty(&props, "order"); err != nil {
+		return nil, err
+	}
+	switch f.order {
+	case "", "ascending", "descending", "ignore":
+	default:
+		return nil, p.errorf(p.peek(), "field %q order ought to be ascending, descending or ignore: %q", f.name, f.order)
+	}
+	if f.aliases, err = p.textsProperty(&props, "aliases"); err != nil {
+		return nil, err
+	}
+	f.props = props
+
+	// NOTE: An optional type, such as "string?", is a union with null, whose
+	// first branch is that of the default value.
+	if typ.typ == "union" && len(typ.branches) == 2 && typ.branches[0].typ == "null" && typ.optional() && f.hasDefault && f.dflt != nil {
+		f.typ = &protocolSchema{typ: "union", branches: []*protocolSchema{typ.branches[1], typ.branches[0]}}
+	}
+	return f, nil
+}
+
+// idlLogicalTypes are the IDL keywords for types with logical types.
+var idlLogicalTypes = map[string][2]string{
+	"date":               {"int", "date"},
+	"time_ms":            {"int", "time-millis"},
+	"timestamp_ms":       {"long", "timestamp-millis"},
+	"local_timestamp_ms": {"long", "local-timestamp-millis"},
+	"uuid":               {"string", "uuid"},
+}
+
+// parseType returns the type, preceded by its properties, and followed by a
+// question mark when it is optional.
+func (p *idlParser) parseType(namespace string) (*protocolSchema, error) {
+	props, err := p.properties()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.kind != idlIdent {
+		return nil, p.errorf(t, "expected type; received: %s", t)
+	}
+	var s *protocolSchema
+	switch keyword := t.text; {
+	case t.quoted:
+		if s, err = p.parseReference(namespace); err != nil {
+			return nil, err
+		}
+	case keyword == "array" || keyword == "map":
+		p.next()
+		if err = p.expectPunct("<"); err != nil {
+			return nil, err
+		}
+		s = &protocolSchema{typ: keyword}
+		if s.items, err = p.parseType(namespace); err != nil {
+			return nil, err
+		}
+		if err = p.expectPunct(">"); err != nil {
+			return nil, err
+		}
+	case keyword == "union":
+		p.next()
+		if err = p.expectPunct("{"); err != nil {
+			return nil, err
+		}
+		s = &protocolSchema{typ: "union"}
+		for !p.isPunct("}") {
+			if len(s.branches) > 0 {
+				if err = p.expectPunct(","); err != nil {
+					return nil, err
+				}
+			}
+			b, err := p.parseType(namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.branches = append(s.branches, b)
+		}
+		p.next()
+	case keyword == "decimal":
+		p.next()
+		if err = p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		precision, err := p.intLiteral()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectPunct(","); err != nil {
+			return nil, err
+		}
+		scale, err := p.intLiteral()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		s = &protocolSchema{typ: "bytes", props: jsonObject{
+			{"logicalType", "decimal"},
+			{"precision", json.Number(strconv.Itoa(precision))},
+			{"scale", json.Number(strconv.Itoa(scale))},
+		}}
+	default:
+		switch keyword {
+		case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+			p.next()
+			s = &protocolSchema{typ: keyword}
+		default:
+			if logical, ok := idlLogicalTypes[keyword]; ok {
+				p.next()
+				s = &protocolSchema{typ: logical[0], props: jsonObject{{"logicalType", logical[1]}}}
+			} else if s, err = p.parseReference(namespace); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(props) > 0 {
+		target := s
+		if s.typ == "" {
+			// NOTE: Like Avro's own implementation, properties of a type
+			// reference are properties of the named type.
+			target = p.protocol.named[s.ref]
+			if target == nil {
+				target = p.protocol.named[namespace+"."+s.ref]
+			}
+			if target == nil {
+				return nil, p.errorf(t, "cannot set properties of type %q: not declared", s.ref)
+			}
+		}
+		target.props = append(target.props, props...)
+	}
+	if p.isPunct("?") {
+		p.next()
+		s = &protocolSchema{typ: "union", branches: []*protocolSchema{{typ: "null"}, s}, props: jsonObject{{idlOptional, true}}}
+	}
+	return s, nil
+}
+
+// idlOptional marks the union of an optional type, whose branches are swapped
+// when the default value of a field of that type is not null. It is never
+// written, because unions have no properties.
+const idlOptional = "\x00optional"
+
+func (s *protocolSchema) optional() bool {
+	_, ok := s.props.get(idlOptional)
+	return ok
+}
+
+func (p *idlParser) parseReference(namespace string) (*protocolSchema, error) {
+	name, err := p.qualifiedIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	return &protocolSchema{ref: name, refNamespace: namespace}, nil
+}
+
+func (p *idlParser) parseMessage(props jsonObject) error {
+	m := &Message{}
+	t := p.peek()
+	var err error
+	if p.isKeyword("void") {
+		p.next()
+		m.response = &protocolSchema{typ: "null"}
+	} else if m.response, err = p.parseType(p.protocol.namespace); err != nil {
+		return err
+	}
+	if m.name, err = p.identifier(); err != nil {
+		return err
+	}
+	m.doc = p.takeDoc()
+	m.props = props
+	if err = p.expectPunct("("); err != nil {
+		return err
+	}
+	for !p.isPunct(")") {
+		if len(m.request) > 0 {
+			if err = p.expectPunct(","); err != nil {
+				return err
+			}
+		}
+		typ, err := p.parseType(p.protocol.namespace)
+		if err != nil {
+			return err
+		}
+		f, err := p.parseVariable(typ)
+		if err != nil {
+			return err
+		}
+		m.request = append(m.request, f)
+	}
+	p.next()
+	switch {
+	case p.isKeyword("oneway"):
+		p.next()
+		if m.response.typ != "null" {
+			return p.errorf(t, "one-way message %q ought to return void", m.name)
+		}
+		m.oneWay = true
+	case p.isKeyword("throws"):
+		p.next()
+		for {
+			e, err := p.parseReference(p.protocol.namespace)
+			if err != nil {
+				return err
+			}
+			m.errors = append(m.errors, e)
+			if !p.isPunct(",") {
+				break
+			}
+			p.next()
+		}
+	}
+	if err = p.expectPunct(";"); err != nil {
+		return err
+	}
+	p.takeDoc()
+	if p.protocol.Message(m.name) != nil {
+		return p.errorf(t, "cannot declare message %q: already declared", m.name)
+	}
+	p.protocol.messages = append(p.protocol.messages, m)
+	return nil
+}
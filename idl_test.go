@@ -0,0 +1,358 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testShopIDL = `
+/** Sells things. */
+@namespace("com.example")
+protocol Shop {
+  /** A kind of customer. */
+  @aliases(["Sort"])
+  enum Kind { REGULAR, GOLD } = REGULAR;
+
+  fixed MD5(16);
+
+  record Customer {
+    /** The name. */
+    string @order("descending") name;
+    Kind kind = "GOLD";
+    union { null, MD5 } hash = null;
+    int visits = 1, orders = 2;
+    string? email;
+    long? points = 3;
+    decimal(9,2) balance;
+    date birthday;
+  }
+
+  error NotFound { string message; }
+
+  /** Finds a customer. */
+  Customer lookup(string name, int limit = 10) throws NotFound;
+  void ping() oneway;
+}
+`
+
+func TestParseIDL(t *testing.T) {
+	p, err := ParseIDL(testShopIDL)
+	ensureError(t, err)
+
+	if got, want := p.Name(), "Shop"; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := p.Namespace(), "com.example"; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := p.Doc(), "Sells things."; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := p.TypeNames(), []string{"com.example.Kind", "com.example.MD5", "com.example.Customer", "com.example.NotFound"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	messages := p.Messages()
+	if got, want := len(messages), 2; got != want {
+		t.Fatalf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := messages[0].Name(), "lookup"; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := messages[0].Doc(), "Finds a customer."; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if messages[0].OneWay() {
+		t.Errorf("GOT: %v; WANT: %v", true, false)
+	}
+	if m := p.Message("ping"); m == nil || !m.OneWay() {
+		t.Errorf("GOT: %v; WANT: %v", m, "one-way message")
+	}
+	if m := p.Message("missing"); m != nil {
+		t.Errorf("GOT: %v; WANT: %v", m, nil)
+	}
+}
+
+func TestParseIDLSchema(t *testing.T) {
+	p, err := ParseIDL(testShopIDL)
+	ensureError(t, err)
+
+	// NOTE: The same JSON is written by avro-tools idl2schemata.
+	schema, err := p.Schema("Customer")
+	ensureError(t, err)
+	want := `{
+  "type" : "record",
+  "name" : "Customer",
+  "namespace" : "com.example",
+  "fields" : [ {
+    "name" : "name",
+    "type" : "string",
+    "doc" : "The name.",
+    "order" : "descending"
+  }, {
+    "name" : "kind",
+    "type" : {
+      "type" : "enum",
+      "name" : "Kind",
+      "doc" : "A kind of customer.",
+      "symbols" : [ "REGULAR", "GOLD" ],
+      "default" : "REGULAR",
+      "aliases" : [ "Sort" ]
+    },
+    "default" : "GOLD"
+  }, {
+    "name" : "hash",
+    "type" : [ "null", {
+      "type" : "fixed",
+      "name" : "MD5",
+      "size" : 16
+    } ],
+    "default" : null
+  }, {
+    "name" : "visits",
+    "type" : "int",
+    "default" : 1
+  }, {
+    "name" : "orders",
+    "type" : "int",
+    "default" : 2
+  }, {
+    "name" : "email",
+    "type" : [ "null", "string" ]
+  }, {
+    "name" : "points",
+    "type" : [ "long", "null" ],
+    "default" : 3
+  }, {
+    "name" : "balance",
+    "type" : {
+      "type" : "bytes",
+      "logicalType" : "decimal",
+      "precision" : 9,
+      "scale" : 2
+    }
+  }, {
+    "name" : "birthday",
+    "type" : {
+      "type" : "int",
+      "logicalType" : "date"
+    }
+  } ]
+}`
+	if schema != want {
+		t.Errorf("GOT:\n%s\nWANT:\n%s", schema, want)
+	}
+
+	_, err = p.Schema("com.example.Missing")
+	ensureError(t, err, "com.example.Missing", "cannot find type")
+}
+
+func TestParseIDLProtocolJSON(t *testing.T) {
+	p, err := ParseIDL(`@namespace("org.ping") protocol Ping {
+  record Pong { long at; }
+  Pong ping(long at) throws Failure;
+  error Failure { string reason; }
+}`)
+	ensureError(t, err)
+
+	// NOTE: The same JSON is written by avro-tools idl.
+	want := `{
+  "protocol" : "Ping",
+  "namespace" : "org.ping",
+  "types" : [ {
+    "type" : "record",
+    "name" : "Pong",
+    "fields" : [ {
+      "name" : "at",
+      "type" : "long"
+    } ]
+  }, {
+    "type" : "error",
+    "name" : "Failure",
+    "fields" : [ {
+      "name" : "reason",
+      "type" : "string"
+    } ]
+  } ],
+  "messages" : {
+    "ping" : {
+      "request" : [ {
+        "name" : "at",
+        "type" : "long"
+      } ],
+      "response" : "Pong",
+      "errors" : [ "Failure" ]
+    }
+  }
+}`
+	if got := p.JSON(); got != want {
+		t.Errorf("GOT:\n%s\nWANT:\n%s", got, want)
+	}
+
+	want = `{"protocol":"Ping","namespace":"org.ping","types":[{"type":"record","name":"Pong","fields":[{"name":"at","type":"long"}]},{"type":"error","name":"Failure","fields":[{"name":"reason","type":"string"}]}],"messages":{"ping":{"request":[{"name":"at","type":"long"}],"response":"Pong","errors":["Failure"]}}}`
+	if got := p.String(); got != want {
+		t.Errorf("GOT:\n%s\nWANT:\n%s", got, want)
+	}
+}
+
+func TestNewCodecFromIDL(t *testing.T) {
+	c, err := NewCodecFromIDL(testShopIDL, "com.example.Customer")
+	ensureError(t, err)
+
+	datum := map[string]interface{}{
+		"name":     "Ann",
+		"kind":     "GOLD",
+		"hash":     nil,
+		"visits":   int32(4),
+		"orders":   int32(5),
+		"email":    Union("string", "ann@example.com"),
+		"points":   nil,
+		"balance":  big.NewRat(1234, 100),
+		"birthday": time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	buf, err := c.BinaryFromNative(nil, datum)
+	ensureError(t, err)
+	decoded, _, err := c.NativeFromBinary(buf)
+	ensureError(t, err)
+	if got, want := decoded.(map[string]interface{})["email"], Union("string", "ann@example.com"); !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	testBinaryCodecPass(t, `{"type":"error","name":"NotFound","fields":[{"name":"message","type":"string"}]}`, map[string]interface{}{"message": "gone"}, []byte("\x08gone"))
+
+	c, err = NewCodecFromIDL(testShopIDL, "NotFound")
+	ensureError(t, err)
+	if got, want := c.CanonicalSchema(), `{"name":"com.example.NotFound","type":"record","fields":[{"name":"message","type":"string"}]}`; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestIDLDecimalsWithDifferentScales(t *testing.T) {
+	// The types of a protocol are compiled together, but each decimal keeps
+	// its own precision and scale.
+	p, err := ParseIDL(`protocol P {
+  record R { decimal(4,2) a; decimal(38,18) b; }
+  record S { decimal(9,1) c; }
+}`)
+	ensureError(t, err)
+	c, err := p.Codec("R")
+	ensureError(t, err)
+
+	buf, err := c.BinaryFromNative(nil, map[string]interface{}{"a": big.NewRat(617, 50), "b": big.NewRat(1, 3)})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x04\x04\xd2\x10\x04\xa0\x3c\xe6\x8d\x21\x55\x55"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+	decoded, _, err := c.NativeFromBinary(buf)
+	ensureError(t, err)
+	third, _ := new(big.Rat).SetString("333333333333333333/1000000000000000000")
+	if got, want := decoded.(map[string]interface{})["b"].(*big.Rat), third; got.Cmp(want) != 0 {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	c, err = p.Codec("S")
+	ensureError(t, err)
+	buf, err = c.BinaryFromNative(nil, map[string]interface{}{"c": big.NewRat(1, 3)})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x02\x03"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+}
+
+func TestParseIDLFileImports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goavro-idl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"common.avdl":        `@namespace("org.common") protocol Common { fixed Id(4); }`,
+		"status.avsc":        `{"type":"enum","name":"org.status.Status","symbols":["ON","OFF"]}`,
+		"nested/events.avpr": `{"protocol":"Events","namespace":"org.events","types":[{"type":"record","name":"Event","fields":[{"name":"at","type":"long"}]}],"messages":{}}`,
+		"main.avdl": `@namespace("org.main")
+protocol Main {
+  import idl "common.avdl";
+  import schema "status.avsc";
+  import protocol "nested/events.avpr";
+  import idl "common.avdl";
+  record Device {
+    org.common.Id id;
+    org.status.Status status;
+    array<org.events.Event> events;
+  }
+}`,
+	}
+	for name, contents := range files {
+		pathname := filepath.Join(dir, name)
+		if err = os.MkdirAll(filepath.Dir(pathname), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(pathname, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := ParseIDLFile(filepath.Join(dir, "main.avdl"))
+	ensureError(t, err)
+	if got, want := p.TypeNames(), []string{"org.common.Id", "org.status.Status", "org.events.Event", "org.main.Device"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	schema, err := p.Schema("Device")
+	ensureError(t, err)
+	c, err := NewCodec(schema)
+	ensureError(t, err)
+	buf, err := c.BinaryFromNative(nil, map[string]interface{}{
+		"id":     []byte("abcd"),
+		"status": "OFF",
+		"events": []interface{}{map[string]interface{}{"at": int64(1)}},
+	})
+	ensureError(t, err)
+	if got, want := buf, []byte("abcd\x02\x02\x02\x00"); !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+
+	_, err = ParseIDLFile(filepath.Join(dir, "missing.avdl"))
+	ensureError(t, err, "cannot parse IDL")
+
+	_, err = ParseIDL(`protocol P { import idl "` + filepath.Join(dir, "missing.avdl") + `"; }`)
+	ensureError(t, err, "cannot import idl")
+}
+
+func TestParseIDLErrors(t *testing.T) {
+	cases := []struct {
+		src  string
+		want string
+	}{
+		{`protocol P { record R { Missing m; } }`, `"Missing": not declared`},
+		{`protocol P { fixed F(1); fixed F(2); }`, "already declared"},
+		{`protocol P { record R { int @order("sideways") i; } }`, "order"},
+		{`protocol P { int ping() oneway; }`, "ought to return void"},
+		{`protocol P { void ping() throws Missing; }`, "Missing"},
+		{`protocol P { void ping(); void ping(); }`, "ping"},
+		{`protocol P { record R { int i } }`, "line 1"},
+		{`protocol P { enum E { A, A } }`, "E"},
+		{`protocol P { record 1R { int i; } }`, "1"},
+		{`protocol P { } extra`, "end of input"},
+	}
+	for _, c := range cases {
+		_, err := ParseIDL(c.src)
+		ensureError(t, err, "cannot parse IDL", c.want)
+	}
+}
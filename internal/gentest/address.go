@@ -0,0 +1,221 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"sync"
+)
+
+type Address struct {
+	Street string `avro:"street"`
+	Zip    *int32 `avro:"zip"`
+}
+
+func NewAddress(buf []byte) (*Address, []byte, error) {
+	return NewAddressAtDepth(buf, 0)
+}
+
+// NewAddressAtDepth is like NewAddress, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewAddressAtDepth(buf []byte, depth int) (*Address, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.address", goavro.MaxDecodeDepth)
+	}
+	result := &Address{}
+	newBuf := buf
+	var err error
+
+	if result.Street, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.address", "street", err)
+	}
+
+	if result.Zip, newBuf, err = func(buf []byte) (*int32, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.IntNativePtrFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.address", "zip", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Address) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.address")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = goavro.StringBinaryFromNative(newBuf, r.Street); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.address", "street", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *int32) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, v *int32) ([]byte, error) {
+			return goavro.IntBinaryFromNative(buf, *v)
+		}(buf, v)
+	}(newBuf, r.Zip); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.address", "zip", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewAddressWithDefaults returns a new Address, with each field that has a default
+// value in the schema set to that value.
+func NewAddressWithDefaults() *Address {
+	r := &Address{}
+	var err error
+	const depth = 0
+	if r.Zip, _, err = func(buf []byte) (*int32, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.IntNativePtrFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.address", "zip", err))
+	}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Address.
+func (r *Address) Schema() string {
+	return `{"name":"com.example.gentest.address","type":"record","fields":[{"name":"street","type":"string"},{"name":"zip","type":["null","int"]}]}`
+}
+
+var codecForAddress struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Address, which is built the first time
+// it is requested.
+func (r *Address) Codec() (*goavro.Codec, error) {
+	codecForAddress.once.Do(func() {
+		codecForAddress.codec, codecForAddress.err = goavro.NewCodec(`{"fields":[{"name":"street","type":"string"},{"default":null,"name":"zip","type":["null","int"]}],"name":"com.example.gentest.address","type":"record"}`)
+	})
+	return codecForAddress.codec, codecForAddress.err
+}
+
+// AddressOCFReader reads Address records from an Avro Object Container File.
+type AddressOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewAddressOCFReader returns a reader of the Address records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Address,
+// or resolves to it once its names are fully qualified.
+func NewAddressOCFReader(ior io.Reader) (*AddressOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Address)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.address", err)
+	}
+	return &AddressOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *AddressOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Address record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *AddressOCFReader) Read() (*Address, error) {
+	var result *Address
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewAddress(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *AddressOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *AddressOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// AddressOCFWriter appends Address records to an Avro Object Container File.
+type AddressOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewAddressOCFWriter returns a writer of Address records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Address
+// is used. It returns an error unless the schema of the file is the schema of
+// Address, or resolves to it once its names are fully qualified.
+func NewAddressOCFWriter(config goavro.OCFConfig) (*AddressOCFWriter, error) {
+	codec, err := (*Address)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.address", err)
+	}
+	return &AddressOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Address records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *AddressOCFWriter) Append(values ...*Address) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *AddressOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
@@ -0,0 +1,46 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"sync"
+)
+
+type Checksum [2]byte
+
+func NewChecksum(buf []byte) (Checksum, []byte, error) {
+	var result Checksum
+	if buflen := len(buf); buflen < 2 {
+		return result, buf, fmt.Errorf("cannot decode binary fixed %q: schema size exceeds remaining buffer size: 2 > %d (short buffer)", "com.example.gentest.checksum", buflen)
+	}
+	copy(result[:], buf)
+	return result, buf[2:], nil
+}
+
+func (f Checksum) MarshalAvro(buf []byte) ([]byte, error) {
+	return append(buf, f[:]...), nil
+}
+
+// Schema returns the canonical form of the schema for Checksum.
+func (Checksum) Schema() string {
+	return `{"name":"com.example.gentest.checksum","type":"fixed","size":2}`
+}
+
+var codecForChecksum struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Checksum, which is built the first time
+// it is requested.
+func (Checksum) Codec() (*goavro.Codec, error) {
+	codecForChecksum.once.Do(func() {
+		codecForChecksum.codec, codecForChecksum.err = goavro.NewCodec(`{"name":"com.example.gentest.checksum","size":2,"type":"fixed"}`)
+	})
+	return codecForChecksum.codec, codecForChecksum.err
+}
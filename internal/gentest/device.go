@@ -0,0 +1,169 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"sync"
+)
+
+type Device struct {
+	Serial string `avro:"serial"`
+}
+
+func NewDevice(buf []byte) (*Device, []byte, error) {
+	return NewDeviceAtDepth(buf, 0)
+}
+
+// NewDeviceAtDepth is like NewDevice, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewDeviceAtDepth(buf []byte, depth int) (*Device, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.device", goavro.MaxDecodeDepth)
+	}
+	result := &Device{}
+	newBuf := buf
+	var err error
+
+	if result.Serial, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.device", "serial", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Device) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.device")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = goavro.StringBinaryFromNative(newBuf, r.Serial); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.device", "serial", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewDeviceWithDefaults returns a new Device, with each field that has a default
+// value in the schema set to that value.
+func NewDeviceWithDefaults() *Device {
+	r := &Device{}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Device.
+func (r *Device) Schema() string {
+	return `{"name":"com.example.gentest.device","type":"record","fields":[{"name":"serial","type":"string"}]}`
+}
+
+var codecForDevice struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Device, which is built the first time
+// it is requested.
+func (r *Device) Codec() (*goavro.Codec, error) {
+	codecForDevice.once.Do(func() {
+		codecForDevice.codec, codecForDevice.err = goavro.NewCodec(`{"fields":[{"name":"serial","type":"string"}],"name":"com.example.gentest.device","type":"record"}`)
+	})
+	return codecForDevice.codec, codecForDevice.err
+}
+
+// DeviceOCFReader reads Device records from an Avro Object Container File.
+type DeviceOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewDeviceOCFReader returns a reader of the Device records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Device,
+// or resolves to it once its names are fully qualified.
+func NewDeviceOCFReader(ior io.Reader) (*DeviceOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Device)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.device", err)
+	}
+	return &DeviceOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *DeviceOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Device record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *DeviceOCFReader) Read() (*Device, error) {
+	var result *Device
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewDevice(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *DeviceOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *DeviceOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// DeviceOCFWriter appends Device records to an Avro Object Container File.
+type DeviceOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewDeviceOCFWriter returns a writer of Device records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Device
+// is used. It returns an error unless the schema of the file is the schema of
+// Device, or resolves to it once its names are fully qualified.
+func NewDeviceOCFWriter(config goavro.OCFConfig) (*DeviceOCFWriter, error) {
+	codec, err := (*Device)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.device", err)
+	}
+	return &DeviceOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Device records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *DeviceOCFWriter) Append(values ...*Device) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *DeviceOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
@@ -0,0 +1,377 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"sync"
+)
+
+type Event struct {
+	Key         UnionNullStringLong                `avro:"key"`
+	PreviousKey UnionNullStringLong                `avro:"previousKey"`
+	Amount      UnionIntDouble                     `avro:"amount"`
+	Only        UnionString                        `avro:"only"`
+	Source      UnionDeviceUserNull                `avro:"source"`
+	When        UnionStringLongTimestampMillis     `avro:"when"`
+	Attempts    []UnionNullIntString               `avro:"attempts"`
+	Extras      map[string]UnionBooleanDoubleArray `avro:"extras"`
+}
+
+func NewEvent(buf []byte) (*Event, []byte, error) {
+	return NewEventAtDepth(buf, 0)
+}
+
+// NewEventAtDepth is like NewEvent, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewEventAtDepth(buf []byte, depth int) (*Event, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.event", goavro.MaxDecodeDepth)
+	}
+	result := &Event{}
+	newBuf := buf
+	var err error
+
+	if result.Key, newBuf, err = func(buf []byte) (UnionNullStringLong, []byte, error) {
+		return NewUnionNullStringLongAtDepth(buf, depth)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.event", "key", err)
+	}
+
+	if result.PreviousKey, newBuf, err = func(buf []byte) (UnionNullStringLong, []byte, error) {
+		return NewUnionNullStringLongAtDepth(buf, depth)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.event", "previousKey", err)
+	}
+
+	if result.Amount, newBuf, err = func(buf []byte) (UnionIntDouble, []byte, error) {
+		return NewUnionIntDoubleAtDepth(buf, depth)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.event", "amount", err)
+	}
+
+	if result.Only, newBuf, err = func(buf []byte) (UnionString, []byte, error) {
+		return NewUnionStringAtDepth(buf, depth)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.event", "only", err)
+	}
+
+	if result.Source, newBuf, err = func(buf []byte) (UnionDeviceUserNull, []byte, error) {
+		return NewUnionDeviceUserNullAtDepth(buf, depth)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.event", "source", err)
+	}
+
+	if result.When, newBuf, err = func(buf []byte) (UnionStringLongTimestampMillis, []byte, error) {
+		return NewUnionStringLongTimestampMillisAtDepth(buf, depth)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.event", "when", err)
+	}
+
+	if result.Attempts, newBuf, err = func(buf []byte) ([]UnionNullIntString, []byte, error) {
+		var value UnionNullIntString
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+		if err != nil {
+			return []UnionNullIntString{}, buf, err
+		}
+
+		arrayValues := make([]UnionNullIntString, 0, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if value, tmpBuf, err = func(buf []byte) (UnionNullIntString, []byte, error) {
+					return NewUnionNullIntStringAtDepth(buf, depth)
+				}(tmpBuf); err != nil {
+					return []UnionNullIntString{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				} else {
+					arrayValues = append(arrayValues, value)
+
+				}
+			}
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []UnionNullIntString{}, buf, err
+			}
+
+		}
+		return arrayValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.event", "attempts", err)
+	}
+
+	if result.Extras, newBuf, err = func(buf []byte) (map[string]UnionBooleanDoubleArray, []byte, error) {
+		var key string
+		var value UnionBooleanDoubleArray
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+		if err != nil {
+			return map[string]UnionBooleanDoubleArray{}, buf, err
+		}
+
+		mapValues := make(map[string]UnionBooleanDoubleArray, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if key, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+					return map[string]UnionBooleanDoubleArray{}, buf, fmt.Errorf("cannot decode binary map key: %s", err)
+				}
+				if _, ok := mapValues[key]; ok {
+					return map[string]UnionBooleanDoubleArray{}, buf, fmt.Errorf("cannot decode binary map: duplicate key: %q", key)
+				}
+				if value, tmpBuf, err = func(buf []byte) (UnionBooleanDoubleArray, []byte, error) {
+					return NewUnionBooleanDoubleArrayAtDepth(buf, depth)
+				}(tmpBuf); err != nil {
+					return map[string]UnionBooleanDoubleArray{}, buf, fmt.Errorf("cannot decode binary map value for key %q: %s", key, err)
+				}
+				mapValues[key] = value
+			}
+			blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+			if err != nil {
+				return map[string]UnionBooleanDoubleArray{}, buf, err
+			}
+
+		}
+		return mapValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.event", "extras", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Event) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.event")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = func(buf []byte, u UnionNullStringLong) ([]byte, error) {
+		return u.MarshalAvro(buf)
+	}(newBuf, r.Key); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.event", "key", err)
+	}
+
+	if newBuf, err = func(buf []byte, u UnionNullStringLong) ([]byte, error) {
+		return u.MarshalAvro(buf)
+	}(newBuf, r.PreviousKey); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.event", "previousKey", err)
+	}
+
+	if newBuf, err = func(buf []byte, u UnionIntDouble) ([]byte, error) {
+		return u.MarshalAvro(buf)
+	}(newBuf, r.Amount); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.event", "amount", err)
+	}
+
+	if newBuf, err = func(buf []byte, u UnionString) ([]byte, error) {
+		return u.MarshalAvro(buf)
+	}(newBuf, r.Only); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.event", "only", err)
+	}
+
+	if newBuf, err = func(buf []byte, u UnionDeviceUserNull) ([]byte, error) {
+		return u.MarshalAvro(buf)
+	}(newBuf, r.Source); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.event", "source", err)
+	}
+
+	if newBuf, err = func(buf []byte, u UnionStringLongTimestampMillis) ([]byte, error) {
+		return u.MarshalAvro(buf)
+	}(newBuf, r.When); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.event", "when", err)
+	}
+
+	if newBuf, err = func(buf []byte, values []UnionNullIntString) ([]byte, error) {
+		var err error
+		var remainingInBlock int64
+
+		for i, value := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = int64(len(values) - i)
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			if buf, err = func(buf []byte, u UnionNullIntString) ([]byte, error) {
+				return u.MarshalAvro(buf)
+			}(buf, value); err != nil {
+				return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+			}
+			remainingInBlock--
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+	}(newBuf, r.Attempts); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.event", "attempts", err)
+	}
+
+	if newBuf, err = func(buf []byte, values map[string]UnionBooleanDoubleArray) ([]byte, error) {
+		var err error
+		keyCount := int64(len(values))
+		var alreadyEncoded, remainingInBlock int64
+
+		for k, v := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = keyCount - alreadyEncoded
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			buf, _ = goavro.StringBinaryFromNative(buf, k)
+			if buf, err = func(buf []byte, u UnionBooleanDoubleArray) ([]byte, error) {
+				return u.MarshalAvro(buf)
+			}(buf, v); err != nil {
+				return nil, fmt.Errorf("cannot encode binary map value for key %q: %v: %s", k, v, err)
+			}
+			remainingInBlock--
+			alreadyEncoded++
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append tailing 0 block count to signal end of Map
+	}(newBuf, r.Extras); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.event", "extras", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewEventWithDefaults returns a new Event, with each field that has a default
+// value in the schema set to that value.
+func NewEventWithDefaults() *Event {
+	r := &Event{}
+	var err error
+	const depth = 0
+	if r.PreviousKey, _, err = func(buf []byte) (UnionNullStringLong, []byte, error) {
+		return NewUnionNullStringLongAtDepth(buf, depth)
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.event", "previousKey", err))
+	}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Event.
+func (r *Event) Schema() string {
+	return `{"name":"com.example.gentest.event","type":"record","fields":[{"name":"key","type":["null","string","long"]},{"name":"previousKey","type":["null","string","long"]},{"name":"amount","type":["int","double"]},{"name":"only","type":["string"]},{"name":"source","type":[{"name":"com.example.gentest.device","type":"record","fields":[{"name":"serial","type":"string"}]},{"name":"com.example.gentest.user","type":"record","fields":[{"name":"email","type":"string"}]},"null"]},{"name":"when","type":["string","long"]},{"name":"attempts","type":{"type":"array","items":["null","int","string"]}},{"name":"extras","type":{"type":"map","values":["boolean","double",{"type":"array","items":"string"}]}}]}`
+}
+
+var codecForEvent struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Event, which is built the first time
+// it is requested.
+func (r *Event) Codec() (*goavro.Codec, error) {
+	codecForEvent.once.Do(func() {
+		codecForEvent.codec, codecForEvent.err = goavro.NewCodec(`{"fields":[{"name":"key","type":["null","string","long"]},{"default":null,"name":"previousKey","type":["null","string","long"]},{"name":"amount","type":["int","double"]},{"name":"only","type":["string"]},{"name":"source","type":[{"fields":[{"name":"serial","type":"string"}],"name":"com.example.gentest.device","type":"record"},{"fields":[{"name":"email","type":"string"}],"name":"com.example.gentest.user","type":"record"},"null"]},{"name":"when","type":["string",{"logicalType":"timestamp-millis","type":"long"}]},{"name":"attempts","type":{"items":["null","int","string"],"type":"array"}},{"name":"extras","type":{"type":"map","values":["boolean","double",{"items":"string","type":"array"}]}}],"name":"com.example.gentest.event","type":"record"}`)
+	})
+	return codecForEvent.codec, codecForEvent.err
+}
+
+// EventOCFReader reads Event records from an Avro Object Container File.
+type EventOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewEventOCFReader returns a reader of the Event records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Event,
+// or resolves to it once its names are fully qualified.
+func NewEventOCFReader(ior io.Reader) (*EventOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Event)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.event", err)
+	}
+	return &EventOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *EventOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Event record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *EventOCFReader) Read() (*Event, error) {
+	var result *Event
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewEvent(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *EventOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *EventOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// EventOCFWriter appends Event records to an Avro Object Container File.
+type EventOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewEventOCFWriter returns a writer of Event records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Event
+// is used. It returns an error unless the schema of the file is the schema of
+// Event, or resolves to it once its names are fully qualified.
+func NewEventOCFWriter(config goavro.OCFConfig) (*EventOCFWriter, error) {
+	codec, err := (*Event)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.event", err)
+	}
+	return &EventOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Event records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *EventOCFWriter) Append(values ...*Event) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *EventOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
@@ -0,0 +1,6 @@
+// Package gentest holds code generated from the schemas in its testdata
+// directory, and tests that compare the behavior of the generated code with
+// that of the goavro.Codec built from the same schemas.
+package gentest
+
+//go:generate go run ../../avrogen -config avrogen.json
@@ -0,0 +1,813 @@
+package gentest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peak6/goavro/v2"
+)
+
+func newCodecFromFile(t *testing.T, pathname string) *goavro.Codec {
+	t.Helper()
+	schema, err := ioutil.ReadFile(pathname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec, err := goavro.NewCodec(string(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return codec
+}
+
+func testOrder() *Order {
+	coupon := "SPRING"
+	discount := 0.15
+	rush := true
+	previous := StatusPENDING
+	count := int64(3)
+	zip := int32(10001)
+	return &Order{
+		Id:             -42,
+		Quantity:       7,
+		Price:          19.99,
+		Weight:         1.5,
+		Gift:           true,
+		Note:           "leave at door",
+		Placed:         time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+		Total:          big.NewRat(13993, 100),
+		Status:         StatusSHIPPED,
+		PreviousStatus: &previous,
+		Tags:           []string{"a", "b", "c"},
+		History:        []Status{StatusPENDING, StatusSHIPPED},
+		Coupon:         &coupon,
+		Discount:       &discount,
+		Rush:           &rush,
+		Lines:          []*Line{{Sku: "x-1", Count: &count}, {Sku: "y-2"}},
+		ShipTo:         &Address{Street: "1 Main St", Zip: &zip},
+		BillTo:         &Address{Street: "2 Side St"},
+		Notes:          []string{},
+	}
+}
+
+func testOrderNative() map[string]interface{} {
+	return map[string]interface{}{
+		"id":             int64(-42),
+		"quantity":       int32(7),
+		"price":          19.99,
+		"weight":         float32(1.5),
+		"gift":           true,
+		"note":           "leave at door",
+		"placed":         time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+		"total":          big.NewRat(13993, 100),
+		"status":         "SHIPPED",
+		"previousStatus": goavro.Union("com.example.gentest.status", "PENDING"),
+		"tags":           []interface{}{"a", "b", "c"},
+		"history":        []interface{}{"PENDING", "SHIPPED"},
+		"coupon":         goavro.Union("string", "SPRING"),
+		"discount":       goavro.Union("double", 0.15),
+		"rush":           goavro.Union("boolean", true),
+		"lines": []interface{}{
+			map[string]interface{}{"sku": "x-1", "count": goavro.Union("long", int64(3))},
+			map[string]interface{}{"sku": "y-2", "count": nil},
+		},
+		"shipTo": goavro.Union("com.example.gentest.address", map[string]interface{}{
+			"street": "1 Main St",
+			"zip":    goavro.Union("int", int32(10001)),
+		}),
+		"billTo": map[string]interface{}{"street": "2 Side St", "zip": nil},
+		"notes":  goavro.Union("array", []interface{}{}),
+	}
+}
+
+func TestMarshalAvroMatchesCodec(t *testing.T) {
+	codec := newCodecFromFile(t, "testdata/order.avsc")
+
+	expected, err := codec.BinaryFromNative(nil, testOrderNative())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := testOrder().MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+
+	// Encoding appends to the provided buffer.
+	prefix := []byte("prefix")
+	actual, err = testOrder().MarshalAvro(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, append([]byte("prefix"), expected...)) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+}
+
+func TestMarshalAvroNullBranches(t *testing.T) {
+	codec := newCodecFromFile(t, "testdata/order.avsc")
+
+	value := testOrder()
+	value.PreviousStatus, value.Coupon, value.Discount, value.Rush, value.ShipTo, value.Notes = nil, nil, nil, nil, nil, nil
+	value.Tags, value.History, value.Lines = nil, nil, nil
+
+	native := testOrderNative()
+	for _, name := range []string{"previousStatus", "coupon", "discount", "rush", "shipTo", "notes"} {
+		native[name] = nil
+	}
+	for _, name := range []string{"tags", "history", "lines"} {
+		native[name] = []interface{}{}
+	}
+
+	expected, err := codec.BinaryFromNative(nil, native)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := value.MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+}
+
+func TestMarshalAvroRoundTrip(t *testing.T) {
+	expected := testOrder()
+	buf, err := expected.MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, buf, err := NewOrder(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buf) != 0 {
+		t.Errorf("GOT: %#v; WANT: %#v", buf, []byte{})
+	}
+	if actual.Total.Cmp(expected.Total) != 0 {
+		t.Errorf("GOT: %v; WANT: %v", actual.Total, expected.Total)
+	}
+	actual.Total = expected.Total
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+}
+
+func TestMarshalAvroErrors(t *testing.T) {
+	ensureError := func(err error, contains ...string) {
+		t.Helper()
+		if err == nil {
+			t.Fatalf("GOT: %v; WANT: %v", err, contains)
+		}
+		for _, stub := range contains {
+			if !strings.Contains(err.Error(), stub) {
+				t.Errorf("GOT: %v; WANT: %v", err, stub)
+			}
+		}
+	}
+
+	var nilOrder *Order
+	_, err := nilOrder.MarshalAvro(nil)
+	ensureError(err, "com.example.gentest.order", "nil")
+
+	value := testOrder()
+	value.Status = Status(3)
+	prefix := []byte("prefix")
+	buf, err := value.MarshalAvro(prefix)
+	ensureError(err, "field \"status\"", "index ought to be between 0 and 2")
+	if !bytes.Equal(buf, prefix) {
+		t.Errorf("GOT: %#v; WANT: %#v", buf, prefix)
+	}
+
+	value = testOrder()
+	value.BillTo = nil
+	_, err = value.MarshalAvro(nil)
+	ensureError(err, "field \"billTo\"", "com.example.gentest.address")
+
+	value = testOrder()
+	value.Total = nil
+	_, err = value.MarshalAvro(nil)
+	ensureError(err, "field \"total\"")
+}
+
+func testReading() *Reading {
+	backup := SensorID{5, 6, 7, 8}
+	processed := time.Date(2020, 2, 3, 4, 5, 6, 7000, time.UTC)
+	high := LevelHIGH
+	return &Reading{
+		Sensor:          SensorID{1, 2, 3, 4},
+		Backup:          &backup,
+		Payload:         []byte("payload"),
+		Raw:             []byte{0xde, 0xad},
+		Amount:          big.NewRat(-123456, 1000),
+		MeasuredAt:      time.Date(2020, 2, 3, 4, 5, 6, 7000000, time.UTC),
+		ReceivedAt:      time.Date(2020, 2, 3, 4, 5, 6, 7008000, time.UTC),
+		Offset:          3 * time.Hour,
+		Latency:         1500 * time.Microsecond,
+		ProcessedAt:     &processed,
+		Batch:           "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		Interval:        goavro.Duration{Months: 1, Days: 2, Milliseconds: 3},
+		LocalMeasuredAt: time.Date(2020, 2, 3, 4, 5, 6, 7000000, time.UTC),
+		LocalReceivedAt: time.Date(2020, 2, 3, 4, 5, 6, 7008000, time.UTC),
+		Labels:          map[string]string{"site": "north"},
+		Limits:          map[string]float64{"max": 42.5},
+		Levels:          []Level{LevelLOW, LevelHIGH, LevelMEDIUM},
+		MaybeLevels:     []*Level{nil, &high},
+		Peers:           map[string]*Peer{"east": {Id: SensorID{9, 9, 9, 9}, Level: LevelMEDIUM}},
+	}
+}
+
+func testReadingNative() map[string]interface{} {
+	return map[string]interface{}{
+		"sensor":          []byte{1, 2, 3, 4},
+		"backup":          goavro.Union("com.example.gentest.sensorID", []byte{5, 6, 7, 8}),
+		"payload":         []byte("payload"),
+		"raw":             goavro.Union("bytes", []byte{0xde, 0xad}),
+		"nothing":         nil,
+		"amount":          big.NewRat(-123456, 1000),
+		"measuredAt":      time.Date(2020, 2, 3, 4, 5, 6, 7000000, time.UTC),
+		"receivedAt":      time.Date(2020, 2, 3, 4, 5, 6, 7008000, time.UTC),
+		"offset":          3 * time.Hour,
+		"latency":         1500 * time.Microsecond,
+		"processedAt":     goavro.Union("long.timestamp-micros", time.Date(2020, 2, 3, 4, 5, 6, 7000, time.UTC)),
+		"batch":           "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"interval":        goavro.Duration{Months: 1, Days: 2, Milliseconds: 3},
+		"localMeasuredAt": time.Date(2020, 2, 3, 4, 5, 6, 7000000, time.UTC),
+		"localReceivedAt": time.Date(2020, 2, 3, 4, 5, 6, 7008000, time.UTC),
+		"labels":          map[string]interface{}{"site": "north"},
+		"limits":          goavro.Union("map", map[string]interface{}{"max": 42.5}),
+		"levels":          []interface{}{"LOW", "HIGH", "MEDIUM"},
+		"maybeLevels":     []interface{}{nil, goavro.Union("com.example.gentest.level", "HIGH")},
+		"peers": map[string]interface{}{
+			"east": map[string]interface{}{"id": []byte{9, 9, 9, 9}, "level": "MEDIUM"},
+		},
+	}
+}
+
+func TestMarshalAvroMatchesCodecForRemainingTypes(t *testing.T) {
+	codec := newCodecFromFile(t, "testdata/reading.avsc")
+
+	expected, err := codec.BinaryFromNative(nil, testReadingNative())
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := testReading().MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+}
+
+func TestMarshalAvroRoundTripForRemainingTypes(t *testing.T) {
+	expected := testReading()
+	expected.Labels["zone"] = "b" // multiple keys in a map encode in any order
+	buf, err := expected.MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, buf, err := NewReading(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buf) != 0 {
+		t.Errorf("GOT: %#v; WANT: %#v", buf, []byte{})
+	}
+	if actual.Amount.Cmp(expected.Amount) != 0 {
+		t.Errorf("GOT: %v; WANT: %v", actual.Amount, expected.Amount)
+	}
+	actual.Amount = expected.Amount
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+
+	// The generated decoder agrees with the codec about the encoded bytes.
+	codec := newCodecFromFile(t, "testdata/reading.avsc")
+	buf, _ = expected.MarshalAvro(nil)
+	if _, _, err = codec.NativeFromBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewFixedShortBuffer(t *testing.T) {
+	_, _, err := NewSensorID([]byte{1, 2})
+	if err == nil || !strings.Contains(err.Error(), "short buffer") {
+		t.Errorf("GOT: %v; WANT: %v", err, "short buffer")
+	}
+}
+
+func TestNewRecordShortBuffer(t *testing.T) {
+	// NOTE: The zip code is missing after its union index.
+	buf := []byte("\x08Main\x02")
+	_, rest, err := NewAddress(buf)
+	if err == nil || !strings.Contains(err.Error(), `cannot decode binary record "com.example.gentest.address" field "zip"`) {
+		t.Errorf("GOT: %v; WANT: %v", err, "field zip")
+	}
+	if !bytes.Equal(rest, buf) {
+		t.Errorf("GOT: %#v; WANT: %#v", rest, buf)
+	}
+}
+
+func testEvent() *Event {
+	var e Event
+	e.Key.SetString("k-1")
+	e.Amount.SetDouble(2.5)
+	e.Only.SetString("only")
+	e.Source.SetUser(&User{Email: "a@example.com"})
+	e.When.SetLongTimestampMillis(time.Date(2021, 1, 2, 3, 4, 5, 6000000, time.UTC))
+	e.Attempts = make([]UnionNullIntString, 3)
+	e.Attempts[1].SetInt(3)
+	e.Attempts[2].SetString("retry")
+	var flag UnionBooleanDoubleArray
+	flag.SetBoolean(true)
+	e.Extras = map[string]UnionBooleanDoubleArray{"flag": flag}
+	return &e
+}
+
+func testEventNative() map[string]interface{} {
+	return map[string]interface{}{
+		"key":         goavro.Union("string", "k-1"),
+		"previousKey": nil,
+		"amount":      goavro.Union("double", 2.5),
+		"only":        goavro.Union("string", "only"),
+		"source":      goavro.Union("com.example.gentest.user", map[string]interface{}{"email": "a@example.com"}),
+		"when":        goavro.Union("long.timestamp-millis", time.Date(2021, 1, 2, 3, 4, 5, 6000000, time.UTC)),
+		"attempts":    []interface{}{nil, goavro.Union("int", int32(3)), goavro.Union("string", "retry")},
+		"extras":      map[string]interface{}{"flag": goavro.Union("boolean", true)},
+	}
+}
+
+func TestUnionMarshalAvroMatchesCodec(t *testing.T) {
+	codec := newCodecFromFile(t, "testdata/event.avsc")
+
+	expected, err := codec.BinaryFromNative(nil, testEventNative())
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := testEvent().MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+
+	// Decoding the codec output yields the same value.
+	decoded, buf, err := NewEvent(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buf) != 0 {
+		t.Errorf("GOT: %#v; WANT: %#v", buf, []byte{})
+	}
+	if !reflect.DeepEqual(decoded, testEvent()) {
+		t.Errorf("GOT: %#v; WANT: %#v", decoded, testEvent())
+	}
+}
+
+func TestUnionAccessors(t *testing.T) {
+	var u UnionNullStringLong
+	if !u.IsNull() || u.Index() != 0 {
+		t.Errorf("GOT: %v; WANT: %v", u.Index(), 0)
+	}
+
+	u.SetLong(13)
+	if v, ok := u.AsLong(); !ok || v != 13 {
+		t.Errorf("GOT: %v, %v; WANT: %v, %v", v, ok, 13, true)
+	}
+	if _, ok := u.AsString(); ok {
+		t.Errorf("GOT: %v; WANT: %v", ok, false)
+	}
+	if u.IsNull() || u.Index() != 2 {
+		t.Errorf("GOT: %v; WANT: %v", u.Index(), 2)
+	}
+
+	u.SetString("hello")
+	if v, ok := u.AsString(); !ok || v != "hello" {
+		t.Errorf("GOT: %v, %v; WANT: %v, %v", v, ok, "hello", true)
+	}
+	if v, ok := u.AsLong(); ok || v != 0 {
+		t.Errorf("GOT: %v, %v; WANT: %v, %v", v, ok, 0, false)
+	}
+
+	u.SetNull()
+	if !u.IsNull() {
+		t.Errorf("GOT: %v; WANT: %v", u.IsNull(), true)
+	}
+}
+
+func TestUnionErrors(t *testing.T) {
+	// Index out of range on decode.
+	_, _, err := NewUnionNullStringLong([]byte{6})
+	if err == nil || !strings.Contains(err.Error(), "index ought to be between 0 and 2") {
+		t.Errorf("GOT: %v; WANT: %v", err, "index ought to be between 0 and 2")
+	}
+
+	// Record branch holding nil.
+	var source UnionDeviceUserNull
+	source.SetDevice(nil)
+	prefix := []byte("prefix")
+	buf, err := source.MarshalAvro(prefix)
+	if err == nil || !strings.Contains(err.Error(), "cannot encode binary union item 1") {
+		t.Errorf("GOT: %v; WANT: %v", err, "cannot encode binary union item 1")
+	}
+	if !bytes.Equal(buf, prefix) {
+		t.Errorf("GOT: %#v; WANT: %#v", buf, prefix)
+	}
+}
+
+func testNode() *Node {
+	var leader UnionNullStringNode
+	leader.SetNode(&Node{Label: "leader", Children: []*Node{}})
+	return &Node{
+		Label: "root",
+		Next:  &Node{Label: "sibling", Next: &Node{Label: "last", Children: []*Node{}}, Children: []*Node{}},
+		Children: []*Node{
+			{Label: "child", Children: []*Node{{Label: "grandchild", Children: []*Node{}}}},
+		},
+		Group: &Group{
+			Members: map[string]*Node{"m": {Label: "member", Children: []*Node{}}},
+			Leader:  leader,
+		},
+	}
+}
+
+func testNodeNative() map[string]interface{} {
+	leaf := func(label string) map[string]interface{} {
+		return map[string]interface{}{"label": label, "next": nil, "children": []interface{}{}, "group": nil}
+	}
+	sibling := leaf("sibling")
+	sibling["next"] = goavro.Union("com.example.gentest.node", leaf("last"))
+	child := leaf("child")
+	child["children"] = []interface{}{leaf("grandchild")}
+	return map[string]interface{}{
+		"label":    "root",
+		"next":     goavro.Union("com.example.gentest.node", sibling),
+		"children": []interface{}{child},
+		"group": goavro.Union("com.example.gentest.group", map[string]interface{}{
+			"members": map[string]interface{}{"m": leaf("member")},
+			"leader":  goavro.Union("com.example.gentest.node", leaf("leader")),
+		}),
+	}
+}
+
+func TestRecursiveMarshalAvroMatchesCodec(t *testing.T) {
+	codec := newCodecFromFile(t, "testdata/node.avsc")
+
+	expected, err := codec.BinaryFromNative(nil, testNodeNative())
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := testNode().MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+
+	decoded, buf, err := NewNode(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buf) != 0 {
+		t.Errorf("GOT: %#v; WANT: %#v", buf, []byte{})
+	}
+	if !reflect.DeepEqual(decoded, testNode()) {
+		t.Errorf("GOT: %#v; WANT: %#v", decoded, testNode())
+	}
+}
+
+func TestRecursiveDecodeDepthLimit(t *testing.T) {
+	defer func(depth int) { goavro.MaxDecodeDepth = depth }(goavro.MaxDecodeDepth)
+	goavro.MaxDecodeDepth = 10
+
+	// encode a list of nodes linked by their next field
+	nest := func(count int) []byte {
+		list := &Node{Label: "0", Children: []*Node{}}
+		for i := 1; i < count; i++ {
+			list = &Node{Label: "n", Next: list, Children: []*Node{}}
+		}
+		buf, err := list.MarshalAvro(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return buf
+	}
+
+	if _, _, err := NewNode(nest(10)); err != nil {
+		t.Errorf("GOT: %v; WANT: %v", err, nil)
+	}
+
+	_, _, err := NewNode(nest(11))
+	if err == nil || !strings.Contains(err.Error(), "MaxDecodeDepth") {
+		t.Errorf("GOT: %v; WANT: %v", err, "MaxDecodeDepth")
+	}
+
+	// Records nested through unions, arrays and maps count toward the limit.
+	deep := &Node{Label: "0", Children: []*Node{}}
+	for i := 1; i < 11; i++ {
+		var leader UnionNullStringNode
+		leader.SetNode(deep)
+		deep = &Node{Label: "n", Children: []*Node{}, Group: &Group{Members: map[string]*Node{}, Leader: leader}}
+	}
+	buf, err := deep.MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = NewNode(buf)
+	if err == nil || !strings.Contains(err.Error(), "MaxDecodeDepth") {
+		t.Errorf("GOT: %v; WANT: %v", err, "MaxDecodeDepth")
+	}
+}
+
+func TestRecordWithDefaultsMatchesCodec(t *testing.T) {
+	codec := newCodecFromFile(t, "testdata/settings.avsc")
+
+	settings := NewSettingsWithDefaults()
+	settings.Required = "yes"
+
+	actual, err := settings.MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The codec encodes the default value of each field missing from a datum.
+	expected, err := codec.BinaryFromNative(nil, map[string]interface{}{"required": "yes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+
+	if actual, expected := settings.Mode, ModeAUTO; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := settings.Limits.Max, int32(20); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := NewLimitsWithDefaults().Max, int32(10); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if settings.Owner != nil {
+		t.Errorf("GOT: %v; WANT: %v", *settings.Owner, nil)
+	}
+
+	// Each call returns a new value.
+	settings.Hosts[0] = "changed"
+	if actual, expected := NewSettingsWithDefaults().Hosts, []string{"a", "b"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+}
+
+func TestSchemaAndCodec(t *testing.T) {
+	order := testOrder()
+	codec, err := order.Codec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := order.Codec(); again != codec {
+		t.Errorf("GOT: %p; WANT: %p", again, codec)
+	}
+	if actual, expected := order.Schema(), codec.CanonicalSchema(); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	// The codec handles the data of the generated type.
+	buf, err := order.MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := newCodecFromFile(t, "testdata/order.avsc").BinaryFromNative(nil, testOrderNative())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", buf, expected)
+	}
+	if _, _, err = codec.NativeFromBinary(buf); err != nil {
+		t.Errorf("GOT: %v; WANT: %v", err, nil)
+	}
+
+	// Named types defined within a schema have their own standalone schema.
+	if actual, expected := StatusSHIPPED.Schema(), `{"name":"com.example.gentest.status","type":"enum","symbols":["PENDING","SHIPPED","DELIVERED"]}`; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if _, err = (SensorID{}).Codec(); err != nil {
+		t.Errorf("GOT: %v; WANT: %v", err, nil)
+	}
+}
+
+func TestEnumDecodeMatchesCodec(t *testing.T) {
+	codec := newCodecFromFile(t, "testdata/settings.avsc")
+	expected, err := codec.BinaryFromNative(nil, map[string]interface{}{"required": "yes", "mode": "ON"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings, _, err := NewSettings(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := settings.Mode, ModeON; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	// An index outside the symbols decodes as the default symbol.
+	mode, rest, err := NewMode([]byte{0x10, 0xff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode != ModeOFF {
+		t.Errorf("GOT: %v; WANT: %v", mode, ModeOFF)
+	}
+	if !bytes.Equal(rest, []byte{0xff}) {
+		t.Errorf("GOT: %#v; WANT: %#v", rest, []byte{0xff})
+	}
+
+	// Without a default symbol, it is rejected like the codec rejects it.
+	_, _, err = NewStatus([]byte{0x06})
+	if err == nil || !strings.Contains(err.Error(), `cannot decode binary enum "com.example.gentest.status": index ought to be between 0 and 2; read index: 3`) {
+		t.Errorf("GOT: %v; WANT: %v", err, "index ought to be between 0 and 2")
+	}
+}
+
+func TestEnumSymbols(t *testing.T) {
+	if actual, expected := StatusSHIPPED.String(), "SHIPPED"; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := Status(7).String(), "Status(7)"; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	status, err := ParseStatus("DELIVERED")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != StatusDELIVERED {
+		t.Errorf("GOT: %v; WANT: %v", status, StatusDELIVERED)
+	}
+	if _, err = ParseStatus("LOST"); err == nil || !strings.Contains(err.Error(), `value ought to be member of symbols: [PENDING SHIPPED DELIVERED]; "LOST"`) {
+		t.Errorf("GOT: %v; WANT: %v", err, "value ought to be member of symbols")
+	}
+
+	text, err := StatusPENDING.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := string(text), "PENDING"; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if err = status.UnmarshalText([]byte("SHIPPED")); err != nil {
+		t.Fatal(err)
+	}
+	if status != StatusSHIPPED {
+		t.Errorf("GOT: %v; WANT: %v", status, StatusSHIPPED)
+	}
+	if _, err = Status(-1).MarshalText(); err == nil {
+		t.Errorf("GOT: %v; WANT: %v", err, "index ought to be between 0 and 2")
+	}
+
+	// Enums marshal as their symbols within JSON documents.
+	type document struct {
+		Status  Status
+		History []Status
+	}
+	data, err := json.Marshal(document{Status: StatusSHIPPED, History: []Status{StatusPENDING, StatusDELIVERED}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := string(data), `{"Status":"SHIPPED","History":["PENDING","DELIVERED"]}`; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	var decoded document
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Status != StatusSHIPPED || !reflect.DeepEqual(decoded.History, []Status{StatusPENDING, StatusDELIVERED}) {
+		t.Errorf("GOT: %v; WANT: %v", decoded, document{Status: StatusSHIPPED, History: []Status{StatusPENDING, StatusDELIVERED}})
+	}
+	if err = json.Unmarshal([]byte(`{"Status":"LOST"}`), &decoded); err == nil {
+		t.Errorf("GOT: %v; WANT: %v", err, "value ought to be member of symbols")
+	}
+	if err = json.Unmarshal([]byte(`{"Status":1}`), &decoded); err == nil {
+		t.Errorf("GOT: %v; WANT: %v", err, "cannot decode textual enum")
+	}
+}
+
+func TestOCFReaderReadsFileWrittenByCodec(t *testing.T) {
+	// NOTE: The schema of the file declares its namespace rather than fully
+	// qualifying its names, so it resolves to the schema of the generated type
+	// without having the same fingerprint.
+	codec := newCodecFromFile(t, "testdata/order.avsc")
+	var buf bytes.Buffer
+	ocfw, err := goavro.NewOCFWriter(goavro.OCFConfig{W: &buf, Codec: codec, CompressionName: goavro.CompressionDeflateLabel})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append([]interface{}{testOrderNative(), testOrderNative()}); err != nil {
+		t.Fatal(err)
+	}
+
+	ocfr, err := NewOrderOCFReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	for ocfr.Scan() {
+		order, err := ocfr.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := testOrder(); !reflect.DeepEqual(order, expected) {
+			t.Errorf("GOT: %#v; WANT: %#v", order, expected)
+		}
+		count++
+	}
+	if err = ocfr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("GOT: %v; WANT: %v", count, 2)
+	}
+}
+
+func TestOCFWriterWritesFileReadByCodec(t *testing.T) {
+	var buf bytes.Buffer
+	ocfw, err := NewOrderOCFWriter(goavro.OCFConfig{W: &buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append(testOrder(), testOrder()); err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := testOrder().MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ocfr, err := goavro.NewOCFReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := ocfr.Codec().CanonicalSchema(), testOrder().Schema(); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	var count int
+	for ocfr.Scan() {
+		datum, err := ocfr.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := ocfr.Codec().BinaryFromNative(nil, datum)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(actual, expected) {
+			t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+		}
+		count++
+	}
+	if err = ocfr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("GOT: %v; WANT: %v", count, 2)
+	}
+}
+
+func TestOCFSchemaMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	ocfw, err := NewSettingsOCFWriter(goavro.OCFConfig{W: &buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append(NewSettingsWithDefaults()); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewOrderOCFReader(bytes.NewReader(buf.Bytes()))
+	if err == nil || !strings.Contains(err.Error(), `cannot read "com.example.gentest.order" records from OCF`) {
+		t.Errorf("GOT: %v; WANT: %v", err, "cannot read records")
+	}
+
+	// Appending to a file with a different schema is also an error.
+	codec := newCodecFromFile(t, "testdata/reading.avsc")
+	_, err = NewOrderOCFWriter(goavro.OCFConfig{W: ioutil.Discard, Codec: codec})
+	if err == nil || !strings.Contains(err.Error(), `cannot write "com.example.gentest.order" records to OCF`) {
+		t.Errorf("GOT: %v; WANT: %v", err, "cannot write records")
+	}
+}
@@ -0,0 +1,243 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"sync"
+)
+
+type Group struct {
+	Members map[string]*Node    `avro:"members"`
+	Leader  UnionNullStringNode `avro:"leader"`
+}
+
+func NewGroup(buf []byte) (*Group, []byte, error) {
+	return NewGroupAtDepth(buf, 0)
+}
+
+// NewGroupAtDepth is like NewGroup, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewGroupAtDepth(buf []byte, depth int) (*Group, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.group", goavro.MaxDecodeDepth)
+	}
+	result := &Group{}
+	newBuf := buf
+	var err error
+
+	if result.Members, newBuf, err = func(buf []byte) (map[string]*Node, []byte, error) {
+		var key string
+		var value *Node
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+		if err != nil {
+			return map[string]*Node{}, buf, err
+		}
+
+		mapValues := make(map[string]*Node, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if key, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+					return map[string]*Node{}, buf, fmt.Errorf("cannot decode binary map key: %s", err)
+				}
+				if _, ok := mapValues[key]; ok {
+					return map[string]*Node{}, buf, fmt.Errorf("cannot decode binary map: duplicate key: %q", key)
+				}
+				if value, tmpBuf, err = func(buf []byte) (*Node, []byte, error) {
+					return NewNodeAtDepth(buf, depth+1)
+				}(tmpBuf); err != nil {
+					return map[string]*Node{}, buf, fmt.Errorf("cannot decode binary map value for key %q: %s", key, err)
+				}
+				mapValues[key] = value
+			}
+			blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+			if err != nil {
+				return map[string]*Node{}, buf, err
+			}
+
+		}
+		return mapValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.group", "members", err)
+	}
+
+	if result.Leader, newBuf, err = func(buf []byte) (UnionNullStringNode, []byte, error) {
+		return NewUnionNullStringNodeAtDepth(buf, depth)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.group", "leader", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Group) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.group")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = func(buf []byte, values map[string]*Node) ([]byte, error) {
+		var err error
+		keyCount := int64(len(values))
+		var alreadyEncoded, remainingInBlock int64
+
+		for k, v := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = keyCount - alreadyEncoded
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			buf, _ = goavro.StringBinaryFromNative(buf, k)
+			if buf, err = func(buf []byte, r *Node) ([]byte, error) {
+				return r.MarshalAvro(buf)
+			}(buf, v); err != nil {
+				return nil, fmt.Errorf("cannot encode binary map value for key %q: %v: %s", k, v, err)
+			}
+			remainingInBlock--
+			alreadyEncoded++
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append tailing 0 block count to signal end of Map
+	}(newBuf, r.Members); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.group", "members", err)
+	}
+
+	if newBuf, err = func(buf []byte, u UnionNullStringNode) ([]byte, error) {
+		return u.MarshalAvro(buf)
+	}(newBuf, r.Leader); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.group", "leader", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewGroupWithDefaults returns a new Group, with each field that has a default
+// value in the schema set to that value.
+func NewGroupWithDefaults() *Group {
+	r := &Group{}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Group.
+func (r *Group) Schema() string {
+	return `{"name":"com.example.gentest.group","type":"record","fields":[{"name":"members","type":{"type":"map","values":{"name":"com.example.gentest.node","type":"record","fields":[{"name":"label","type":"string"},{"name":"next","type":["null","com.example.gentest.node"]},{"name":"children","type":{"type":"array","items":"com.example.gentest.node"}},{"name":"group","type":["null",{"name":"com.example.gentest.group","type":"record","fields":[{"name":"members","type":{"type":"map","values":"com.example.gentest.node"}},{"name":"leader","type":["null","string","com.example.gentest.node"]}]}]}]}}},{"name":"leader","type":["null","string","com.example.gentest.node"]}]}`
+}
+
+var codecForGroup struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Group, which is built the first time
+// it is requested.
+func (r *Group) Codec() (*goavro.Codec, error) {
+	codecForGroup.once.Do(func() {
+		codecForGroup.codec, codecForGroup.err = goavro.NewCodec(`{"fields":[{"name":"members","type":{"type":"map","values":{"fields":[{"name":"label","type":"string"},{"default":null,"name":"next","type":["null","com.example.gentest.node"]},{"name":"children","type":{"items":"com.example.gentest.node","type":"array"}},{"default":null,"name":"group","type":["null",{"fields":[{"name":"members","type":{"type":"map","values":"com.example.gentest.node"}},{"name":"leader","type":["null","string","com.example.gentest.node"]}],"name":"com.example.gentest.group","type":"record"}]}],"name":"com.example.gentest.node","type":"record"}}},{"name":"leader","type":["null","string","com.example.gentest.node"]}],"name":"com.example.gentest.group","type":"record"}`)
+	})
+	return codecForGroup.codec, codecForGroup.err
+}
+
+// GroupOCFReader reads Group records from an Avro Object Container File.
+type GroupOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewGroupOCFReader returns a reader of the Group records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Group,
+// or resolves to it once its names are fully qualified.
+func NewGroupOCFReader(ior io.Reader) (*GroupOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Group)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.group", err)
+	}
+	return &GroupOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *GroupOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Group record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *GroupOCFReader) Read() (*Group, error) {
+	var result *Group
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewGroup(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *GroupOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *GroupOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// GroupOCFWriter appends Group records to an Avro Object Container File.
+type GroupOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewGroupOCFWriter returns a writer of Group records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Group
+// is used. It returns an error unless the schema of the file is the schema of
+// Group, or resolves to it once its names are fully qualified.
+func NewGroupOCFWriter(config goavro.OCFConfig) (*GroupOCFWriter, error) {
+	codec, err := (*Group)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.group", err)
+	}
+	return &GroupOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Group records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *GroupOCFWriter) Append(values ...*Group) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *GroupOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
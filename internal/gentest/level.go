@@ -0,0 +1,115 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"sync"
+)
+
+type Level int
+
+const (
+	LevelLOW Level = iota
+	LevelMEDIUM
+	LevelHIGH
+)
+
+var symbolsForLevel = [...]string{"LOW", "MEDIUM", "HIGH"}
+
+func NewLevel(buf []byte) (Level, []byte, error) {
+	index, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return 0, buf, fmt.Errorf("cannot decode binary enum %q index: %s", "com.example.gentest.level", err)
+	}
+	if index < 0 || index >= 3 {
+		return 0, buf, fmt.Errorf("cannot decode binary enum %q: index ought to be between 0 and 2; read index: %d", "com.example.gentest.level", index)
+	}
+	return Level(index), newBuf, nil
+}
+
+func (e Level) MarshalAvro(buf []byte) ([]byte, error) {
+	if e < 0 || e >= 3 {
+		return buf, fmt.Errorf("cannot encode binary enum %q: index ought to be between 0 and 2; received: %d", "com.example.gentest.level", e)
+	}
+	return goavro.IntEnumBinaryFromNative(buf, int(e))
+}
+
+// String returns the symbol of the Level, or its number when it is not one of
+// the symbols.
+func (e Level) String() string {
+	if e < 0 || e >= 3 {
+		return fmt.Sprintf("Level(%d)", int(e))
+	}
+	return symbolsForLevel[e]
+}
+
+// ParseLevel returns the Level with the specified symbol.
+func ParseLevel(symbol string) (Level, error) {
+	for i, s := range symbolsForLevel {
+		if s == symbol {
+			return Level(i), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot parse enum %q: value ought to be member of symbols: %v; %q", "com.example.gentest.level", symbolsForLevel, symbol)
+}
+
+// MarshalText returns the symbol of the enum.
+func (e Level) MarshalText() ([]byte, error) {
+	if e < 0 || e >= 3 {
+		return nil, fmt.Errorf("cannot encode textual enum %q: index ought to be between 0 and 2; received: %d", "com.example.gentest.level", e)
+	}
+	return []byte(symbolsForLevel[e]), nil
+}
+
+// UnmarshalText sets the enum to the value with the symbol in text.
+func (e *Level) UnmarshalText(text []byte) error {
+	value, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*e = value
+	return nil
+}
+
+// MarshalJSON returns the symbol of the enum as a JSON string.
+func (e Level) MarshalJSON() ([]byte, error) {
+	text, err := e.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON sets the enum to the value with the symbol in the JSON string.
+func (e *Level) UnmarshalJSON(data []byte) error {
+	var symbol string
+	if err := json.Unmarshal(data, &symbol); err != nil {
+		return fmt.Errorf("cannot decode textual enum %q: %s", "com.example.gentest.level", err)
+	}
+	return e.UnmarshalText([]byte(symbol))
+}
+
+// Schema returns the canonical form of the schema for Level.
+func (Level) Schema() string {
+	return `{"name":"com.example.gentest.level","type":"enum","symbols":["LOW","MEDIUM","HIGH"]}`
+}
+
+var codecForLevel struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Level, which is built the first time
+// it is requested.
+func (Level) Codec() (*goavro.Codec, error) {
+	codecForLevel.once.Do(func() {
+		codecForLevel.codec, codecForLevel.err = goavro.NewCodec(`{"name":"com.example.gentest.level","symbols":["LOW","MEDIUM","HIGH"],"type":"enum"}`)
+	})
+	return codecForLevel.codec, codecForLevel.err
+}
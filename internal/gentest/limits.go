@@ -0,0 +1,174 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"sync"
+)
+
+type Limits struct {
+	Max int32 `avro:"max"`
+}
+
+func NewLimits(buf []byte) (*Limits, []byte, error) {
+	return NewLimitsAtDepth(buf, 0)
+}
+
+// NewLimitsAtDepth is like NewLimits, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewLimitsAtDepth(buf []byte, depth int) (*Limits, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.limits", goavro.MaxDecodeDepth)
+	}
+	result := &Limits{}
+	newBuf := buf
+	var err error
+
+	if result.Max, newBuf, err = goavro.IntNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.limits", "max", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Limits) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.limits")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = goavro.IntBinaryFromNative(newBuf, r.Max); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.limits", "max", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewLimitsWithDefaults returns a new Limits, with each field that has a default
+// value in the schema set to that value.
+func NewLimitsWithDefaults() *Limits {
+	r := &Limits{}
+	var err error
+	const depth = 0
+	if r.Max, _, err = goavro.IntNativeFromBinary([]byte{0x14}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.limits", "max", err))
+	}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Limits.
+func (r *Limits) Schema() string {
+	return `{"name":"com.example.gentest.limits","type":"record","fields":[{"name":"max","type":"int"}]}`
+}
+
+var codecForLimits struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Limits, which is built the first time
+// it is requested.
+func (r *Limits) Codec() (*goavro.Codec, error) {
+	codecForLimits.once.Do(func() {
+		codecForLimits.codec, codecForLimits.err = goavro.NewCodec(`{"fields":[{"default":10,"name":"max","type":"int"}],"name":"com.example.gentest.limits","type":"record"}`)
+	})
+	return codecForLimits.codec, codecForLimits.err
+}
+
+// LimitsOCFReader reads Limits records from an Avro Object Container File.
+type LimitsOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewLimitsOCFReader returns a reader of the Limits records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Limits,
+// or resolves to it once its names are fully qualified.
+func NewLimitsOCFReader(ior io.Reader) (*LimitsOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Limits)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.limits", err)
+	}
+	return &LimitsOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *LimitsOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Limits record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *LimitsOCFReader) Read() (*Limits, error) {
+	var result *Limits
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewLimits(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *LimitsOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *LimitsOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// LimitsOCFWriter appends Limits records to an Avro Object Container File.
+type LimitsOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewLimitsOCFWriter returns a writer of Limits records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Limits
+// is used. It returns an error unless the schema of the file is the schema of
+// Limits, or resolves to it once its names are fully qualified.
+func NewLimitsOCFWriter(config goavro.OCFConfig) (*LimitsOCFWriter, error) {
+	codec, err := (*Limits)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.limits", err)
+	}
+	return &LimitsOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Limits records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *LimitsOCFWriter) Append(values ...*Limits) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *LimitsOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
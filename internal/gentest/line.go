@@ -0,0 +1,221 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"sync"
+)
+
+type Line struct {
+	Sku   string `avro:"sku"`
+	Count *int64 `avro:"count"`
+}
+
+func NewLine(buf []byte) (*Line, []byte, error) {
+	return NewLineAtDepth(buf, 0)
+}
+
+// NewLineAtDepth is like NewLine, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewLineAtDepth(buf []byte, depth int) (*Line, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.line", goavro.MaxDecodeDepth)
+	}
+	result := &Line{}
+	newBuf := buf
+	var err error
+
+	if result.Sku, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.line", "sku", err)
+	}
+
+	if result.Count, newBuf, err = func(buf []byte) (*int64, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.LongNativePtrFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.line", "count", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Line) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.line")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = goavro.StringBinaryFromNative(newBuf, r.Sku); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.line", "sku", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *int64) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, v *int64) ([]byte, error) {
+			return goavro.LongBinaryFromNative(buf, *v)
+		}(buf, v)
+	}(newBuf, r.Count); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.line", "count", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewLineWithDefaults returns a new Line, with each field that has a default
+// value in the schema set to that value.
+func NewLineWithDefaults() *Line {
+	r := &Line{}
+	var err error
+	const depth = 0
+	if r.Count, _, err = func(buf []byte) (*int64, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.LongNativePtrFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.line", "count", err))
+	}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Line.
+func (r *Line) Schema() string {
+	return `{"name":"com.example.gentest.line","type":"record","fields":[{"name":"sku","type":"string"},{"name":"count","type":["null","long"]}]}`
+}
+
+var codecForLine struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Line, which is built the first time
+// it is requested.
+func (r *Line) Codec() (*goavro.Codec, error) {
+	codecForLine.once.Do(func() {
+		codecForLine.codec, codecForLine.err = goavro.NewCodec(`{"fields":[{"name":"sku","type":"string"},{"default":null,"name":"count","type":["null","long"]}],"name":"com.example.gentest.line","type":"record"}`)
+	})
+	return codecForLine.codec, codecForLine.err
+}
+
+// LineOCFReader reads Line records from an Avro Object Container File.
+type LineOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewLineOCFReader returns a reader of the Line records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Line,
+// or resolves to it once its names are fully qualified.
+func NewLineOCFReader(ior io.Reader) (*LineOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Line)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.line", err)
+	}
+	return &LineOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *LineOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Line record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *LineOCFReader) Read() (*Line, error) {
+	var result *Line
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewLine(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *LineOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *LineOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// LineOCFWriter appends Line records to an Avro Object Container File.
+type LineOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewLineOCFWriter returns a writer of Line records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Line
+// is used. It returns an error unless the schema of the file is the schema of
+// Line, or resolves to it once its names are fully qualified.
+func NewLineOCFWriter(config goavro.OCFConfig) (*LineOCFWriter, error) {
+	codec, err := (*Line)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.line", err)
+	}
+	return &LineOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Line records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *LineOCFWriter) Append(values ...*Line) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *LineOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
@@ -0,0 +1,116 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"sync"
+)
+
+type Mode int
+
+const (
+	ModeOFF Mode = iota
+	ModeON
+	ModeAUTO
+)
+
+var symbolsForMode = [...]string{"OFF", "ON", "AUTO"}
+
+func NewMode(buf []byte) (Mode, []byte, error) {
+	index, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return 0, buf, fmt.Errorf("cannot decode binary enum %q index: %s", "com.example.gentest.mode", err)
+	}
+	if index < 0 || index >= 3 {
+		// Unknown symbols decode as the default symbol, OFF.
+		return ModeOFF, newBuf, nil
+	}
+	return Mode(index), newBuf, nil
+}
+
+func (e Mode) MarshalAvro(buf []byte) ([]byte, error) {
+	if e < 0 || e >= 3 {
+		return buf, fmt.Errorf("cannot encode binary enum %q: index ought to be between 0 and 2; received: %d", "com.example.gentest.mode", e)
+	}
+	return goavro.IntEnumBinaryFromNative(buf, int(e))
+}
+
+// String returns the symbol of the Mode, or its number when it is not one of
+// the symbols.
+func (e Mode) String() string {
+	if e < 0 || e >= 3 {
+		return fmt.Sprintf("Mode(%d)", int(e))
+	}
+	return symbolsForMode[e]
+}
+
+// ParseMode returns the Mode with the specified symbol.
+func ParseMode(symbol string) (Mode, error) {
+	for i, s := range symbolsForMode {
+		if s == symbol {
+			return Mode(i), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot parse enum %q: value ought to be member of symbols: %v; %q", "com.example.gentest.mode", symbolsForMode, symbol)
+}
+
+// MarshalText returns the symbol of the enum.
+func (e Mode) MarshalText() ([]byte, error) {
+	if e < 0 || e >= 3 {
+		return nil, fmt.Errorf("cannot encode textual enum %q: index ought to be between 0 and 2; received: %d", "com.example.gentest.mode", e)
+	}
+	return []byte(symbolsForMode[e]), nil
+}
+
+// UnmarshalText sets the enum to the value with the symbol in text.
+func (e *Mode) UnmarshalText(text []byte) error {
+	value, err := ParseMode(string(text))
+	if err != nil {
+		return err
+	}
+	*e = value
+	return nil
+}
+
+// MarshalJSON returns the symbol of the enum as a JSON string.
+func (e Mode) MarshalJSON() ([]byte, error) {
+	text, err := e.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON sets the enum to the value with the symbol in the JSON string.
+func (e *Mode) UnmarshalJSON(data []byte) error {
+	var symbol string
+	if err := json.Unmarshal(data, &symbol); err != nil {
+		return fmt.Errorf("cannot decode textual enum %q: %s", "com.example.gentest.mode", err)
+	}
+	return e.UnmarshalText([]byte(symbol))
+}
+
+// Schema returns the canonical form of the schema for Mode.
+func (Mode) Schema() string {
+	return `{"name":"com.example.gentest.mode","type":"enum","symbols":["OFF","ON","AUTO"]}`
+}
+
+var codecForMode struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Mode, which is built the first time
+// it is requested.
+func (Mode) Codec() (*goavro.Codec, error) {
+	codecForMode.once.Do(func() {
+		codecForMode.codec, codecForMode.err = goavro.NewCodec(`{"default":"OFF","name":"com.example.gentest.mode","symbols":["OFF","ON","AUTO"],"type":"enum"}`)
+	})
+	return codecForMode.codec, codecForMode.err
+}
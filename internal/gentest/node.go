@@ -0,0 +1,341 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"sync"
+)
+
+type Node struct {
+	Label    string  `avro:"label"`
+	Next     *Node   `avro:"next"`
+	Children []*Node `avro:"children"`
+	Group    *Group  `avro:"group"`
+}
+
+func NewNode(buf []byte) (*Node, []byte, error) {
+	return NewNodeAtDepth(buf, 0)
+}
+
+// NewNodeAtDepth is like NewNode, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewNodeAtDepth(buf []byte, depth int) (*Node, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.node", goavro.MaxDecodeDepth)
+	}
+	result := &Node{}
+	newBuf := buf
+	var err error
+
+	if result.Label, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.node", "label", err)
+	}
+
+	if result.Next, newBuf, err = func(buf []byte) (*Node, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*Node, []byte, error) {
+				return NewNodeAtDepth(buf, depth+1)
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.node", "next", err)
+	}
+
+	if result.Children, newBuf, err = func(buf []byte) ([]*Node, []byte, error) {
+		var value *Node
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+		if err != nil {
+			return []*Node{}, buf, err
+		}
+
+		arrayValues := make([]*Node, 0, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if value, tmpBuf, err = func(buf []byte) (*Node, []byte, error) {
+					return NewNodeAtDepth(buf, depth+1)
+				}(tmpBuf); err != nil {
+					return []*Node{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				} else {
+					arrayValues = append(arrayValues, value)
+
+				}
+			}
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []*Node{}, buf, err
+			}
+
+		}
+		return arrayValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.node", "children", err)
+	}
+
+	if result.Group, newBuf, err = func(buf []byte) (*Group, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*Group, []byte, error) {
+				return NewGroupAtDepth(buf, depth+1)
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.node", "group", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Node) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.node")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = goavro.StringBinaryFromNative(newBuf, r.Label); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.node", "label", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *Node) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, r *Node) ([]byte, error) {
+			return r.MarshalAvro(buf)
+		}(buf, v)
+	}(newBuf, r.Next); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.node", "next", err)
+	}
+
+	if newBuf, err = func(buf []byte, values []*Node) ([]byte, error) {
+		var err error
+		var remainingInBlock int64
+
+		for i, value := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = int64(len(values) - i)
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			if buf, err = func(buf []byte, r *Node) ([]byte, error) {
+				return r.MarshalAvro(buf)
+			}(buf, value); err != nil {
+				return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+			}
+			remainingInBlock--
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+	}(newBuf, r.Children); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.node", "children", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *Group) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, r *Group) ([]byte, error) {
+			return r.MarshalAvro(buf)
+		}(buf, v)
+	}(newBuf, r.Group); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.node", "group", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewNodeWithDefaults returns a new Node, with each field that has a default
+// value in the schema set to that value.
+func NewNodeWithDefaults() *Node {
+	r := &Node{}
+	var err error
+	const depth = 0
+	if r.Next, _, err = func(buf []byte) (*Node, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*Node, []byte, error) {
+				return NewNodeAtDepth(buf, depth+1)
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.node", "next", err))
+	}
+	if r.Group, _, err = func(buf []byte) (*Group, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*Group, []byte, error) {
+				return NewGroupAtDepth(buf, depth+1)
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.node", "group", err))
+	}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Node.
+func (r *Node) Schema() string {
+	return `{"name":"com.example.gentest.node","type":"record","fields":[{"name":"label","type":"string"},{"name":"next","type":["null","com.example.gentest.node"]},{"name":"children","type":{"type":"array","items":"com.example.gentest.node"}},{"name":"group","type":["null",{"name":"com.example.gentest.group","type":"record","fields":[{"name":"members","type":{"type":"map","values":"com.example.gentest.node"}},{"name":"leader","type":["null","string","com.example.gentest.node"]}]}]}]}`
+}
+
+var codecForNode struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Node, which is built the first time
+// it is requested.
+func (r *Node) Codec() (*goavro.Codec, error) {
+	codecForNode.once.Do(func() {
+		codecForNode.codec, codecForNode.err = goavro.NewCodec(`{"fields":[{"name":"label","type":"string"},{"default":null,"name":"next","type":["null","com.example.gentest.node"]},{"name":"children","type":{"items":"com.example.gentest.node","type":"array"}},{"default":null,"name":"group","type":["null",{"fields":[{"name":"members","type":{"type":"map","values":"com.example.gentest.node"}},{"name":"leader","type":["null","string","com.example.gentest.node"]}],"name":"com.example.gentest.group","type":"record"}]}],"name":"com.example.gentest.node","type":"record"}`)
+	})
+	return codecForNode.codec, codecForNode.err
+}
+
+// NodeOCFReader reads Node records from an Avro Object Container File.
+type NodeOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewNodeOCFReader returns a reader of the Node records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Node,
+// or resolves to it once its names are fully qualified.
+func NewNodeOCFReader(ior io.Reader) (*NodeOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Node)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.node", err)
+	}
+	return &NodeOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *NodeOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Node record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *NodeOCFReader) Read() (*Node, error) {
+	var result *Node
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewNode(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *NodeOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *NodeOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// NodeOCFWriter appends Node records to an Avro Object Container File.
+type NodeOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewNodeOCFWriter returns a writer of Node records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Node
+// is used. It returns an error unless the schema of the file is the schema of
+// Node, or resolves to it once its names are fully qualified.
+func NewNodeOCFWriter(config goavro.OCFConfig) (*NodeOCFWriter, error) {
+	codec, err := (*Node)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.node", err)
+	}
+	return &NodeOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Node records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *NodeOCFWriter) Append(values ...*Node) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *NodeOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
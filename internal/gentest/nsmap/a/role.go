@@ -0,0 +1,114 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package a
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"sync"
+)
+
+type Role int
+
+const (
+	RoleADMIN Role = iota
+	RoleMEMBER
+)
+
+var symbolsForRole = [...]string{"ADMIN", "MEMBER"}
+
+func NewRole(buf []byte) (Role, []byte, error) {
+	index, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return 0, buf, fmt.Errorf("cannot decode binary enum %q index: %s", "com.a.Role", err)
+	}
+	if index < 0 || index >= 2 {
+		return 0, buf, fmt.Errorf("cannot decode binary enum %q: index ought to be between 0 and 1; read index: %d", "com.a.Role", index)
+	}
+	return Role(index), newBuf, nil
+}
+
+func (e Role) MarshalAvro(buf []byte) ([]byte, error) {
+	if e < 0 || e >= 2 {
+		return buf, fmt.Errorf("cannot encode binary enum %q: index ought to be between 0 and 1; received: %d", "com.a.Role", e)
+	}
+	return goavro.IntEnumBinaryFromNative(buf, int(e))
+}
+
+// String returns the symbol of the Role, or its number when it is not one of
+// the symbols.
+func (e Role) String() string {
+	if e < 0 || e >= 2 {
+		return fmt.Sprintf("Role(%d)", int(e))
+	}
+	return symbolsForRole[e]
+}
+
+// ParseRole returns the Role with the specified symbol.
+func ParseRole(symbol string) (Role, error) {
+	for i, s := range symbolsForRole {
+		if s == symbol {
+			return Role(i), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot parse enum %q: value ought to be member of symbols: %v; %q", "com.a.Role", symbolsForRole, symbol)
+}
+
+// MarshalText returns the symbol of the enum.
+func (e Role) MarshalText() ([]byte, error) {
+	if e < 0 || e >= 2 {
+		return nil, fmt.Errorf("cannot encode textual enum %q: index ought to be between 0 and 1; received: %d", "com.a.Role", e)
+	}
+	return []byte(symbolsForRole[e]), nil
+}
+
+// UnmarshalText sets the enum to the value with the symbol in text.
+func (e *Role) UnmarshalText(text []byte) error {
+	value, err := ParseRole(string(text))
+	if err != nil {
+		return err
+	}
+	*e = value
+	return nil
+}
+
+// MarshalJSON returns the symbol of the enum as a JSON string.
+func (e Role) MarshalJSON() ([]byte, error) {
+	text, err := e.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON sets the enum to the value with the symbol in the JSON string.
+func (e *Role) UnmarshalJSON(data []byte) error {
+	var symbol string
+	if err := json.Unmarshal(data, &symbol); err != nil {
+		return fmt.Errorf("cannot decode textual enum %q: %s", "com.a.Role", err)
+	}
+	return e.UnmarshalText([]byte(symbol))
+}
+
+// Schema returns the canonical form of the schema for Role.
+func (Role) Schema() string {
+	return `{"name":"com.a.Role","type":"enum","symbols":["ADMIN","MEMBER"]}`
+}
+
+var codecForRole struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Role, which is built the first time
+// it is requested.
+func (Role) Codec() (*goavro.Codec, error) {
+	codecForRole.once.Do(func() {
+		codecForRole.codec, codecForRole.err = goavro.NewCodec(`{"name":"com.a.Role","symbols":["ADMIN","MEMBER"],"type":"enum"}`)
+	})
+	return codecForRole.codec, codecForRole.err
+}
@@ -0,0 +1,180 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package a
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"sync"
+)
+
+type User struct {
+	Id   int64 `avro:"id"`
+	Role Role  `avro:"role"`
+}
+
+func NewUser(buf []byte) (*User, []byte, error) {
+	return NewUserAtDepth(buf, 0)
+}
+
+// NewUserAtDepth is like NewUser, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUserAtDepth(buf []byte, depth int) (*User, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.a.User", goavro.MaxDecodeDepth)
+	}
+	result := &User{}
+	newBuf := buf
+	var err error
+
+	if result.Id, newBuf, err = goavro.LongNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.a.User", "id", err)
+	}
+
+	if result.Role, newBuf, err = NewRole(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.a.User", "role", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *User) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.a.User")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = goavro.LongBinaryFromNative(newBuf, r.Id); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.a.User", "id", err)
+	}
+
+	if newBuf, err = func(buf []byte, e Role) ([]byte, error) {
+		return e.MarshalAvro(buf)
+	}(newBuf, r.Role); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.a.User", "role", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewUserWithDefaults returns a new User, with each field that has a default
+// value in the schema set to that value.
+func NewUserWithDefaults() *User {
+	r := &User{}
+	return r
+}
+
+// Schema returns the canonical form of the schema for User.
+func (r *User) Schema() string {
+	return `{"name":"com.a.User","type":"record","fields":[{"name":"id","type":"long"},{"name":"role","type":{"name":"com.a.Role","type":"enum","symbols":["ADMIN","MEMBER"]}}]}`
+}
+
+var codecForUser struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of User, which is built the first time
+// it is requested.
+func (r *User) Codec() (*goavro.Codec, error) {
+	codecForUser.once.Do(func() {
+		codecForUser.codec, codecForUser.err = goavro.NewCodec(`{"fields":[{"name":"id","type":"long"},{"name":"role","type":{"name":"com.a.Role","symbols":["ADMIN","MEMBER"],"type":"enum"}}],"name":"com.a.User","type":"record"}`)
+	})
+	return codecForUser.codec, codecForUser.err
+}
+
+// UserOCFReader reads User records from an Avro Object Container File.
+type UserOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewUserOCFReader returns a reader of the User records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of User,
+// or resolves to it once its names are fully qualified.
+func NewUserOCFReader(ior io.Reader) (*UserOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*User)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.a.User", err)
+	}
+	return &UserOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *UserOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next User record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *UserOCFReader) Read() (*User, error) {
+	var result *User
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewUser(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *UserOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *UserOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// UserOCFWriter appends User records to an Avro Object Container File.
+type UserOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewUserOCFWriter returns a writer of User records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of User
+// is used. It returns an error unless the schema of the file is the schema of
+// User, or resolves to it once its names are fully qualified.
+func NewUserOCFWriter(config goavro.OCFConfig) (*UserOCFWriter, error) {
+	codec, err := (*User)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.a.User", err)
+	}
+	return &UserOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the User records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *UserOCFWriter) Append(values ...*User) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *UserOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
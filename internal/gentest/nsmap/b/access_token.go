@@ -0,0 +1,46 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package b
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"sync"
+)
+
+type AccessToken [4]byte
+
+func NewAccessToken(buf []byte) (AccessToken, []byte, error) {
+	var result AccessToken
+	if buflen := len(buf); buflen < 4 {
+		return result, buf, fmt.Errorf("cannot decode binary fixed %q: schema size exceeds remaining buffer size: 4 > %d (short buffer)", "com.b.Token", buflen)
+	}
+	copy(result[:], buf)
+	return result, buf[4:], nil
+}
+
+func (f AccessToken) MarshalAvro(buf []byte) ([]byte, error) {
+	return append(buf, f[:]...), nil
+}
+
+// Schema returns the canonical form of the schema for AccessToken.
+func (AccessToken) Schema() string {
+	return `{"name":"com.b.Token","type":"fixed","size":4}`
+}
+
+var codecForAccessToken struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of AccessToken, which is built the first time
+// it is requested.
+func (AccessToken) Codec() (*goavro.Codec, error) {
+	codecForAccessToken.once.Do(func() {
+		codecForAccessToken.codec, codecForAccessToken.err = goavro.NewCodec(`{"name":"com.b.Token","size":4,"type":"fixed"}`)
+	})
+	return codecForAccessToken.codec, codecForAccessToken.err
+}
@@ -0,0 +1,112 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package b
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"github.com/peak6/goavro/v2/internal/gentest/nsmap/a"
+)
+
+// UnionNullUserString holds exactly one of the values allowed by the union [null com.a.User string].
+// Its zero value holds the zero value of the first member of the union.
+type UnionNullUserString struct {
+	index       int
+	valueUser   *a.User
+	valueString string
+}
+
+// Index returns the index of the union member held by the union.
+func (u UnionNullUserString) Index() int {
+	return u.index
+}
+
+// IsNull returns true when the union holds null.
+func (u UnionNullUserString) IsNull() bool {
+	return u.index == 0
+}
+
+// SetNull sets the union to hold null.
+func (u *UnionNullUserString) SetNull() {
+	*u = UnionNullUserString{index: 0}
+}
+
+// AsUser returns the com.a.User value held by the union, and true when the union
+// holds a com.a.User value.
+func (u UnionNullUserString) AsUser() (*a.User, bool) {
+	return u.valueUser, u.index == 1
+}
+
+// SetUser sets the union to hold the specified com.a.User value.
+func (u *UnionNullUserString) SetUser(v *a.User) {
+	*u = UnionNullUserString{index: 1, valueUser: v}
+}
+
+// AsString returns the string value held by the union, and true when the union
+// holds a string value.
+func (u UnionNullUserString) AsString() (string, bool) {
+	return u.valueString, u.index == 2
+}
+
+// SetString sets the union to hold the specified string value.
+func (u *UnionNullUserString) SetString(v string) {
+	*u = UnionNullUserString{index: 2, valueString: v}
+}
+
+func NewUnionNullUserString(buf []byte) (UnionNullUserString, []byte, error) {
+	return NewUnionNullUserStringAtDepth(buf, 0)
+}
+
+// NewUnionNullUserStringAtDepth is like NewUnionNullUserString, for a union nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUnionNullUserStringAtDepth(buf []byte, depth int) (UnionNullUserString, []byte, error) {
+	var result UnionNullUserString
+	idx, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return result, buf, err
+	}
+	switch idx {
+	case 0:
+	// Null case, nothing to decode
+	case 1:
+		if result.valueUser, newBuf, err = func(buf []byte) (*a.User, []byte, error) {
+			return a.NewUserAtDepth(buf, depth+1)
+		}(newBuf); err != nil {
+			return UnionNullUserString{}, buf, fmt.Errorf("cannot decode binary union item 2: %s", err)
+		}
+	case 2:
+		if result.valueString, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+			return UnionNullUserString{}, buf, fmt.Errorf("cannot decode binary union item 3: %s", err)
+		}
+	default:
+		return result, buf, fmt.Errorf("cannot decode binary union: index ought to be between 0 and 2; read index: %d", idx)
+	}
+	result.index = int(idx)
+	return result, newBuf, nil
+}
+
+func (u UnionNullUserString) MarshalAvro(buf []byte) ([]byte, error) {
+	var err error
+	newBuf := buf
+	switch u.index {
+	case 0:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 0)
+	case 1:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 1)
+		if newBuf, err = func(buf []byte, r *a.User) ([]byte, error) {
+			return r.MarshalAvro(buf)
+		}(newBuf, u.valueUser); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 2: %s", err)
+		}
+	case 2:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 2)
+		if newBuf, err = goavro.StringBinaryFromNative(newBuf, u.valueString); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 3: %s", err)
+		}
+	default:
+		return buf, fmt.Errorf("cannot encode binary union: index ought to be between 0 and 2; received: %d", u.index)
+	}
+	return newBuf, nil
+}
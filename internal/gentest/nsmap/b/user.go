@@ -0,0 +1,288 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package b
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"github.com/peak6/goavro/v2/internal/gentest/nsmap/a"
+	"io"
+	"sync"
+)
+
+type User struct {
+	Name     string              `avro:"name"`
+	Owner    *a.User             `avro:"owner"`
+	Delegate UnionNullUserString `avro:"delegate"`
+	Roles    []a.Role            `avro:"roles"`
+	Token    AccessToken         `avro:"token"`
+}
+
+func NewUser(buf []byte) (*User, []byte, error) {
+	return NewUserAtDepth(buf, 0)
+}
+
+// NewUserAtDepth is like NewUser, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUserAtDepth(buf []byte, depth int) (*User, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.b.User", goavro.MaxDecodeDepth)
+	}
+	result := &User{}
+	newBuf := buf
+	var err error
+
+	if result.Name, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.b.User", "name", err)
+	}
+
+	if result.Owner, newBuf, err = func(buf []byte) (*a.User, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*a.User, []byte, error) {
+				return a.NewUserAtDepth(buf, depth+1)
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.b.User", "owner", err)
+	}
+
+	if result.Delegate, newBuf, err = func(buf []byte) (UnionNullUserString, []byte, error) {
+		return NewUnionNullUserStringAtDepth(buf, depth)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.b.User", "delegate", err)
+	}
+
+	if result.Roles, newBuf, err = func(buf []byte) ([]a.Role, []byte, error) {
+		var value a.Role
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+		if err != nil {
+			return []a.Role{}, buf, err
+		}
+
+		arrayValues := make([]a.Role, 0, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if value, tmpBuf, err = a.NewRole(tmpBuf); err != nil {
+					return []a.Role{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				} else {
+					arrayValues = append(arrayValues, value)
+
+				}
+			}
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []a.Role{}, buf, err
+			}
+
+		}
+		return arrayValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.b.User", "roles", err)
+	}
+
+	if result.Token, newBuf, err = NewAccessToken(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.b.User", "token", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *User) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.b.User")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = goavro.StringBinaryFromNative(newBuf, r.Name); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.b.User", "name", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *a.User) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, r *a.User) ([]byte, error) {
+			return r.MarshalAvro(buf)
+		}(buf, v)
+	}(newBuf, r.Owner); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.b.User", "owner", err)
+	}
+
+	if newBuf, err = func(buf []byte, u UnionNullUserString) ([]byte, error) {
+		return u.MarshalAvro(buf)
+	}(newBuf, r.Delegate); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.b.User", "delegate", err)
+	}
+
+	if newBuf, err = func(buf []byte, values []a.Role) ([]byte, error) {
+		var err error
+		var remainingInBlock int64
+
+		for i, value := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = int64(len(values) - i)
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			if buf, err = func(buf []byte, e a.Role) ([]byte, error) {
+				return e.MarshalAvro(buf)
+			}(buf, value); err != nil {
+				return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+			}
+			remainingInBlock--
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+	}(newBuf, r.Roles); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.b.User", "roles", err)
+	}
+
+	if newBuf, err = func(buf []byte, f AccessToken) ([]byte, error) {
+		return f.MarshalAvro(buf)
+	}(newBuf, r.Token); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.b.User", "token", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewUserWithDefaults returns a new User, with each field that has a default
+// value in the schema set to that value.
+func NewUserWithDefaults() *User {
+	r := &User{}
+	return r
+}
+
+// Schema returns the canonical form of the schema for User.
+func (r *User) Schema() string {
+	return `{"name":"com.b.User","type":"record","fields":[{"name":"name","type":"string"},{"name":"owner","type":["null",{"name":"com.a.User","type":"record","fields":[{"name":"id","type":"long"},{"name":"role","type":{"name":"com.a.Role","type":"enum","symbols":["ADMIN","MEMBER"]}}]}]},{"name":"delegate","type":["null","com.a.User","string"]},{"name":"roles","type":{"type":"array","items":"com.a.Role"}},{"name":"token","type":{"name":"com.b.Token","type":"fixed","size":4}}]}`
+}
+
+var codecForUser struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of User, which is built the first time
+// it is requested.
+func (r *User) Codec() (*goavro.Codec, error) {
+	codecForUser.once.Do(func() {
+		codecForUser.codec, codecForUser.err = goavro.NewCodec(`{"fields":[{"name":"name","type":"string"},{"name":"owner","type":["null",{"fields":[{"name":"id","type":"long"},{"name":"role","type":{"name":"com.a.Role","symbols":["ADMIN","MEMBER"],"type":"enum"}}],"name":"com.a.User","type":"record"}]},{"name":"delegate","type":["null","com.a.User","string"]},{"name":"roles","type":{"items":"com.a.Role","type":"array"}},{"name":"token","type":{"name":"com.b.Token","size":4,"type":"fixed"}}],"name":"com.b.User","type":"record"}`)
+	})
+	return codecForUser.codec, codecForUser.err
+}
+
+// UserOCFReader reads User records from an Avro Object Container File.
+type UserOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewUserOCFReader returns a reader of the User records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of User,
+// or resolves to it once its names are fully qualified.
+func NewUserOCFReader(ior io.Reader) (*UserOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*User)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.b.User", err)
+	}
+	return &UserOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *UserOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next User record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *UserOCFReader) Read() (*User, error) {
+	var result *User
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewUser(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *UserOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *UserOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// UserOCFWriter appends User records to an Avro Object Container File.
+type UserOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewUserOCFWriter returns a writer of User records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of User
+// is used. It returns an error unless the schema of the file is the schema of
+// User, or resolves to it once its names are fully qualified.
+func NewUserOCFWriter(config goavro.OCFConfig) (*UserOCFWriter, error) {
+	codec, err := (*User)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.b.User", err)
+	}
+	return &UserOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the User records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *UserOCFWriter) Append(values ...*User) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *UserOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
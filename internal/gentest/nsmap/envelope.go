@@ -0,0 +1,199 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package nsmap
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"github.com/peak6/goavro/v2/internal/gentest/nsmap/a"
+	"github.com/peak6/goavro/v2/internal/gentest/nsmap/b"
+	"io"
+	"sync"
+)
+
+type Envelope struct {
+	Sender    *a.User       `avro:"sender"`
+	Recipient *b.User       `avro:"recipient"`
+	Token     b.AccessToken `avro:"token"`
+}
+
+func NewEnvelope(buf []byte) (*Envelope, []byte, error) {
+	return NewEnvelopeAtDepth(buf, 0)
+}
+
+// NewEnvelopeAtDepth is like NewEnvelope, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewEnvelopeAtDepth(buf []byte, depth int) (*Envelope, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.Envelope", goavro.MaxDecodeDepth)
+	}
+	result := &Envelope{}
+	newBuf := buf
+	var err error
+
+	if result.Sender, newBuf, err = func(buf []byte) (*a.User, []byte, error) {
+		return a.NewUserAtDepth(buf, depth+1)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.Envelope", "sender", err)
+	}
+
+	if result.Recipient, newBuf, err = func(buf []byte) (*b.User, []byte, error) {
+		return b.NewUserAtDepth(buf, depth+1)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.Envelope", "recipient", err)
+	}
+
+	if result.Token, newBuf, err = b.NewAccessToken(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.Envelope", "token", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Envelope) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.Envelope")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = func(buf []byte, r *a.User) ([]byte, error) {
+		return r.MarshalAvro(buf)
+	}(newBuf, r.Sender); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.Envelope", "sender", err)
+	}
+
+	if newBuf, err = func(buf []byte, r *b.User) ([]byte, error) {
+		return r.MarshalAvro(buf)
+	}(newBuf, r.Recipient); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.Envelope", "recipient", err)
+	}
+
+	if newBuf, err = func(buf []byte, f b.AccessToken) ([]byte, error) {
+		return f.MarshalAvro(buf)
+	}(newBuf, r.Token); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.Envelope", "token", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewEnvelopeWithDefaults returns a new Envelope, with each field that has a default
+// value in the schema set to that value.
+func NewEnvelopeWithDefaults() *Envelope {
+	r := &Envelope{}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Envelope.
+func (r *Envelope) Schema() string {
+	return `{"name":"com.example.Envelope","type":"record","fields":[{"name":"sender","type":{"name":"com.a.User","type":"record","fields":[{"name":"id","type":"long"},{"name":"role","type":{"name":"com.a.Role","type":"enum","symbols":["ADMIN","MEMBER"]}}]}},{"name":"recipient","type":{"name":"com.b.User","type":"record","fields":[{"name":"name","type":"string"},{"name":"owner","type":["null","com.a.User"]},{"name":"delegate","type":["null","com.a.User","string"]},{"name":"roles","type":{"type":"array","items":"com.a.Role"}},{"name":"token","type":{"name":"com.b.Token","type":"fixed","size":4}}]}},{"name":"token","type":"com.b.Token"}]}`
+}
+
+var codecForEnvelope struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Envelope, which is built the first time
+// it is requested.
+func (r *Envelope) Codec() (*goavro.Codec, error) {
+	codecForEnvelope.once.Do(func() {
+		codecForEnvelope.codec, codecForEnvelope.err = goavro.NewCodec(`{"fields":[{"name":"sender","type":{"fields":[{"name":"id","type":"long"},{"name":"role","type":{"name":"com.a.Role","symbols":["ADMIN","MEMBER"],"type":"enum"}}],"name":"com.a.User","type":"record"}},{"name":"recipient","type":{"fields":[{"name":"name","type":"string"},{"name":"owner","type":["null","com.a.User"]},{"name":"delegate","type":["null","com.a.User","string"]},{"name":"roles","type":{"items":"com.a.Role","type":"array"}},{"name":"token","type":{"name":"com.b.Token","size":4,"type":"fixed"}}],"name":"com.b.User","type":"record"}},{"name":"token","type":"com.b.Token"}],"name":"com.example.Envelope","type":"record"}`)
+	})
+	return codecForEnvelope.codec, codecForEnvelope.err
+}
+
+// EnvelopeOCFReader reads Envelope records from an Avro Object Container File.
+type EnvelopeOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewEnvelopeOCFReader returns a reader of the Envelope records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Envelope,
+// or resolves to it once its names are fully qualified.
+func NewEnvelopeOCFReader(ior io.Reader) (*EnvelopeOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Envelope)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.Envelope", err)
+	}
+	return &EnvelopeOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *EnvelopeOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Envelope record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *EnvelopeOCFReader) Read() (*Envelope, error) {
+	var result *Envelope
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewEnvelope(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *EnvelopeOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *EnvelopeOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// EnvelopeOCFWriter appends Envelope records to an Avro Object Container File.
+type EnvelopeOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewEnvelopeOCFWriter returns a writer of Envelope records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Envelope
+// is used. It returns an error unless the schema of the file is the schema of
+// Envelope, or resolves to it once its names are fully qualified.
+func NewEnvelopeOCFWriter(config goavro.OCFConfig) (*EnvelopeOCFWriter, error) {
+	codec, err := (*Envelope)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.Envelope", err)
+	}
+	return &EnvelopeOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Envelope records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *EnvelopeOCFWriter) Append(values ...*Envelope) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *EnvelopeOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
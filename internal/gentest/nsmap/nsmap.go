@@ -0,0 +1,7 @@
+// Package nsmap holds code generated from the schemas in its testdata
+// directory, with each Avro namespace mapped to its own package, and tests
+// that compare the behavior of the generated code with that of the
+// goavro.Codec built from the same schemas.
+package nsmap
+
+//go:generate go run ../../../avrogen -config avrogen.json
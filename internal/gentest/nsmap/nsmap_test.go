@@ -0,0 +1,97 @@
+package nsmap
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/peak6/goavro/v2"
+	"github.com/peak6/goavro/v2/internal/gentest/nsmap/a"
+	"github.com/peak6/goavro/v2/internal/gentest/nsmap/b"
+)
+
+// newEnvelopeCodec returns the codec for the Envelope record, whose schema
+// refers to types defined in other schema files, by defining those types
+// inline.
+func newEnvelopeCodec(t *testing.T) *goavro.Codec {
+	t.Helper()
+	userA, err := ioutil.ReadFile("testdata/a.avsc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	userB, err := ioutil.ReadFile("testdata/b.avsc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec, err := goavro.NewCodec(fmt.Sprintf(`{"type":"record","name":"Envelope","namespace":"com.example","fields":[{"name":"sender","type":%s},{"name":"recipient","type":%s},{"name":"token","type":"com.b.Token"}]}`, userA, userB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return codec
+}
+
+func TestEnvelopeMarshalAvroMatchesCodec(t *testing.T) {
+	codec := newEnvelopeCodec(t)
+
+	var delegate b.UnionNullUserString
+	delegate.SetUser(&a.User{Id: 7, Role: a.RoleMEMBER})
+	envelope := &Envelope{
+		Sender: &a.User{Id: 1, Role: a.RoleADMIN},
+		Recipient: &b.User{
+			Name:     "bob",
+			Owner:    &a.User{Id: 2, Role: a.RoleMEMBER},
+			Delegate: delegate,
+			Roles:    []a.Role{a.RoleADMIN, a.RoleMEMBER},
+			Token:    b.AccessToken{1, 2, 3, 4},
+		},
+		Token: b.AccessToken{5, 6, 7, 8},
+	}
+
+	actual, err := envelope.MarshalAvro(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := codec.BinaryFromNative(nil, map[string]interface{}{
+		"sender": map[string]interface{}{"id": int64(1), "role": "ADMIN"},
+		"recipient": map[string]interface{}{
+			"name":     "bob",
+			"owner":    goavro.Union("com.a.User", map[string]interface{}{"id": int64(2), "role": "MEMBER"}),
+			"delegate": goavro.Union("com.a.User", map[string]interface{}{"id": int64(7), "role": "MEMBER"}),
+			"roles":    []interface{}{"ADMIN", "MEMBER"},
+			"token":    []byte{1, 2, 3, 4},
+		},
+		"token": []byte{5, 6, 7, 8},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+
+	// The codec of the generated type inlines the types defined by the schemas
+	// of other packages.
+	generatedCodec, err := envelope.Codec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := envelope.Schema(), generatedCodec.CanonicalSchema(); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if _, _, err = generatedCodec.NativeFromBinary(expected); err != nil {
+		t.Errorf("GOT: %v; WANT: %v", err, nil)
+	}
+
+	decoded, rest, err := NewEnvelope(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != 0 {
+		t.Errorf("GOT: %#v; WANT: %#v", rest, []byte{})
+	}
+	if !reflect.DeepEqual(decoded, envelope) {
+		t.Errorf("GOT: %#v; WANT: %#v", decoded, envelope)
+	}
+}
@@ -0,0 +1,821 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"math/big"
+	"sync"
+	"time"
+)
+
+type Order struct {
+	Id             int64     `avro:"id"`
+	Quantity       int32     `avro:"quantity"`
+	Price          float64   `avro:"price"`
+	Weight         float32   `avro:"weight"`
+	Gift           bool      `avro:"gift"`
+	Note           string    `avro:"note"`
+	Placed         time.Time `avro:"placed"`
+	Total          *big.Rat  `avro:"total"`
+	Status         Status    `avro:"status"`
+	PreviousStatus *Status   `avro:"previousStatus"`
+	Tags           []string  `avro:"tags"`
+	History        []Status  `avro:"history"`
+	Coupon         *string   `avro:"coupon"`
+	Discount       *float64  `avro:"discount"`
+	Rush           *bool     `avro:"rush"`
+	Lines          []*Line   `avro:"lines"`
+	ShipTo         *Address  `avro:"shipTo"`
+	BillTo         *Address  `avro:"billTo"`
+	Notes          []string  `avro:"notes"`
+}
+
+func NewOrder(buf []byte) (*Order, []byte, error) {
+	return NewOrderAtDepth(buf, 0)
+}
+
+// NewOrderAtDepth is like NewOrder, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewOrderAtDepth(buf []byte, depth int) (*Order, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.order", goavro.MaxDecodeDepth)
+	}
+	result := &Order{}
+	newBuf := buf
+	var err error
+
+	if result.Id, newBuf, err = goavro.LongNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "id", err)
+	}
+
+	if result.Quantity, newBuf, err = goavro.IntNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "quantity", err)
+	}
+
+	if result.Price, newBuf, err = goavro.DoubleNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "price", err)
+	}
+
+	if result.Weight, newBuf, err = goavro.FloatNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "weight", err)
+	}
+
+	if result.Gift, newBuf, err = goavro.BoolNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "gift", err)
+	}
+
+	if result.Note, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "note", err)
+	}
+
+	if result.Placed, newBuf, err = goavro.NativeFromBinaryDate(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "placed", err)
+	}
+
+	if result.Total, newBuf, err = func(buf []byte) (*big.Rat, []byte, error) {
+		return goavro.NativeFromBinaryDecimalBytes(buf, 10, 2)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "total", err)
+	}
+
+	if result.Status, newBuf, err = NewStatus(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "status", err)
+	}
+
+	if result.PreviousStatus, newBuf, err = func(buf []byte) (*Status, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*Status, []byte, error) {
+				v, newBuf, err := NewStatus(buf)
+				if err != nil {
+					return nil, buf, err
+				}
+				return &v, newBuf, nil
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "previousStatus", err)
+	}
+
+	if result.Tags, newBuf, err = func(buf []byte) ([]string, []byte, error) {
+		var value string
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+		if err != nil {
+			return []string{}, buf, err
+		}
+
+		arrayValues := make([]string, 0, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if value, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+					return []string{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				} else {
+					arrayValues = append(arrayValues, value)
+
+				}
+			}
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []string{}, buf, err
+			}
+
+		}
+		return arrayValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "tags", err)
+	}
+
+	if result.History, newBuf, err = func(buf []byte) ([]Status, []byte, error) {
+		var value Status
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+		if err != nil {
+			return []Status{}, buf, err
+		}
+
+		arrayValues := make([]Status, 0, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if value, tmpBuf, err = NewStatus(tmpBuf); err != nil {
+					return []Status{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				} else {
+					arrayValues = append(arrayValues, value)
+
+				}
+			}
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []Status{}, buf, err
+			}
+
+		}
+		return arrayValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "history", err)
+	}
+
+	if result.Coupon, newBuf, err = func(buf []byte) (*string, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.StringNativePtrFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "coupon", err)
+	}
+
+	if result.Discount, newBuf, err = func(buf []byte) (*float64, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.DoubleNativePtrFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "discount", err)
+	}
+
+	if result.Rush, newBuf, err = func(buf []byte) (*bool, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			return goavro.BoolNativePtrFromBinary(tmpBuf)
+		case 1:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "rush", err)
+	}
+
+	if result.Lines, newBuf, err = func(buf []byte) ([]*Line, []byte, error) {
+		var value *Line
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+		if err != nil {
+			return []*Line{}, buf, err
+		}
+
+		arrayValues := make([]*Line, 0, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if value, tmpBuf, err = func(buf []byte) (*Line, []byte, error) {
+					return NewLineAtDepth(buf, depth+1)
+				}(tmpBuf); err != nil {
+					return []*Line{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				} else {
+					arrayValues = append(arrayValues, value)
+
+				}
+			}
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []*Line{}, buf, err
+			}
+
+		}
+		return arrayValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "lines", err)
+	}
+
+	if result.ShipTo, newBuf, err = func(buf []byte) (*Address, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*Address, []byte, error) {
+				return NewAddressAtDepth(buf, depth+1)
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "shipTo", err)
+	}
+
+	if result.BillTo, newBuf, err = func(buf []byte) (*Address, []byte, error) {
+		return NewAddressAtDepth(buf, depth+1)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "billTo", err)
+	}
+
+	if result.Notes, newBuf, err = func(buf []byte) ([]string, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) ([]string, []byte, error) {
+				var value string
+				var err error
+				var blockCount int64
+				tmpBuf := buf
+
+				blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+				if err != nil {
+					return []string{}, buf, err
+				}
+
+				arrayValues := make([]string, 0, blockCount)
+
+				for blockCount != 0 {
+					// Decode 'blockCount' datum values
+					for i := int64(0); i < blockCount; i++ {
+						if value, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+							return []string{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+						} else {
+							arrayValues = append(arrayValues, value)
+
+						}
+					}
+					blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+					if err != nil {
+						return []string{}, buf, err
+					}
+
+				}
+				return arrayValues, tmpBuf, nil
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.order", "notes", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Order) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.order")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = goavro.LongBinaryFromNative(newBuf, r.Id); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "id", err)
+	}
+
+	if newBuf, err = goavro.IntBinaryFromNative(newBuf, r.Quantity); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "quantity", err)
+	}
+
+	if newBuf, err = goavro.DoubleBinaryFromNative(newBuf, r.Price); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "price", err)
+	}
+
+	if newBuf, err = goavro.FloatBinaryFromNative(newBuf, r.Weight); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "weight", err)
+	}
+
+	if newBuf, err = goavro.BoolBinaryFromNative(newBuf, r.Gift); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "gift", err)
+	}
+
+	if newBuf, err = goavro.StringBinaryFromNative(newBuf, r.Note); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "note", err)
+	}
+
+	if newBuf, err = goavro.BinaryFromNativeDate(newBuf, r.Placed); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "placed", err)
+	}
+
+	if newBuf, err = func(buf []byte, r *big.Rat) ([]byte, error) {
+		return goavro.BinaryFromNativeDecimalBytes(buf, r, 10, 2)
+	}(newBuf, r.Total); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "total", err)
+	}
+
+	if newBuf, err = func(buf []byte, e Status) ([]byte, error) {
+		return e.MarshalAvro(buf)
+	}(newBuf, r.Status); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "status", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *Status) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, v *Status) ([]byte, error) {
+			return func(buf []byte, e Status) ([]byte, error) {
+				return e.MarshalAvro(buf)
+			}(buf, *v)
+		}(buf, v)
+	}(newBuf, r.PreviousStatus); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "previousStatus", err)
+	}
+
+	if newBuf, err = func(buf []byte, values []string) ([]byte, error) {
+		var err error
+		var remainingInBlock int64
+
+		for i, value := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = int64(len(values) - i)
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			if buf, err = goavro.StringBinaryFromNative(buf, value); err != nil {
+				return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+			}
+			remainingInBlock--
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+	}(newBuf, r.Tags); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "tags", err)
+	}
+
+	if newBuf, err = func(buf []byte, values []Status) ([]byte, error) {
+		var err error
+		var remainingInBlock int64
+
+		for i, value := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = int64(len(values) - i)
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			if buf, err = func(buf []byte, e Status) ([]byte, error) {
+				return e.MarshalAvro(buf)
+			}(buf, value); err != nil {
+				return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+			}
+			remainingInBlock--
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+	}(newBuf, r.History); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "history", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *string) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, v *string) ([]byte, error) {
+			return goavro.StringBinaryFromNative(buf, *v)
+		}(buf, v)
+	}(newBuf, r.Coupon); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "coupon", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *float64) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, v *float64) ([]byte, error) {
+			return goavro.DoubleBinaryFromNative(buf, *v)
+		}(buf, v)
+	}(newBuf, r.Discount); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "discount", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *bool) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 1)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 0)
+		return func(buf []byte, v *bool) ([]byte, error) {
+			return goavro.BoolBinaryFromNative(buf, *v)
+		}(buf, v)
+	}(newBuf, r.Rush); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "rush", err)
+	}
+
+	if newBuf, err = func(buf []byte, values []*Line) ([]byte, error) {
+		var err error
+		var remainingInBlock int64
+
+		for i, value := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = int64(len(values) - i)
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			if buf, err = func(buf []byte, r *Line) ([]byte, error) {
+				return r.MarshalAvro(buf)
+			}(buf, value); err != nil {
+				return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+			}
+			remainingInBlock--
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+	}(newBuf, r.Lines); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "lines", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *Address) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, r *Address) ([]byte, error) {
+			return r.MarshalAvro(buf)
+		}(buf, v)
+	}(newBuf, r.ShipTo); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "shipTo", err)
+	}
+
+	if newBuf, err = func(buf []byte, r *Address) ([]byte, error) {
+		return r.MarshalAvro(buf)
+	}(newBuf, r.BillTo); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "billTo", err)
+	}
+
+	if newBuf, err = func(buf []byte, v []string) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, values []string) ([]byte, error) {
+			var err error
+			var remainingInBlock int64
+
+			for i, value := range values {
+				if remainingInBlock == 0 { // start a new block
+					remainingInBlock = int64(len(values) - i)
+					if remainingInBlock > goavro.MaxBlockCount {
+						// limit block count to MaxBlockCount
+						remainingInBlock = goavro.MaxBlockCount
+					}
+					buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+				}
+				if buf, err = goavro.StringBinaryFromNative(buf, value); err != nil {
+					return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+				}
+				remainingInBlock--
+			}
+			return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+		}(buf, v)
+	}(newBuf, r.Notes); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.order", "notes", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewOrderWithDefaults returns a new Order, with each field that has a default
+// value in the schema set to that value.
+func NewOrderWithDefaults() *Order {
+	r := &Order{}
+	var err error
+	const depth = 0
+	if r.PreviousStatus, _, err = func(buf []byte) (*Status, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*Status, []byte, error) {
+				v, newBuf, err := NewStatus(buf)
+				if err != nil {
+					return nil, buf, err
+				}
+				return &v, newBuf, nil
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.order", "previousStatus", err))
+	}
+	if r.Coupon, _, err = func(buf []byte) (*string, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.StringNativePtrFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.order", "coupon", err))
+	}
+	if r.Discount, _, err = func(buf []byte) (*float64, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.DoubleNativePtrFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.order", "discount", err))
+	}
+	if r.ShipTo, _, err = func(buf []byte) (*Address, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*Address, []byte, error) {
+				return NewAddressAtDepth(buf, depth+1)
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.order", "shipTo", err))
+	}
+	if r.Notes, _, err = func(buf []byte) ([]string, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) ([]string, []byte, error) {
+				var value string
+				var err error
+				var blockCount int64
+				tmpBuf := buf
+
+				blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+				if err != nil {
+					return []string{}, buf, err
+				}
+
+				arrayValues := make([]string, 0, blockCount)
+
+				for blockCount != 0 {
+					// Decode 'blockCount' datum values
+					for i := int64(0); i < blockCount; i++ {
+						if value, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+							return []string{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+						} else {
+							arrayValues = append(arrayValues, value)
+
+						}
+					}
+					blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+					if err != nil {
+						return []string{}, buf, err
+					}
+
+				}
+				return arrayValues, tmpBuf, nil
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.order", "notes", err))
+	}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Order.
+func (r *Order) Schema() string {
+	return `{"name":"com.example.gentest.order","type":"record","fields":[{"name":"id","type":"long"},{"name":"quantity","type":"int"},{"name":"price","type":"double"},{"name":"weight","type":"float"},{"name":"gift","type":"boolean"},{"name":"note","type":"string"},{"name":"placed","type":"int"},{"name":"total","type":"bytes"},{"name":"status","type":{"name":"com.example.gentest.status","type":"enum","symbols":["PENDING","SHIPPED","DELIVERED"]}},{"name":"previousStatus","type":["null","com.example.gentest.status"]},{"name":"tags","type":{"type":"array","items":"string"}},{"name":"history","type":{"type":"array","items":"com.example.gentest.status"}},{"name":"coupon","type":["null","string"]},{"name":"discount","type":["null","double"]},{"name":"rush","type":["boolean","null"]},{"name":"lines","type":{"type":"array","items":{"name":"com.example.gentest.line","type":"record","fields":[{"name":"sku","type":"string"},{"name":"count","type":["null","long"]}]}}},{"name":"shipTo","type":["null",{"name":"com.example.gentest.address","type":"record","fields":[{"name":"street","type":"string"},{"name":"zip","type":["null","int"]}]}]},{"name":"billTo","type":"com.example.gentest.address"},{"name":"notes","type":["null",{"type":"array","items":"string"}]}]}`
+}
+
+var codecForOrder struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Order, which is built the first time
+// it is requested.
+func (r *Order) Codec() (*goavro.Codec, error) {
+	codecForOrder.once.Do(func() {
+		codecForOrder.codec, codecForOrder.err = goavro.NewCodec(`{"fields":[{"name":"id","type":"long"},{"name":"quantity","type":"int"},{"name":"price","type":"double"},{"name":"weight","type":"float"},{"name":"gift","type":"boolean"},{"name":"note","type":"string"},{"name":"placed","type":{"logicalType":"date","type":"int"}},{"name":"total","type":{"logicalType":"decimal","precision":10,"scale":2,"type":"bytes"}},{"name":"status","type":{"name":"com.example.gentest.status","symbols":["PENDING","SHIPPED","DELIVERED"],"type":"enum"}},{"default":null,"name":"previousStatus","type":["null","com.example.gentest.status"]},{"name":"tags","type":{"items":"string","type":"array"}},{"name":"history","type":{"items":"com.example.gentest.status","type":"array"}},{"default":null,"name":"coupon","type":["null","string"]},{"default":null,"name":"discount","type":["null","double"]},{"name":"rush","type":["boolean","null"]},{"name":"lines","type":{"items":{"fields":[{"name":"sku","type":"string"},{"default":null,"name":"count","type":["null","long"]}],"name":"com.example.gentest.line","type":"record"},"type":"array"}},{"default":null,"name":"shipTo","type":["null",{"fields":[{"name":"street","type":"string"},{"default":null,"name":"zip","type":["null","int"]}],"name":"com.example.gentest.address","type":"record"}]},{"name":"billTo","type":"com.example.gentest.address"},{"default":null,"name":"notes","type":["null",{"items":"string","type":"array"}]}],"name":"com.example.gentest.order","type":"record"}`)
+	})
+	return codecForOrder.codec, codecForOrder.err
+}
+
+// OrderOCFReader reads Order records from an Avro Object Container File.
+type OrderOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewOrderOCFReader returns a reader of the Order records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Order,
+// or resolves to it once its names are fully qualified.
+func NewOrderOCFReader(ior io.Reader) (*OrderOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Order)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.order", err)
+	}
+	return &OrderOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *OrderOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Order record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *OrderOCFReader) Read() (*Order, error) {
+	var result *Order
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewOrder(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *OrderOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *OrderOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// OrderOCFWriter appends Order records to an Avro Object Container File.
+type OrderOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewOrderOCFWriter returns a writer of Order records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Order
+// is used. It returns an error unless the schema of the file is the schema of
+// Order, or resolves to it once its names are fully qualified.
+func NewOrderOCFWriter(config goavro.OCFConfig) (*OrderOCFWriter, error) {
+	codec, err := (*Order)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.order", err)
+	}
+	return &OrderOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Order records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *OrderOCFWriter) Append(values ...*Order) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *OrderOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
@@ -0,0 +1,182 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"sync"
+)
+
+type Peer struct {
+	Id    SensorID `avro:"id"`
+	Level Level    `avro:"level"`
+}
+
+func NewPeer(buf []byte) (*Peer, []byte, error) {
+	return NewPeerAtDepth(buf, 0)
+}
+
+// NewPeerAtDepth is like NewPeer, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewPeerAtDepth(buf []byte, depth int) (*Peer, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.peer", goavro.MaxDecodeDepth)
+	}
+	result := &Peer{}
+	newBuf := buf
+	var err error
+
+	if result.Id, newBuf, err = NewSensorID(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.peer", "id", err)
+	}
+
+	if result.Level, newBuf, err = NewLevel(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.peer", "level", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Peer) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.peer")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = func(buf []byte, f SensorID) ([]byte, error) {
+		return f.MarshalAvro(buf)
+	}(newBuf, r.Id); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.peer", "id", err)
+	}
+
+	if newBuf, err = func(buf []byte, e Level) ([]byte, error) {
+		return e.MarshalAvro(buf)
+	}(newBuf, r.Level); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.peer", "level", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewPeerWithDefaults returns a new Peer, with each field that has a default
+// value in the schema set to that value.
+func NewPeerWithDefaults() *Peer {
+	r := &Peer{}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Peer.
+func (r *Peer) Schema() string {
+	return `{"name":"com.example.gentest.peer","type":"record","fields":[{"name":"id","type":{"name":"com.example.gentest.sensorID","type":"fixed","size":4}},{"name":"level","type":{"name":"com.example.gentest.level","type":"enum","symbols":["LOW","MEDIUM","HIGH"]}}]}`
+}
+
+var codecForPeer struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Peer, which is built the first time
+// it is requested.
+func (r *Peer) Codec() (*goavro.Codec, error) {
+	codecForPeer.once.Do(func() {
+		codecForPeer.codec, codecForPeer.err = goavro.NewCodec(`{"fields":[{"name":"id","type":{"name":"com.example.gentest.sensorID","size":4,"type":"fixed"}},{"name":"level","type":{"name":"com.example.gentest.level","symbols":["LOW","MEDIUM","HIGH"],"type":"enum"}}],"name":"com.example.gentest.peer","type":"record"}`)
+	})
+	return codecForPeer.codec, codecForPeer.err
+}
+
+// PeerOCFReader reads Peer records from an Avro Object Container File.
+type PeerOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewPeerOCFReader returns a reader of the Peer records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Peer,
+// or resolves to it once its names are fully qualified.
+func NewPeerOCFReader(ior io.Reader) (*PeerOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Peer)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.peer", err)
+	}
+	return &PeerOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *PeerOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Peer record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *PeerOCFReader) Read() (*Peer, error) {
+	var result *Peer
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewPeer(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *PeerOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *PeerOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// PeerOCFWriter appends Peer records to an Avro Object Container File.
+type PeerOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewPeerOCFWriter returns a writer of Peer records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Peer
+// is used. It returns an error unless the schema of the file is the schema of
+// Peer, or resolves to it once its names are fully qualified.
+func NewPeerOCFWriter(config goavro.OCFConfig) (*PeerOCFWriter, error) {
+	codec, err := (*Peer)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.peer", err)
+	}
+	return &PeerOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Peer records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *PeerOCFWriter) Append(values ...*Peer) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *PeerOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
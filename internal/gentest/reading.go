@@ -0,0 +1,881 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"math/big"
+	"sync"
+	"time"
+)
+
+type Reading struct {
+	Sensor          SensorID           `avro:"sensor"`
+	Backup          *SensorID          `avro:"backup"`
+	Payload         []byte             `avro:"payload"`
+	Raw             []byte             `avro:"raw"`
+	Nothing         struct{}           `avro:"nothing"`
+	Amount          *big.Rat           `avro:"amount"`
+	MeasuredAt      time.Time          `avro:"measuredAt"`
+	ReceivedAt      time.Time          `avro:"receivedAt"`
+	Offset          time.Duration      `avro:"offset"`
+	Latency         time.Duration      `avro:"latency"`
+	ProcessedAt     *time.Time         `avro:"processedAt"`
+	Batch           string             `avro:"batch"`
+	Interval        goavro.Duration    `avro:"interval"`
+	LocalMeasuredAt time.Time          `avro:"localMeasuredAt"`
+	LocalReceivedAt time.Time          `avro:"localReceivedAt"`
+	Labels          map[string]string  `avro:"labels"`
+	Limits          map[string]float64 `avro:"limits"`
+	Levels          []Level            `avro:"levels"`
+	MaybeLevels     []*Level           `avro:"maybeLevels"`
+	Peers           map[string]*Peer   `avro:"peers"`
+}
+
+func NewReading(buf []byte) (*Reading, []byte, error) {
+	return NewReadingAtDepth(buf, 0)
+}
+
+// NewReadingAtDepth is like NewReading, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewReadingAtDepth(buf []byte, depth int) (*Reading, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.reading", goavro.MaxDecodeDepth)
+	}
+	result := &Reading{}
+	newBuf := buf
+	var err error
+
+	if result.Sensor, newBuf, err = NewSensorID(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "sensor", err)
+	}
+
+	if result.Backup, newBuf, err = func(buf []byte) (*SensorID, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*SensorID, []byte, error) {
+				v, newBuf, err := NewSensorID(buf)
+				if err != nil {
+					return nil, buf, err
+				}
+				return &v, newBuf, nil
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "backup", err)
+	}
+
+	if result.Payload, newBuf, err = goavro.BytesNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "payload", err)
+	}
+
+	if result.Raw, newBuf, err = func(buf []byte) ([]byte, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.BytesNativeFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "raw", err)
+	}
+
+	if result.Nothing, newBuf, err = func(buf []byte) (struct{}, []byte, error) {
+		return struct{}{}, buf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "nothing", err)
+	}
+
+	if result.Amount, newBuf, err = func(buf []byte) (*big.Rat, []byte, error) {
+		return goavro.NativeFromBinaryDecimalFixed(buf, 8, 12, 3)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "amount", err)
+	}
+
+	if result.MeasuredAt, newBuf, err = goavro.NativeFromBinaryTimeStampMillis(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "measuredAt", err)
+	}
+
+	if result.ReceivedAt, newBuf, err = goavro.NativeFromBinaryTimeStampMicros(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "receivedAt", err)
+	}
+
+	if result.Offset, newBuf, err = goavro.NativeFromBinaryTimeMillis(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "offset", err)
+	}
+
+	if result.Latency, newBuf, err = goavro.NativeFromBinaryTimeMicros(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "latency", err)
+	}
+
+	if result.ProcessedAt, newBuf, err = func(buf []byte) (*time.Time, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*time.Time, []byte, error) {
+				v, newBuf, err := goavro.NativeFromBinaryTimeStampMicros(buf)
+				if err != nil {
+					return nil, buf, err
+				}
+				return &v, newBuf, nil
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "processedAt", err)
+	}
+
+	if result.Batch, newBuf, err = goavro.NativeFromBinaryUUID(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "batch", err)
+	}
+
+	if result.Interval, newBuf, err = goavro.NativeFromBinaryDuration(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "interval", err)
+	}
+
+	if result.LocalMeasuredAt, newBuf, err = goavro.NativeFromBinaryTimeStampMillis(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "localMeasuredAt", err)
+	}
+
+	if result.LocalReceivedAt, newBuf, err = goavro.NativeFromBinaryTimeStampMicros(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "localReceivedAt", err)
+	}
+
+	if result.Labels, newBuf, err = func(buf []byte) (map[string]string, []byte, error) {
+		var key string
+		var value string
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+		if err != nil {
+			return map[string]string{}, buf, err
+		}
+
+		mapValues := make(map[string]string, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if key, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+					return map[string]string{}, buf, fmt.Errorf("cannot decode binary map key: %s", err)
+				}
+				if _, ok := mapValues[key]; ok {
+					return map[string]string{}, buf, fmt.Errorf("cannot decode binary map: duplicate key: %q", key)
+				}
+				if value, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+					return map[string]string{}, buf, fmt.Errorf("cannot decode binary map value for key %q: %s", key, err)
+				}
+				mapValues[key] = value
+			}
+			blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+			if err != nil {
+				return map[string]string{}, buf, err
+			}
+
+		}
+		return mapValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "labels", err)
+	}
+
+	if result.Limits, newBuf, err = func(buf []byte) (map[string]float64, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (map[string]float64, []byte, error) {
+				var key string
+				var value float64
+				var err error
+				var blockCount int64
+				tmpBuf := buf
+
+				blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+				if err != nil {
+					return map[string]float64{}, buf, err
+				}
+
+				mapValues := make(map[string]float64, blockCount)
+
+				for blockCount != 0 {
+					// Decode 'blockCount' datum values
+					for i := int64(0); i < blockCount; i++ {
+						if key, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+							return map[string]float64{}, buf, fmt.Errorf("cannot decode binary map key: %s", err)
+						}
+						if _, ok := mapValues[key]; ok {
+							return map[string]float64{}, buf, fmt.Errorf("cannot decode binary map: duplicate key: %q", key)
+						}
+						if value, tmpBuf, err = goavro.DoubleNativeFromBinary(tmpBuf); err != nil {
+							return map[string]float64{}, buf, fmt.Errorf("cannot decode binary map value for key %q: %s", key, err)
+						}
+						mapValues[key] = value
+					}
+					blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+					if err != nil {
+						return map[string]float64{}, buf, err
+					}
+
+				}
+				return mapValues, tmpBuf, nil
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "limits", err)
+	}
+
+	if result.Levels, newBuf, err = func(buf []byte) ([]Level, []byte, error) {
+		var value Level
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+		if err != nil {
+			return []Level{}, buf, err
+		}
+
+		arrayValues := make([]Level, 0, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if value, tmpBuf, err = NewLevel(tmpBuf); err != nil {
+					return []Level{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				} else {
+					arrayValues = append(arrayValues, value)
+
+				}
+			}
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []Level{}, buf, err
+			}
+
+		}
+		return arrayValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "levels", err)
+	}
+
+	if result.MaybeLevels, newBuf, err = func(buf []byte) ([]*Level, []byte, error) {
+		var value *Level
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+		if err != nil {
+			return []*Level{}, buf, err
+		}
+
+		arrayValues := make([]*Level, 0, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if value, tmpBuf, err = func(buf []byte) (*Level, []byte, error) {
+					tmpBuf := buf
+					idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+					if err != nil {
+						return nil, buf, err
+					}
+					switch idx {
+					case 0:
+						// Null case, use empty value
+						return nil, tmpBuf, nil
+					case 1:
+						return func(buf []byte) (*Level, []byte, error) {
+							v, newBuf, err := NewLevel(buf)
+							if err != nil {
+								return nil, buf, err
+							}
+							return &v, newBuf, nil
+						}(tmpBuf)
+					default:
+						return nil, buf, fmt.Errorf("union index out of bounds")
+					}
+				}(tmpBuf); err != nil {
+					return []*Level{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				} else {
+					arrayValues = append(arrayValues, value)
+
+				}
+			}
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []*Level{}, buf, err
+			}
+
+		}
+		return arrayValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "maybeLevels", err)
+	}
+
+	if result.Peers, newBuf, err = func(buf []byte) (map[string]*Peer, []byte, error) {
+		var key string
+		var value *Peer
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+		if err != nil {
+			return map[string]*Peer{}, buf, err
+		}
+
+		mapValues := make(map[string]*Peer, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if key, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+					return map[string]*Peer{}, buf, fmt.Errorf("cannot decode binary map key: %s", err)
+				}
+				if _, ok := mapValues[key]; ok {
+					return map[string]*Peer{}, buf, fmt.Errorf("cannot decode binary map: duplicate key: %q", key)
+				}
+				if value, tmpBuf, err = func(buf []byte) (*Peer, []byte, error) {
+					return NewPeerAtDepth(buf, depth+1)
+				}(tmpBuf); err != nil {
+					return map[string]*Peer{}, buf, fmt.Errorf("cannot decode binary map value for key %q: %s", key, err)
+				}
+				mapValues[key] = value
+			}
+			blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+			if err != nil {
+				return map[string]*Peer{}, buf, err
+			}
+
+		}
+		return mapValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.reading", "peers", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Reading) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.reading")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = func(buf []byte, f SensorID) ([]byte, error) {
+		return f.MarshalAvro(buf)
+	}(newBuf, r.Sensor); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "sensor", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *SensorID) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, v *SensorID) ([]byte, error) {
+			return func(buf []byte, f SensorID) ([]byte, error) {
+				return f.MarshalAvro(buf)
+			}(buf, *v)
+		}(buf, v)
+	}(newBuf, r.Backup); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "backup", err)
+	}
+
+	if newBuf, err = goavro.BytesBinaryFromNative(newBuf, r.Payload); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "payload", err)
+	}
+
+	if newBuf, err = func(buf []byte, v []byte) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return goavro.BytesBinaryFromNative(buf, v)
+	}(newBuf, r.Raw); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "raw", err)
+	}
+
+	if newBuf, err = func(buf []byte, _ struct{}) ([]byte, error) {
+		return buf, nil
+	}(newBuf, r.Nothing); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "nothing", err)
+	}
+
+	if newBuf, err = func(buf []byte, r *big.Rat) ([]byte, error) {
+		return goavro.BinaryFromNativeDecimalFixed(buf, r, 8, 12, 3)
+	}(newBuf, r.Amount); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "amount", err)
+	}
+
+	if newBuf, err = goavro.BinaryFromNativeTimeStampMillis(newBuf, r.MeasuredAt); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "measuredAt", err)
+	}
+
+	if newBuf, err = goavro.BinaryFromNativeTimeStampMicros(newBuf, r.ReceivedAt); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "receivedAt", err)
+	}
+
+	if newBuf, err = goavro.BinaryFromNativeTimeMillis(newBuf, r.Offset); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "offset", err)
+	}
+
+	if newBuf, err = goavro.BinaryFromNativeTimeMicros(newBuf, r.Latency); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "latency", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *time.Time) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, v *time.Time) ([]byte, error) {
+			return goavro.BinaryFromNativeTimeStampMicros(buf, *v)
+		}(buf, v)
+	}(newBuf, r.ProcessedAt); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "processedAt", err)
+	}
+
+	if newBuf, err = goavro.BinaryFromNativeUUID(newBuf, r.Batch); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "batch", err)
+	}
+
+	if newBuf, err = goavro.BinaryFromNativeDuration(newBuf, r.Interval); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "interval", err)
+	}
+
+	if newBuf, err = goavro.BinaryFromNativeLocalTimeStampMillis(newBuf, r.LocalMeasuredAt); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "localMeasuredAt", err)
+	}
+
+	if newBuf, err = goavro.BinaryFromNativeLocalTimeStampMicros(newBuf, r.LocalReceivedAt); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "localReceivedAt", err)
+	}
+
+	if newBuf, err = func(buf []byte, values map[string]string) ([]byte, error) {
+		var err error
+		keyCount := int64(len(values))
+		var alreadyEncoded, remainingInBlock int64
+
+		for k, v := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = keyCount - alreadyEncoded
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			buf, _ = goavro.StringBinaryFromNative(buf, k)
+			if buf, err = goavro.StringBinaryFromNative(buf, v); err != nil {
+				return nil, fmt.Errorf("cannot encode binary map value for key %q: %v: %s", k, v, err)
+			}
+			remainingInBlock--
+			alreadyEncoded++
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append tailing 0 block count to signal end of Map
+	}(newBuf, r.Labels); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "labels", err)
+	}
+
+	if newBuf, err = func(buf []byte, v map[string]float64) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, values map[string]float64) ([]byte, error) {
+			var err error
+			keyCount := int64(len(values))
+			var alreadyEncoded, remainingInBlock int64
+
+			for k, v := range values {
+				if remainingInBlock == 0 { // start a new block
+					remainingInBlock = keyCount - alreadyEncoded
+					if remainingInBlock > goavro.MaxBlockCount {
+						// limit block count to MaxBlockCount
+						remainingInBlock = goavro.MaxBlockCount
+					}
+					buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+				}
+				buf, _ = goavro.StringBinaryFromNative(buf, k)
+				if buf, err = goavro.DoubleBinaryFromNative(buf, v); err != nil {
+					return nil, fmt.Errorf("cannot encode binary map value for key %q: %v: %s", k, v, err)
+				}
+				remainingInBlock--
+				alreadyEncoded++
+			}
+			return goavro.LongBinaryFromNative(buf, 0) // append tailing 0 block count to signal end of Map
+		}(buf, v)
+	}(newBuf, r.Limits); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "limits", err)
+	}
+
+	if newBuf, err = func(buf []byte, values []Level) ([]byte, error) {
+		var err error
+		var remainingInBlock int64
+
+		for i, value := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = int64(len(values) - i)
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			if buf, err = func(buf []byte, e Level) ([]byte, error) {
+				return e.MarshalAvro(buf)
+			}(buf, value); err != nil {
+				return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+			}
+			remainingInBlock--
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+	}(newBuf, r.Levels); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "levels", err)
+	}
+
+	if newBuf, err = func(buf []byte, values []*Level) ([]byte, error) {
+		var err error
+		var remainingInBlock int64
+
+		for i, value := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = int64(len(values) - i)
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			if buf, err = func(buf []byte, v *Level) ([]byte, error) {
+				if v == nil {
+					return goavro.LongBinaryFromNative(buf, 0)
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, 1)
+				return func(buf []byte, v *Level) ([]byte, error) {
+					return func(buf []byte, e Level) ([]byte, error) {
+						return e.MarshalAvro(buf)
+					}(buf, *v)
+				}(buf, v)
+			}(buf, value); err != nil {
+				return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+			}
+			remainingInBlock--
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+	}(newBuf, r.MaybeLevels); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "maybeLevels", err)
+	}
+
+	if newBuf, err = func(buf []byte, values map[string]*Peer) ([]byte, error) {
+		var err error
+		keyCount := int64(len(values))
+		var alreadyEncoded, remainingInBlock int64
+
+		for k, v := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = keyCount - alreadyEncoded
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			buf, _ = goavro.StringBinaryFromNative(buf, k)
+			if buf, err = func(buf []byte, r *Peer) ([]byte, error) {
+				return r.MarshalAvro(buf)
+			}(buf, v); err != nil {
+				return nil, fmt.Errorf("cannot encode binary map value for key %q: %v: %s", k, v, err)
+			}
+			remainingInBlock--
+			alreadyEncoded++
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append tailing 0 block count to signal end of Map
+	}(newBuf, r.Peers); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.reading", "peers", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewReadingWithDefaults returns a new Reading, with each field that has a default
+// value in the schema set to that value.
+func NewReadingWithDefaults() *Reading {
+	r := &Reading{}
+	var err error
+	const depth = 0
+	if r.Backup, _, err = func(buf []byte) (*SensorID, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*SensorID, []byte, error) {
+				v, newBuf, err := NewSensorID(buf)
+				if err != nil {
+					return nil, buf, err
+				}
+				return &v, newBuf, nil
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.reading", "backup", err))
+	}
+	if r.Raw, _, err = func(buf []byte) ([]byte, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.BytesNativeFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.reading", "raw", err))
+	}
+	if r.ProcessedAt, _, err = func(buf []byte) (*time.Time, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (*time.Time, []byte, error) {
+				v, newBuf, err := goavro.NativeFromBinaryTimeStampMicros(buf)
+				if err != nil {
+					return nil, buf, err
+				}
+				return &v, newBuf, nil
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.reading", "processedAt", err))
+	}
+	if r.Limits, _, err = func(buf []byte) (map[string]float64, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return func(buf []byte) (map[string]float64, []byte, error) {
+				var key string
+				var value float64
+				var err error
+				var blockCount int64
+				tmpBuf := buf
+
+				blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+				if err != nil {
+					return map[string]float64{}, buf, err
+				}
+
+				mapValues := make(map[string]float64, blockCount)
+
+				for blockCount != 0 {
+					// Decode 'blockCount' datum values
+					for i := int64(0); i < blockCount; i++ {
+						if key, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+							return map[string]float64{}, buf, fmt.Errorf("cannot decode binary map key: %s", err)
+						}
+						if _, ok := mapValues[key]; ok {
+							return map[string]float64{}, buf, fmt.Errorf("cannot decode binary map: duplicate key: %q", key)
+						}
+						if value, tmpBuf, err = goavro.DoubleNativeFromBinary(tmpBuf); err != nil {
+							return map[string]float64{}, buf, fmt.Errorf("cannot decode binary map value for key %q: %s", key, err)
+						}
+						mapValues[key] = value
+					}
+					blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+					if err != nil {
+						return map[string]float64{}, buf, err
+					}
+
+				}
+				return mapValues, tmpBuf, nil
+			}(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.reading", "limits", err))
+	}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Reading.
+func (r *Reading) Schema() string {
+	return `{"name":"com.example.gentest.reading","type":"record","fields":[{"name":"sensor","type":{"name":"com.example.gentest.sensorID","type":"fixed","size":4}},{"name":"backup","type":["null","com.example.gentest.sensorID"]},{"name":"payload","type":"bytes"},{"name":"raw","type":["null","bytes"]},{"name":"nothing","type":"null"},{"name":"amount","type":{"name":"com.example.gentest.amount","type":"fixed","size":8}},{"name":"measuredAt","type":"long"},{"name":"receivedAt","type":"long"},{"name":"offset","type":"int"},{"name":"latency","type":"long"},{"name":"processedAt","type":["null","long"]},{"name":"batch","type":"string"},{"name":"interval","type":{"name":"com.example.gentest.interval","type":"fixed","size":12}},{"name":"localMeasuredAt","type":"long"},{"name":"localReceivedAt","type":"long"},{"name":"labels","type":{"type":"map","values":"string"}},{"name":"limits","type":["null",{"type":"map","values":"double"}]},{"name":"levels","type":{"type":"array","items":{"name":"com.example.gentest.level","type":"enum","symbols":["LOW","MEDIUM","HIGH"]}}},{"name":"maybeLevels","type":{"type":"array","items":["null","com.example.gentest.level"]}},{"name":"peers","type":{"type":"map","values":{"name":"com.example.gentest.peer","type":"record","fields":[{"name":"id","type":"com.example.gentest.sensorID"},{"name":"level","type":"com.example.gentest.level"}]}}}]}`
+}
+
+var codecForReading struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Reading, which is built the first time
+// it is requested.
+func (r *Reading) Codec() (*goavro.Codec, error) {
+	codecForReading.once.Do(func() {
+		codecForReading.codec, codecForReading.err = goavro.NewCodec(`{"fields":[{"name":"sensor","type":{"name":"com.example.gentest.sensorID","size":4,"type":"fixed"}},{"default":null,"name":"backup","type":["null","com.example.gentest.sensorID"]},{"name":"payload","type":"bytes"},{"default":null,"name":"raw","type":["null","bytes"]},{"name":"nothing","type":"null"},{"name":"amount","type":{"logicalType":"decimal","name":"com.example.gentest.amount","precision":12,"scale":3,"size":8,"type":"fixed"}},{"name":"measuredAt","type":{"logicalType":"timestamp-millis","type":"long"}},{"name":"receivedAt","type":{"logicalType":"timestamp-micros","type":"long"}},{"name":"offset","type":{"logicalType":"time-millis","type":"int"}},{"name":"latency","type":{"logicalType":"time-micros","type":"long"}},{"default":null,"name":"processedAt","type":["null",{"logicalType":"timestamp-micros","type":"long"}]},{"name":"batch","type":{"logicalType":"uuid","type":"string"}},{"name":"interval","type":{"logicalType":"duration","name":"com.example.gentest.interval","size":12,"type":"fixed"}},{"name":"localMeasuredAt","type":{"logicalType":"local-timestamp-millis","type":"long"}},{"name":"localReceivedAt","type":{"logicalType":"local-timestamp-micros","type":"long"}},{"name":"labels","type":{"type":"map","values":"string"}},{"default":null,"name":"limits","type":["null",{"type":"map","values":"double"}]},{"name":"levels","type":{"items":{"name":"com.example.gentest.level","symbols":["LOW","MEDIUM","HIGH"],"type":"enum"},"type":"array"}},{"name":"maybeLevels","type":{"items":["null","com.example.gentest.level"],"type":"array"}},{"name":"peers","type":{"type":"map","values":{"fields":[{"name":"id","type":"com.example.gentest.sensorID"},{"name":"level","type":"com.example.gentest.level"}],"name":"com.example.gentest.peer","type":"record"}}}],"name":"com.example.gentest.reading","type":"record"}`)
+	})
+	return codecForReading.codec, codecForReading.err
+}
+
+// ReadingOCFReader reads Reading records from an Avro Object Container File.
+type ReadingOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewReadingOCFReader returns a reader of the Reading records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Reading,
+// or resolves to it once its names are fully qualified.
+func NewReadingOCFReader(ior io.Reader) (*ReadingOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Reading)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.reading", err)
+	}
+	return &ReadingOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *ReadingOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Reading record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *ReadingOCFReader) Read() (*Reading, error) {
+	var result *Reading
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewReading(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *ReadingOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *ReadingOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// ReadingOCFWriter appends Reading records to an Avro Object Container File.
+type ReadingOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewReadingOCFWriter returns a writer of Reading records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Reading
+// is used. It returns an error unless the schema of the file is the schema of
+// Reading, or resolves to it once its names are fully qualified.
+func NewReadingOCFWriter(config goavro.OCFConfig) (*ReadingOCFWriter, error) {
+	codec, err := (*Reading)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.reading", err)
+	}
+	return &ReadingOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Reading records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *ReadingOCFWriter) Append(values ...*Reading) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *ReadingOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
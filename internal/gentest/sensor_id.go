@@ -0,0 +1,46 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"sync"
+)
+
+type SensorID [4]byte
+
+func NewSensorID(buf []byte) (SensorID, []byte, error) {
+	var result SensorID
+	if buflen := len(buf); buflen < 4 {
+		return result, buf, fmt.Errorf("cannot decode binary fixed %q: schema size exceeds remaining buffer size: 4 > %d (short buffer)", "com.example.gentest.sensorID", buflen)
+	}
+	copy(result[:], buf)
+	return result, buf[4:], nil
+}
+
+func (f SensorID) MarshalAvro(buf []byte) ([]byte, error) {
+	return append(buf, f[:]...), nil
+}
+
+// Schema returns the canonical form of the schema for SensorID.
+func (SensorID) Schema() string {
+	return `{"name":"com.example.gentest.sensorID","type":"fixed","size":4}`
+}
+
+var codecForSensorID struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of SensorID, which is built the first time
+// it is requested.
+func (SensorID) Codec() (*goavro.Codec, error) {
+	codecForSensorID.once.Do(func() {
+		codecForSensorID.codec, codecForSensorID.err = goavro.NewCodec(`{"name":"com.example.gentest.sensorID","size":4,"type":"fixed"}`)
+	})
+	return codecForSensorID.codec, codecForSensorID.err
+}
@@ -0,0 +1,584 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"io"
+	"sync"
+)
+
+type Settings struct {
+	Retries   int32            `avro:"retries"`
+	TimeoutMs int64            `avro:"timeout_ms"`
+	Ratio     float64          `avro:"ratio"`
+	Enabled   bool             `avro:"enabled"`
+	Label     string           `avro:"label"`
+	Salt      []byte           `avro:"salt"`
+	Mode      Mode             `avro:"mode"`
+	Checksum  Checksum         `avro:"checksum"`
+	Hosts     []string         `avro:"hosts"`
+	Weights   map[string]int32 `avro:"weights"`
+	Owner     *string          `avro:"owner"`
+	Fallback  *string          `avro:"fallback"`
+	Limits    *Limits          `avro:"limits"`
+	Required  string           `avro:"required"`
+}
+
+func NewSettings(buf []byte) (*Settings, []byte, error) {
+	return NewSettingsAtDepth(buf, 0)
+}
+
+// NewSettingsAtDepth is like NewSettings, for a record nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewSettingsAtDepth(buf []byte, depth int) (*Settings, []byte, error) {
+	if depth >= goavro.MaxDecodeDepth {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q: nested deeper than MaxDecodeDepth: %d", "com.example.gentest.settings", goavro.MaxDecodeDepth)
+	}
+	result := &Settings{}
+	newBuf := buf
+	var err error
+
+	if result.Retries, newBuf, err = goavro.IntNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "retries", err)
+	}
+
+	if result.TimeoutMs, newBuf, err = goavro.LongNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "timeout_ms", err)
+	}
+
+	if result.Ratio, newBuf, err = goavro.DoubleNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "ratio", err)
+	}
+
+	if result.Enabled, newBuf, err = goavro.BoolNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "enabled", err)
+	}
+
+	if result.Label, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "label", err)
+	}
+
+	if result.Salt, newBuf, err = goavro.BytesNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "salt", err)
+	}
+
+	if result.Mode, newBuf, err = NewMode(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "mode", err)
+	}
+
+	if result.Checksum, newBuf, err = NewChecksum(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "checksum", err)
+	}
+
+	if result.Hosts, newBuf, err = func(buf []byte) ([]string, []byte, error) {
+		var value string
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+		if err != nil {
+			return []string{}, buf, err
+		}
+
+		arrayValues := make([]string, 0, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if value, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+					return []string{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				} else {
+					arrayValues = append(arrayValues, value)
+
+				}
+			}
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []string{}, buf, err
+			}
+
+		}
+		return arrayValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "hosts", err)
+	}
+
+	if result.Weights, newBuf, err = func(buf []byte) (map[string]int32, []byte, error) {
+		var key string
+		var value int32
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+		if err != nil {
+			return map[string]int32{}, buf, err
+		}
+
+		mapValues := make(map[string]int32, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if key, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+					return map[string]int32{}, buf, fmt.Errorf("cannot decode binary map key: %s", err)
+				}
+				if _, ok := mapValues[key]; ok {
+					return map[string]int32{}, buf, fmt.Errorf("cannot decode binary map: duplicate key: %q", key)
+				}
+				if value, tmpBuf, err = goavro.IntNativeFromBinary(tmpBuf); err != nil {
+					return map[string]int32{}, buf, fmt.Errorf("cannot decode binary map value for key %q: %s", key, err)
+				}
+				mapValues[key] = value
+			}
+			blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+			if err != nil {
+				return map[string]int32{}, buf, err
+			}
+
+		}
+		return mapValues, tmpBuf, nil
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "weights", err)
+	}
+
+	if result.Owner, newBuf, err = func(buf []byte) (*string, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.StringNativePtrFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "owner", err)
+	}
+
+	if result.Fallback, newBuf, err = func(buf []byte) (*string, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			return goavro.StringNativePtrFromBinary(tmpBuf)
+		case 1:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "fallback", err)
+	}
+
+	if result.Limits, newBuf, err = func(buf []byte) (*Limits, []byte, error) {
+		return NewLimitsAtDepth(buf, depth+1)
+	}(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "limits", err)
+	}
+
+	if result.Required, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+		return nil, buf, fmt.Errorf("cannot decode binary record %q field %q: %s", "com.example.gentest.settings", "required", err)
+	}
+
+	return result, newBuf, nil
+}
+
+func (r *Settings) MarshalAvro(buf []byte) ([]byte, error) {
+	if r == nil {
+		return buf, fmt.Errorf("cannot encode binary record %q: received nil", "com.example.gentest.settings")
+	}
+	newBuf := buf
+	var err error
+
+	if newBuf, err = goavro.IntBinaryFromNative(newBuf, r.Retries); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "retries", err)
+	}
+
+	if newBuf, err = goavro.LongBinaryFromNative(newBuf, r.TimeoutMs); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "timeout_ms", err)
+	}
+
+	if newBuf, err = goavro.DoubleBinaryFromNative(newBuf, r.Ratio); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "ratio", err)
+	}
+
+	if newBuf, err = goavro.BoolBinaryFromNative(newBuf, r.Enabled); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "enabled", err)
+	}
+
+	if newBuf, err = goavro.StringBinaryFromNative(newBuf, r.Label); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "label", err)
+	}
+
+	if newBuf, err = goavro.BytesBinaryFromNative(newBuf, r.Salt); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "salt", err)
+	}
+
+	if newBuf, err = func(buf []byte, e Mode) ([]byte, error) {
+		return e.MarshalAvro(buf)
+	}(newBuf, r.Mode); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "mode", err)
+	}
+
+	if newBuf, err = func(buf []byte, f Checksum) ([]byte, error) {
+		return f.MarshalAvro(buf)
+	}(newBuf, r.Checksum); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "checksum", err)
+	}
+
+	if newBuf, err = func(buf []byte, values []string) ([]byte, error) {
+		var err error
+		var remainingInBlock int64
+
+		for i, value := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = int64(len(values) - i)
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			if buf, err = goavro.StringBinaryFromNative(buf, value); err != nil {
+				return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+			}
+			remainingInBlock--
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+	}(newBuf, r.Hosts); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "hosts", err)
+	}
+
+	if newBuf, err = func(buf []byte, values map[string]int32) ([]byte, error) {
+		var err error
+		keyCount := int64(len(values))
+		var alreadyEncoded, remainingInBlock int64
+
+		for k, v := range values {
+			if remainingInBlock == 0 { // start a new block
+				remainingInBlock = keyCount - alreadyEncoded
+				if remainingInBlock > goavro.MaxBlockCount {
+					// limit block count to MaxBlockCount
+					remainingInBlock = goavro.MaxBlockCount
+				}
+				buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+			}
+			buf, _ = goavro.StringBinaryFromNative(buf, k)
+			if buf, err = goavro.IntBinaryFromNative(buf, v); err != nil {
+				return nil, fmt.Errorf("cannot encode binary map value for key %q: %v: %s", k, v, err)
+			}
+			remainingInBlock--
+			alreadyEncoded++
+		}
+		return goavro.LongBinaryFromNative(buf, 0) // append tailing 0 block count to signal end of Map
+	}(newBuf, r.Weights); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "weights", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *string) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 0)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 1)
+		return func(buf []byte, v *string) ([]byte, error) {
+			return goavro.StringBinaryFromNative(buf, *v)
+		}(buf, v)
+	}(newBuf, r.Owner); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "owner", err)
+	}
+
+	if newBuf, err = func(buf []byte, v *string) ([]byte, error) {
+		if v == nil {
+			return goavro.LongBinaryFromNative(buf, 1)
+		}
+		buf, _ = goavro.LongBinaryFromNative(buf, 0)
+		return func(buf []byte, v *string) ([]byte, error) {
+			return goavro.StringBinaryFromNative(buf, *v)
+		}(buf, v)
+	}(newBuf, r.Fallback); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "fallback", err)
+	}
+
+	if newBuf, err = func(buf []byte, r *Limits) ([]byte, error) {
+		return r.MarshalAvro(buf)
+	}(newBuf, r.Limits); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "limits", err)
+	}
+
+	if newBuf, err = goavro.StringBinaryFromNative(newBuf, r.Required); err != nil {
+		return buf, fmt.Errorf("cannot encode binary record %q field %q: value does not match its schema: %s", "com.example.gentest.settings", "required", err)
+	}
+
+	return newBuf, nil
+}
+
+// NewSettingsWithDefaults returns a new Settings, with each field that has a default
+// value in the schema set to that value.
+func NewSettingsWithDefaults() *Settings {
+	r := &Settings{}
+	var err error
+	const depth = 0
+	if r.Retries, _, err = goavro.IntNativeFromBinary([]byte{0x6}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "retries", err))
+	}
+	if r.TimeoutMs, _, err = goavro.LongNativeFromBinary([]byte{0xb8, 0x17}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "timeout_ms", err))
+	}
+	if r.Ratio, _, err = goavro.DoubleNativeFromBinary([]byte{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0xd0, 0x3f}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "ratio", err))
+	}
+	if r.Enabled, _, err = goavro.BoolNativeFromBinary([]byte{0x1}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "enabled", err))
+	}
+	if r.Label, _, err = goavro.StringNativeFromBinary([]byte{0x8, 0x6e, 0x6f, 0x6e, 0x65}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "label", err))
+	}
+	if r.Salt, _, err = goavro.BytesNativeFromBinary([]byte{0x6, 0x1, 0xc3, 0xbf}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "salt", err))
+	}
+	if r.Mode, _, err = NewMode([]byte{0x4}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "mode", err))
+	}
+	if r.Checksum, _, err = NewChecksum([]byte{0x61, 0x62}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "checksum", err))
+	}
+	if r.Hosts, _, err = func(buf []byte) ([]string, []byte, error) {
+		var value string
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+		if err != nil {
+			return []string{}, buf, err
+		}
+
+		arrayValues := make([]string, 0, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if value, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+					return []string{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+				} else {
+					arrayValues = append(arrayValues, value)
+
+				}
+			}
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []string{}, buf, err
+			}
+
+		}
+		return arrayValues, tmpBuf, nil
+	}([]byte{0x4, 0x2, 0x61, 0x2, 0x62, 0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "hosts", err))
+	}
+	if r.Weights, _, err = func(buf []byte) (map[string]int32, []byte, error) {
+		var key string
+		var value int32
+		var err error
+		var blockCount int64
+		tmpBuf := buf
+
+		blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+		if err != nil {
+			return map[string]int32{}, buf, err
+		}
+
+		mapValues := make(map[string]int32, blockCount)
+
+		for blockCount != 0 {
+			// Decode 'blockCount' datum values
+			for i := int64(0); i < blockCount; i++ {
+				if key, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+					return map[string]int32{}, buf, fmt.Errorf("cannot decode binary map key: %s", err)
+				}
+				if _, ok := mapValues[key]; ok {
+					return map[string]int32{}, buf, fmt.Errorf("cannot decode binary map: duplicate key: %q", key)
+				}
+				if value, tmpBuf, err = goavro.IntNativeFromBinary(tmpBuf); err != nil {
+					return map[string]int32{}, buf, fmt.Errorf("cannot decode binary map value for key %q: %s", key, err)
+				}
+				mapValues[key] = value
+			}
+			blockCount, tmpBuf, err = goavro.DecodeMapBlockCount(tmpBuf)
+			if err != nil {
+				return map[string]int32{}, buf, err
+			}
+
+		}
+		return mapValues, tmpBuf, nil
+	}([]byte{0x2, 0x2, 0x78, 0x2, 0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "weights", err))
+	}
+	if r.Owner, _, err = func(buf []byte) (*string, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		case 1:
+			return goavro.StringNativePtrFromBinary(tmpBuf)
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "owner", err))
+	}
+	if r.Fallback, _, err = func(buf []byte) (*string, []byte, error) {
+		tmpBuf := buf
+		idx, tmpBuf, err := goavro.LongNativeFromBinary(tmpBuf)
+		if err != nil {
+			return nil, buf, err
+		}
+		switch idx {
+		case 0:
+			return goavro.StringNativePtrFromBinary(tmpBuf)
+		case 1:
+			// Null case, use empty value
+			return nil, tmpBuf, nil
+		default:
+			return nil, buf, fmt.Errorf("union index out of bounds")
+		}
+	}([]byte{0x0, 0xe, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "fallback", err))
+	}
+	if r.Limits, _, err = func(buf []byte) (*Limits, []byte, error) {
+		return NewLimitsAtDepth(buf, depth+1)
+	}([]byte{0x28}); err != nil {
+		panic(fmt.Sprintf("cannot decode default value of record %q field %q: %s", "com.example.gentest.settings", "limits", err))
+	}
+	return r
+}
+
+// Schema returns the canonical form of the schema for Settings.
+func (r *Settings) Schema() string {
+	return `{"name":"com.example.gentest.settings","type":"record","fields":[{"name":"retries","type":"int"},{"name":"timeout_ms","type":"long"},{"name":"ratio","type":"double"},{"name":"enabled","type":"boolean"},{"name":"label","type":"string"},{"name":"salt","type":"bytes"},{"name":"mode","type":{"name":"com.example.gentest.mode","type":"enum","symbols":["OFF","ON","AUTO"]}},{"name":"checksum","type":{"name":"com.example.gentest.checksum","type":"fixed","size":2}},{"name":"hosts","type":{"type":"array","items":"string"}},{"name":"weights","type":{"type":"map","values":"int"}},{"name":"owner","type":["null","string"]},{"name":"fallback","type":["string","null"]},{"name":"limits","type":{"name":"com.example.gentest.limits","type":"record","fields":[{"name":"max","type":"int"}]}},{"name":"required","type":"string"}]}`
+}
+
+var codecForSettings struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Settings, which is built the first time
+// it is requested.
+func (r *Settings) Codec() (*goavro.Codec, error) {
+	codecForSettings.once.Do(func() {
+		codecForSettings.codec, codecForSettings.err = goavro.NewCodec(`{"fields":[{"default":3,"name":"retries","type":"int"},{"default":1500,"name":"timeout_ms","type":"long"},{"default":0.25,"name":"ratio","type":"double"},{"default":true,"name":"enabled","type":"boolean"},{"default":"none","name":"label","type":"string"},{"default":"\u0001ÿ","name":"salt","type":"bytes"},{"default":"AUTO","name":"mode","type":{"default":"OFF","name":"com.example.gentest.mode","symbols":["OFF","ON","AUTO"],"type":"enum"}},{"default":"ab","name":"checksum","type":{"name":"com.example.gentest.checksum","size":2,"type":"fixed"}},{"default":["a","b"],"name":"hosts","type":{"items":"string","type":"array"}},{"default":{"x":1},"name":"weights","type":{"type":"map","values":"int"}},{"default":null,"name":"owner","type":["null","string"]},{"default":"primary","name":"fallback","type":["string","null"]},{"default":{"max":20},"name":"limits","type":{"fields":[{"default":10,"name":"max","type":"int"}],"name":"com.example.gentest.limits","type":"record"}},{"name":"required","type":"string"}],"name":"com.example.gentest.settings","type":"record"}`)
+	})
+	return codecForSettings.codec, codecForSettings.err
+}
+
+// SettingsOCFReader reads Settings records from an Avro Object Container File.
+type SettingsOCFReader struct {
+	ocfr *goavro.OCFReader
+}
+
+// NewSettingsOCFReader returns a reader of the Settings records of the OCF read from
+// ior. It returns an error unless the schema of the file is the schema of Settings,
+// or resolves to it once its names are fully qualified.
+func NewSettingsOCFReader(ior io.Reader) (*SettingsOCFReader, error) {
+	ocfr, err := goavro.NewOCFReader(ior)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := (*Settings)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfr.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot read %q records from OCF: %s", "com.example.gentest.settings", err)
+	}
+	return &SettingsOCFReader{ocfr: ocfr}, nil
+}
+
+// Scan returns true when there is at least one more record to be read, and
+// false when there are no more records or an error occurred.
+func (r *SettingsOCFReader) Scan() bool {
+	return r.ocfr.Scan()
+}
+
+// Read decodes and returns the next Settings record of the OCF. It ought to be
+// called once after each successful call to Scan.
+func (r *SettingsOCFReader) Read() (*Settings, error) {
+	var result *Settings
+	err := r.ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+		var err error
+		result, buf, err = NewSettings(buf)
+		return buf, err
+	})
+	return result, err
+}
+
+// Err returns the last error encountered while reading the OCF.
+func (r *SettingsOCFReader) Err() error {
+	return r.ocfr.Err()
+}
+
+// OCFReader returns the underlying OCFReader.
+func (r *SettingsOCFReader) OCFReader() *goavro.OCFReader {
+	return r.ocfr
+}
+
+// SettingsOCFWriter appends Settings records to an Avro Object Container File.
+type SettingsOCFWriter struct {
+	ocfw *goavro.OCFWriter
+}
+
+// NewSettingsOCFWriter returns a writer of Settings records to the OCF specified by
+// config. When config specifies neither a Codec nor a Schema, the codec of Settings
+// is used. It returns an error unless the schema of the file is the schema of
+// Settings, or resolves to it once its names are fully qualified.
+func NewSettingsOCFWriter(config goavro.OCFConfig) (*SettingsOCFWriter, error) {
+	codec, err := (*Settings)(nil).Codec()
+	if err != nil {
+		return nil, err
+	}
+	if config.Codec == nil && config.Schema == "" {
+		config.Codec = codec
+	}
+	ocfw, err := goavro.NewOCFWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	if err = codec.CheckSchema(ocfw.Codec()); err != nil {
+		return nil, fmt.Errorf("cannot write %q records to OCF: %s", "com.example.gentest.settings", err)
+	}
+	return &SettingsOCFWriter{ocfw: ocfw}, nil
+}
+
+// Append encodes the Settings records and appends them to the OCF, as a single
+// block unless there are more than goavro.MaxBlockCount records.
+func (w *SettingsOCFWriter) Append(values ...*Settings) error {
+	return w.ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return values[i].MarshalAvro(buf)
+	})
+}
+
+// OCFWriter returns the underlying OCFWriter.
+func (w *SettingsOCFWriter) OCFWriter() *goavro.OCFWriter {
+	return w.ocfw
+}
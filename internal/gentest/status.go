@@ -0,0 +1,115 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"sync"
+)
+
+type Status int
+
+const (
+	StatusPENDING Status = iota
+	StatusSHIPPED
+	StatusDELIVERED
+)
+
+var symbolsForStatus = [...]string{"PENDING", "SHIPPED", "DELIVERED"}
+
+func NewStatus(buf []byte) (Status, []byte, error) {
+	index, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return 0, buf, fmt.Errorf("cannot decode binary enum %q index: %s", "com.example.gentest.status", err)
+	}
+	if index < 0 || index >= 3 {
+		return 0, buf, fmt.Errorf("cannot decode binary enum %q: index ought to be between 0 and 2; read index: %d", "com.example.gentest.status", index)
+	}
+	return Status(index), newBuf, nil
+}
+
+func (e Status) MarshalAvro(buf []byte) ([]byte, error) {
+	if e < 0 || e >= 3 {
+		return buf, fmt.Errorf("cannot encode binary enum %q: index ought to be between 0 and 2; received: %d", "com.example.gentest.status", e)
+	}
+	return goavro.IntEnumBinaryFromNative(buf, int(e))
+}
+
+// String returns the symbol of the Status, or its number when it is not one of
+// the symbols.
+func (e Status) String() string {
+	if e < 0 || e >= 3 {
+		return fmt.Sprintf("Status(%d)", int(e))
+	}
+	return symbolsForStatus[e]
+}
+
+// ParseStatus returns the Status with the specified symbol.
+func ParseStatus(symbol string) (Status, error) {
+	for i, s := range symbolsForStatus {
+		if s == symbol {
+			return Status(i), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot parse enum %q: value ought to be member of symbols: %v; %q", "com.example.gentest.status", symbolsForStatus, symbol)
+}
+
+// MarshalText returns the symbol of the enum.
+func (e Status) MarshalText() ([]byte, error) {
+	if e < 0 || e >= 3 {
+		return nil, fmt.Errorf("cannot encode textual enum %q: index ought to be between 0 and 2; received: %d", "com.example.gentest.status", e)
+	}
+	return []byte(symbolsForStatus[e]), nil
+}
+
+// UnmarshalText sets the enum to the value with the symbol in text.
+func (e *Status) UnmarshalText(text []byte) error {
+	value, err := ParseStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*e = value
+	return nil
+}
+
+// MarshalJSON returns the symbol of the enum as a JSON string.
+func (e Status) MarshalJSON() ([]byte, error) {
+	text, err := e.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON sets the enum to the value with the symbol in the JSON string.
+func (e *Status) UnmarshalJSON(data []byte) error {
+	var symbol string
+	if err := json.Unmarshal(data, &symbol); err != nil {
+		return fmt.Errorf("cannot decode textual enum %q: %s", "com.example.gentest.status", err)
+	}
+	return e.UnmarshalText([]byte(symbol))
+}
+
+// Schema returns the canonical form of the schema for Status.
+func (Status) Schema() string {
+	return `{"name":"com.example.gentest.status","type":"enum","symbols":["PENDING","SHIPPED","DELIVERED"]}`
+}
+
+var codecForStatus struct {
+	once  sync.Once
+	codec *goavro.Codec
+	err   error
+}
+
+// Codec returns the codec for the schema of Status, which is built the first time
+// it is requested.
+func (Status) Codec() (*goavro.Codec, error) {
+	codecForStatus.once.Do(func() {
+		codecForStatus.codec, codecForStatus.err = goavro.NewCodec(`{"name":"com.example.gentest.status","symbols":["PENDING","SHIPPED","DELIVERED"],"type":"enum"}`)
+	})
+	return codecForStatus.codec, codecForStatus.err
+}
@@ -0,0 +1,163 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+)
+
+// UnionBooleanDoubleArray holds exactly one of the values allowed by the union [boolean double array].
+// Its zero value holds the zero value of the first member of the union.
+type UnionBooleanDoubleArray struct {
+	index        int
+	valueBoolean bool
+	valueDouble  float64
+	valueArray   []string
+}
+
+// Index returns the index of the union member held by the union.
+func (u UnionBooleanDoubleArray) Index() int {
+	return u.index
+}
+
+// AsBoolean returns the boolean value held by the union, and true when the union
+// holds a boolean value.
+func (u UnionBooleanDoubleArray) AsBoolean() (bool, bool) {
+	return u.valueBoolean, u.index == 0
+}
+
+// SetBoolean sets the union to hold the specified boolean value.
+func (u *UnionBooleanDoubleArray) SetBoolean(v bool) {
+	*u = UnionBooleanDoubleArray{index: 0, valueBoolean: v}
+}
+
+// AsDouble returns the double value held by the union, and true when the union
+// holds a double value.
+func (u UnionBooleanDoubleArray) AsDouble() (float64, bool) {
+	return u.valueDouble, u.index == 1
+}
+
+// SetDouble sets the union to hold the specified double value.
+func (u *UnionBooleanDoubleArray) SetDouble(v float64) {
+	*u = UnionBooleanDoubleArray{index: 1, valueDouble: v}
+}
+
+// AsArray returns the array value held by the union, and true when the union
+// holds a array value.
+func (u UnionBooleanDoubleArray) AsArray() ([]string, bool) {
+	return u.valueArray, u.index == 2
+}
+
+// SetArray sets the union to hold the specified array value.
+func (u *UnionBooleanDoubleArray) SetArray(v []string) {
+	*u = UnionBooleanDoubleArray{index: 2, valueArray: v}
+}
+
+func NewUnionBooleanDoubleArray(buf []byte) (UnionBooleanDoubleArray, []byte, error) {
+	return NewUnionBooleanDoubleArrayAtDepth(buf, 0)
+}
+
+// NewUnionBooleanDoubleArrayAtDepth is like NewUnionBooleanDoubleArray, for a union nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUnionBooleanDoubleArrayAtDepth(buf []byte, depth int) (UnionBooleanDoubleArray, []byte, error) {
+	var result UnionBooleanDoubleArray
+	idx, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return result, buf, err
+	}
+	switch idx {
+	case 0:
+		if result.valueBoolean, newBuf, err = goavro.BoolNativeFromBinary(newBuf); err != nil {
+			return UnionBooleanDoubleArray{}, buf, fmt.Errorf("cannot decode binary union item 1: %s", err)
+		}
+	case 1:
+		if result.valueDouble, newBuf, err = goavro.DoubleNativeFromBinary(newBuf); err != nil {
+			return UnionBooleanDoubleArray{}, buf, fmt.Errorf("cannot decode binary union item 2: %s", err)
+		}
+	case 2:
+		if result.valueArray, newBuf, err = func(buf []byte) ([]string, []byte, error) {
+			var value string
+			var err error
+			var blockCount int64
+			tmpBuf := buf
+
+			blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+			if err != nil {
+				return []string{}, buf, err
+			}
+
+			arrayValues := make([]string, 0, blockCount)
+
+			for blockCount != 0 {
+				// Decode 'blockCount' datum values
+				for i := int64(0); i < blockCount; i++ {
+					if value, tmpBuf, err = goavro.StringNativeFromBinary(tmpBuf); err != nil {
+						return []string{}, buf, fmt.Errorf("cannot decode binary array item %d: %s", i+1, err)
+					} else {
+						arrayValues = append(arrayValues, value)
+
+					}
+				}
+				blockCount, tmpBuf, err = goavro.DecodeBlockCount(tmpBuf)
+				if err != nil {
+					return []string{}, buf, err
+				}
+
+			}
+			return arrayValues, tmpBuf, nil
+		}(newBuf); err != nil {
+			return UnionBooleanDoubleArray{}, buf, fmt.Errorf("cannot decode binary union item 3: %s", err)
+		}
+	default:
+		return result, buf, fmt.Errorf("cannot decode binary union: index ought to be between 0 and 2; read index: %d", idx)
+	}
+	result.index = int(idx)
+	return result, newBuf, nil
+}
+
+func (u UnionBooleanDoubleArray) MarshalAvro(buf []byte) ([]byte, error) {
+	var err error
+	newBuf := buf
+	switch u.index {
+	case 0:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 0)
+		if newBuf, err = goavro.BoolBinaryFromNative(newBuf, u.valueBoolean); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 1: %s", err)
+		}
+	case 1:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 1)
+		if newBuf, err = goavro.DoubleBinaryFromNative(newBuf, u.valueDouble); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 2: %s", err)
+		}
+	case 2:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 2)
+		if newBuf, err = func(buf []byte, values []string) ([]byte, error) {
+			var err error
+			var remainingInBlock int64
+
+			for i, value := range values {
+				if remainingInBlock == 0 { // start a new block
+					remainingInBlock = int64(len(values) - i)
+					if remainingInBlock > goavro.MaxBlockCount {
+						// limit block count to MaxBlockCount
+						remainingInBlock = goavro.MaxBlockCount
+					}
+					buf, _ = goavro.LongBinaryFromNative(buf, remainingInBlock)
+				}
+				if buf, err = goavro.StringBinaryFromNative(buf, value); err != nil {
+					return nil, fmt.Errorf("cannot encode binary array item %d: %v: %s", i+1, value, err)
+				}
+				remainingInBlock--
+			}
+			return goavro.LongBinaryFromNative(buf, 0) // append trailing 0 block count to signal end of Array
+		}(newBuf, u.valueArray); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 3: %s", err)
+		}
+	default:
+		return buf, fmt.Errorf("cannot encode binary union: index ought to be between 0 and 2; received: %d", u.index)
+	}
+	return newBuf, nil
+}
@@ -0,0 +1,115 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+)
+
+// UnionDeviceUserNull holds exactly one of the values allowed by the union [com.example.gentest.device com.example.gentest.user null].
+// Its zero value holds the zero value of the first member of the union.
+type UnionDeviceUserNull struct {
+	index       int
+	valueDevice *Device
+	valueUser   *User
+}
+
+// Index returns the index of the union member held by the union.
+func (u UnionDeviceUserNull) Index() int {
+	return u.index
+}
+
+// AsDevice returns the com.example.gentest.device value held by the union, and true when the union
+// holds a com.example.gentest.device value.
+func (u UnionDeviceUserNull) AsDevice() (*Device, bool) {
+	return u.valueDevice, u.index == 0
+}
+
+// SetDevice sets the union to hold the specified com.example.gentest.device value.
+func (u *UnionDeviceUserNull) SetDevice(v *Device) {
+	*u = UnionDeviceUserNull{index: 0, valueDevice: v}
+}
+
+// AsUser returns the com.example.gentest.user value held by the union, and true when the union
+// holds a com.example.gentest.user value.
+func (u UnionDeviceUserNull) AsUser() (*User, bool) {
+	return u.valueUser, u.index == 1
+}
+
+// SetUser sets the union to hold the specified com.example.gentest.user value.
+func (u *UnionDeviceUserNull) SetUser(v *User) {
+	*u = UnionDeviceUserNull{index: 1, valueUser: v}
+}
+
+// IsNull returns true when the union holds null.
+func (u UnionDeviceUserNull) IsNull() bool {
+	return u.index == 2
+}
+
+// SetNull sets the union to hold null.
+func (u *UnionDeviceUserNull) SetNull() {
+	*u = UnionDeviceUserNull{index: 2}
+}
+
+func NewUnionDeviceUserNull(buf []byte) (UnionDeviceUserNull, []byte, error) {
+	return NewUnionDeviceUserNullAtDepth(buf, 0)
+}
+
+// NewUnionDeviceUserNullAtDepth is like NewUnionDeviceUserNull, for a union nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUnionDeviceUserNullAtDepth(buf []byte, depth int) (UnionDeviceUserNull, []byte, error) {
+	var result UnionDeviceUserNull
+	idx, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return result, buf, err
+	}
+	switch idx {
+	case 0:
+		if result.valueDevice, newBuf, err = func(buf []byte) (*Device, []byte, error) {
+			return NewDeviceAtDepth(buf, depth+1)
+		}(newBuf); err != nil {
+			return UnionDeviceUserNull{}, buf, fmt.Errorf("cannot decode binary union item 1: %s", err)
+		}
+	case 1:
+		if result.valueUser, newBuf, err = func(buf []byte) (*User, []byte, error) {
+			return NewUserAtDepth(buf, depth+1)
+		}(newBuf); err != nil {
+			return UnionDeviceUserNull{}, buf, fmt.Errorf("cannot decode binary union item 2: %s", err)
+		}
+	case 2:
+	// Null case, nothing to decode
+	default:
+		return result, buf, fmt.Errorf("cannot decode binary union: index ought to be between 0 and 2; read index: %d", idx)
+	}
+	result.index = int(idx)
+	return result, newBuf, nil
+}
+
+func (u UnionDeviceUserNull) MarshalAvro(buf []byte) ([]byte, error) {
+	var err error
+	newBuf := buf
+	switch u.index {
+	case 0:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 0)
+		if newBuf, err = func(buf []byte, r *Device) ([]byte, error) {
+			return r.MarshalAvro(buf)
+		}(newBuf, u.valueDevice); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 1: %s", err)
+		}
+	case 1:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 1)
+		if newBuf, err = func(buf []byte, r *User) ([]byte, error) {
+			return r.MarshalAvro(buf)
+		}(newBuf, u.valueUser); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 2: %s", err)
+		}
+	case 2:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 2)
+	default:
+		return buf, fmt.Errorf("cannot encode binary union: index ought to be between 0 and 2; received: %d", u.index)
+	}
+	return newBuf, nil
+}
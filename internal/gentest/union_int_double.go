@@ -0,0 +1,93 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+)
+
+// UnionIntDouble holds exactly one of the values allowed by the union [int double].
+// Its zero value holds the zero value of the first member of the union.
+type UnionIntDouble struct {
+	index       int
+	valueInt    int32
+	valueDouble float64
+}
+
+// Index returns the index of the union member held by the union.
+func (u UnionIntDouble) Index() int {
+	return u.index
+}
+
+// AsInt returns the int value held by the union, and true when the union
+// holds a int value.
+func (u UnionIntDouble) AsInt() (int32, bool) {
+	return u.valueInt, u.index == 0
+}
+
+// SetInt sets the union to hold the specified int value.
+func (u *UnionIntDouble) SetInt(v int32) {
+	*u = UnionIntDouble{index: 0, valueInt: v}
+}
+
+// AsDouble returns the double value held by the union, and true when the union
+// holds a double value.
+func (u UnionIntDouble) AsDouble() (float64, bool) {
+	return u.valueDouble, u.index == 1
+}
+
+// SetDouble sets the union to hold the specified double value.
+func (u *UnionIntDouble) SetDouble(v float64) {
+	*u = UnionIntDouble{index: 1, valueDouble: v}
+}
+
+func NewUnionIntDouble(buf []byte) (UnionIntDouble, []byte, error) {
+	return NewUnionIntDoubleAtDepth(buf, 0)
+}
+
+// NewUnionIntDoubleAtDepth is like NewUnionIntDouble, for a union nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUnionIntDoubleAtDepth(buf []byte, depth int) (UnionIntDouble, []byte, error) {
+	var result UnionIntDouble
+	idx, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return result, buf, err
+	}
+	switch idx {
+	case 0:
+		if result.valueInt, newBuf, err = goavro.IntNativeFromBinary(newBuf); err != nil {
+			return UnionIntDouble{}, buf, fmt.Errorf("cannot decode binary union item 1: %s", err)
+		}
+	case 1:
+		if result.valueDouble, newBuf, err = goavro.DoubleNativeFromBinary(newBuf); err != nil {
+			return UnionIntDouble{}, buf, fmt.Errorf("cannot decode binary union item 2: %s", err)
+		}
+	default:
+		return result, buf, fmt.Errorf("cannot decode binary union: index ought to be between 0 and 1; read index: %d", idx)
+	}
+	result.index = int(idx)
+	return result, newBuf, nil
+}
+
+func (u UnionIntDouble) MarshalAvro(buf []byte) ([]byte, error) {
+	var err error
+	newBuf := buf
+	switch u.index {
+	case 0:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 0)
+		if newBuf, err = goavro.IntBinaryFromNative(newBuf, u.valueInt); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 1: %s", err)
+		}
+	case 1:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 1)
+		if newBuf, err = goavro.DoubleBinaryFromNative(newBuf, u.valueDouble); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 2: %s", err)
+		}
+	default:
+		return buf, fmt.Errorf("cannot encode binary union: index ought to be between 0 and 1; received: %d", u.index)
+	}
+	return newBuf, nil
+}
@@ -0,0 +1,107 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+)
+
+// UnionNullIntString holds exactly one of the values allowed by the union [null int string].
+// Its zero value holds the zero value of the first member of the union.
+type UnionNullIntString struct {
+	index       int
+	valueInt    int32
+	valueString string
+}
+
+// Index returns the index of the union member held by the union.
+func (u UnionNullIntString) Index() int {
+	return u.index
+}
+
+// IsNull returns true when the union holds null.
+func (u UnionNullIntString) IsNull() bool {
+	return u.index == 0
+}
+
+// SetNull sets the union to hold null.
+func (u *UnionNullIntString) SetNull() {
+	*u = UnionNullIntString{index: 0}
+}
+
+// AsInt returns the int value held by the union, and true when the union
+// holds a int value.
+func (u UnionNullIntString) AsInt() (int32, bool) {
+	return u.valueInt, u.index == 1
+}
+
+// SetInt sets the union to hold the specified int value.
+func (u *UnionNullIntString) SetInt(v int32) {
+	*u = UnionNullIntString{index: 1, valueInt: v}
+}
+
+// AsString returns the string value held by the union, and true when the union
+// holds a string value.
+func (u UnionNullIntString) AsString() (string, bool) {
+	return u.valueString, u.index == 2
+}
+
+// SetString sets the union to hold the specified string value.
+func (u *UnionNullIntString) SetString(v string) {
+	*u = UnionNullIntString{index: 2, valueString: v}
+}
+
+func NewUnionNullIntString(buf []byte) (UnionNullIntString, []byte, error) {
+	return NewUnionNullIntStringAtDepth(buf, 0)
+}
+
+// NewUnionNullIntStringAtDepth is like NewUnionNullIntString, for a union nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUnionNullIntStringAtDepth(buf []byte, depth int) (UnionNullIntString, []byte, error) {
+	var result UnionNullIntString
+	idx, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return result, buf, err
+	}
+	switch idx {
+	case 0:
+	// Null case, nothing to decode
+	case 1:
+		if result.valueInt, newBuf, err = goavro.IntNativeFromBinary(newBuf); err != nil {
+			return UnionNullIntString{}, buf, fmt.Errorf("cannot decode binary union item 2: %s", err)
+		}
+	case 2:
+		if result.valueString, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+			return UnionNullIntString{}, buf, fmt.Errorf("cannot decode binary union item 3: %s", err)
+		}
+	default:
+		return result, buf, fmt.Errorf("cannot decode binary union: index ought to be between 0 and 2; read index: %d", idx)
+	}
+	result.index = int(idx)
+	return result, newBuf, nil
+}
+
+func (u UnionNullIntString) MarshalAvro(buf []byte) ([]byte, error) {
+	var err error
+	newBuf := buf
+	switch u.index {
+	case 0:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 0)
+	case 1:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 1)
+		if newBuf, err = goavro.IntBinaryFromNative(newBuf, u.valueInt); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 2: %s", err)
+		}
+	case 2:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 2)
+		if newBuf, err = goavro.StringBinaryFromNative(newBuf, u.valueString); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 3: %s", err)
+		}
+	default:
+		return buf, fmt.Errorf("cannot encode binary union: index ought to be between 0 and 2; received: %d", u.index)
+	}
+	return newBuf, nil
+}
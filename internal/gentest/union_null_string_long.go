@@ -0,0 +1,107 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+)
+
+// UnionNullStringLong holds exactly one of the values allowed by the union [null string long].
+// Its zero value holds the zero value of the first member of the union.
+type UnionNullStringLong struct {
+	index       int
+	valueString string
+	valueLong   int64
+}
+
+// Index returns the index of the union member held by the union.
+func (u UnionNullStringLong) Index() int {
+	return u.index
+}
+
+// IsNull returns true when the union holds null.
+func (u UnionNullStringLong) IsNull() bool {
+	return u.index == 0
+}
+
+// SetNull sets the union to hold null.
+func (u *UnionNullStringLong) SetNull() {
+	*u = UnionNullStringLong{index: 0}
+}
+
+// AsString returns the string value held by the union, and true when the union
+// holds a string value.
+func (u UnionNullStringLong) AsString() (string, bool) {
+	return u.valueString, u.index == 1
+}
+
+// SetString sets the union to hold the specified string value.
+func (u *UnionNullStringLong) SetString(v string) {
+	*u = UnionNullStringLong{index: 1, valueString: v}
+}
+
+// AsLong returns the long value held by the union, and true when the union
+// holds a long value.
+func (u UnionNullStringLong) AsLong() (int64, bool) {
+	return u.valueLong, u.index == 2
+}
+
+// SetLong sets the union to hold the specified long value.
+func (u *UnionNullStringLong) SetLong(v int64) {
+	*u = UnionNullStringLong{index: 2, valueLong: v}
+}
+
+func NewUnionNullStringLong(buf []byte) (UnionNullStringLong, []byte, error) {
+	return NewUnionNullStringLongAtDepth(buf, 0)
+}
+
+// NewUnionNullStringLongAtDepth is like NewUnionNullStringLong, for a union nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUnionNullStringLongAtDepth(buf []byte, depth int) (UnionNullStringLong, []byte, error) {
+	var result UnionNullStringLong
+	idx, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return result, buf, err
+	}
+	switch idx {
+	case 0:
+	// Null case, nothing to decode
+	case 1:
+		if result.valueString, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+			return UnionNullStringLong{}, buf, fmt.Errorf("cannot decode binary union item 2: %s", err)
+		}
+	case 2:
+		if result.valueLong, newBuf, err = goavro.LongNativeFromBinary(newBuf); err != nil {
+			return UnionNullStringLong{}, buf, fmt.Errorf("cannot decode binary union item 3: %s", err)
+		}
+	default:
+		return result, buf, fmt.Errorf("cannot decode binary union: index ought to be between 0 and 2; read index: %d", idx)
+	}
+	result.index = int(idx)
+	return result, newBuf, nil
+}
+
+func (u UnionNullStringLong) MarshalAvro(buf []byte) ([]byte, error) {
+	var err error
+	newBuf := buf
+	switch u.index {
+	case 0:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 0)
+	case 1:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 1)
+		if newBuf, err = goavro.StringBinaryFromNative(newBuf, u.valueString); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 2: %s", err)
+		}
+	case 2:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 2)
+		if newBuf, err = goavro.LongBinaryFromNative(newBuf, u.valueLong); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 3: %s", err)
+		}
+	default:
+		return buf, fmt.Errorf("cannot encode binary union: index ought to be between 0 and 2; received: %d", u.index)
+	}
+	return newBuf, nil
+}
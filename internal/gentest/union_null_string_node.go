@@ -0,0 +1,111 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+)
+
+// UnionNullStringNode holds exactly one of the values allowed by the union [null string com.example.gentest.node].
+// Its zero value holds the zero value of the first member of the union.
+type UnionNullStringNode struct {
+	index       int
+	valueString string
+	valueNode   *Node
+}
+
+// Index returns the index of the union member held by the union.
+func (u UnionNullStringNode) Index() int {
+	return u.index
+}
+
+// IsNull returns true when the union holds null.
+func (u UnionNullStringNode) IsNull() bool {
+	return u.index == 0
+}
+
+// SetNull sets the union to hold null.
+func (u *UnionNullStringNode) SetNull() {
+	*u = UnionNullStringNode{index: 0}
+}
+
+// AsString returns the string value held by the union, and true when the union
+// holds a string value.
+func (u UnionNullStringNode) AsString() (string, bool) {
+	return u.valueString, u.index == 1
+}
+
+// SetString sets the union to hold the specified string value.
+func (u *UnionNullStringNode) SetString(v string) {
+	*u = UnionNullStringNode{index: 1, valueString: v}
+}
+
+// AsNode returns the com.example.gentest.node value held by the union, and true when the union
+// holds a com.example.gentest.node value.
+func (u UnionNullStringNode) AsNode() (*Node, bool) {
+	return u.valueNode, u.index == 2
+}
+
+// SetNode sets the union to hold the specified com.example.gentest.node value.
+func (u *UnionNullStringNode) SetNode(v *Node) {
+	*u = UnionNullStringNode{index: 2, valueNode: v}
+}
+
+func NewUnionNullStringNode(buf []byte) (UnionNullStringNode, []byte, error) {
+	return NewUnionNullStringNodeAtDepth(buf, 0)
+}
+
+// NewUnionNullStringNodeAtDepth is like NewUnionNullStringNode, for a union nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUnionNullStringNodeAtDepth(buf []byte, depth int) (UnionNullStringNode, []byte, error) {
+	var result UnionNullStringNode
+	idx, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return result, buf, err
+	}
+	switch idx {
+	case 0:
+	// Null case, nothing to decode
+	case 1:
+		if result.valueString, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+			return UnionNullStringNode{}, buf, fmt.Errorf("cannot decode binary union item 2: %s", err)
+		}
+	case 2:
+		if result.valueNode, newBuf, err = func(buf []byte) (*Node, []byte, error) {
+			return NewNodeAtDepth(buf, depth+1)
+		}(newBuf); err != nil {
+			return UnionNullStringNode{}, buf, fmt.Errorf("cannot decode binary union item 3: %s", err)
+		}
+	default:
+		return result, buf, fmt.Errorf("cannot decode binary union: index ought to be between 0 and 2; read index: %d", idx)
+	}
+	result.index = int(idx)
+	return result, newBuf, nil
+}
+
+func (u UnionNullStringNode) MarshalAvro(buf []byte) ([]byte, error) {
+	var err error
+	newBuf := buf
+	switch u.index {
+	case 0:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 0)
+	case 1:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 1)
+		if newBuf, err = goavro.StringBinaryFromNative(newBuf, u.valueString); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 2: %s", err)
+		}
+	case 2:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 2)
+		if newBuf, err = func(buf []byte, r *Node) ([]byte, error) {
+			return r.MarshalAvro(buf)
+		}(newBuf, u.valueNode); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 3: %s", err)
+		}
+	default:
+		return buf, fmt.Errorf("cannot encode binary union: index ought to be between 0 and 2; received: %d", u.index)
+	}
+	return newBuf, nil
+}
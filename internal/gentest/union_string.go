@@ -0,0 +1,72 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+)
+
+// UnionString holds exactly one of the values allowed by the union [string].
+// Its zero value holds the zero value of the first member of the union.
+type UnionString struct {
+	index       int
+	valueString string
+}
+
+// Index returns the index of the union member held by the union.
+func (u UnionString) Index() int {
+	return u.index
+}
+
+// AsString returns the string value held by the union, and true when the union
+// holds a string value.
+func (u UnionString) AsString() (string, bool) {
+	return u.valueString, u.index == 0
+}
+
+// SetString sets the union to hold the specified string value.
+func (u *UnionString) SetString(v string) {
+	*u = UnionString{index: 0, valueString: v}
+}
+
+func NewUnionString(buf []byte) (UnionString, []byte, error) {
+	return NewUnionStringAtDepth(buf, 0)
+}
+
+// NewUnionStringAtDepth is like NewUnionString, for a union nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUnionStringAtDepth(buf []byte, depth int) (UnionString, []byte, error) {
+	var result UnionString
+	idx, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return result, buf, err
+	}
+	switch idx {
+	case 0:
+		if result.valueString, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+			return UnionString{}, buf, fmt.Errorf("cannot decode binary union item 1: %s", err)
+		}
+	default:
+		return result, buf, fmt.Errorf("cannot decode binary union: index ought to be between 0 and 0; read index: %d", idx)
+	}
+	result.index = int(idx)
+	return result, newBuf, nil
+}
+
+func (u UnionString) MarshalAvro(buf []byte) ([]byte, error) {
+	var err error
+	newBuf := buf
+	switch u.index {
+	case 0:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 0)
+		if newBuf, err = goavro.StringBinaryFromNative(newBuf, u.valueString); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 1: %s", err)
+		}
+	default:
+		return buf, fmt.Errorf("cannot encode binary union: index ought to be between 0 and 0; received: %d", u.index)
+	}
+	return newBuf, nil
+}
@@ -0,0 +1,94 @@
+//******************************************
+//* This file is is generated, DO NOT EDIT *
+//******************************************
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/peak6/goavro/v2"
+	"time"
+)
+
+// UnionStringLongTimestampMillis holds exactly one of the values allowed by the union [string long.timestamp-millis].
+// Its zero value holds the zero value of the first member of the union.
+type UnionStringLongTimestampMillis struct {
+	index                    int
+	valueString              string
+	valueLongTimestampMillis time.Time
+}
+
+// Index returns the index of the union member held by the union.
+func (u UnionStringLongTimestampMillis) Index() int {
+	return u.index
+}
+
+// AsString returns the string value held by the union, and true when the union
+// holds a string value.
+func (u UnionStringLongTimestampMillis) AsString() (string, bool) {
+	return u.valueString, u.index == 0
+}
+
+// SetString sets the union to hold the specified string value.
+func (u *UnionStringLongTimestampMillis) SetString(v string) {
+	*u = UnionStringLongTimestampMillis{index: 0, valueString: v}
+}
+
+// AsLongTimestampMillis returns the long.timestamp-millis value held by the union, and true when the union
+// holds a long.timestamp-millis value.
+func (u UnionStringLongTimestampMillis) AsLongTimestampMillis() (time.Time, bool) {
+	return u.valueLongTimestampMillis, u.index == 1
+}
+
+// SetLongTimestampMillis sets the union to hold the specified long.timestamp-millis value.
+func (u *UnionStringLongTimestampMillis) SetLongTimestampMillis(v time.Time) {
+	*u = UnionStringLongTimestampMillis{index: 1, valueLongTimestampMillis: v}
+}
+
+func NewUnionStringLongTimestampMillis(buf []byte) (UnionStringLongTimestampMillis, []byte, error) {
+	return NewUnionStringLongTimestampMillisAtDepth(buf, 0)
+}
+
+// NewUnionStringLongTimestampMillisAtDepth is like NewUnionStringLongTimestampMillis, for a union nested within depth records.
+// It is used by generated code to limit how deeply records are nested.
+func NewUnionStringLongTimestampMillisAtDepth(buf []byte, depth int) (UnionStringLongTimestampMillis, []byte, error) {
+	var result UnionStringLongTimestampMillis
+	idx, newBuf, err := goavro.LongNativeFromBinary(buf)
+	if err != nil {
+		return result, buf, err
+	}
+	switch idx {
+	case 0:
+		if result.valueString, newBuf, err = goavro.StringNativeFromBinary(newBuf); err != nil {
+			return UnionStringLongTimestampMillis{}, buf, fmt.Errorf("cannot decode binary union item 1: %s", err)
+		}
+	case 1:
+		if result.valueLongTimestampMillis, newBuf, err = goavro.NativeFromBinaryTimeStampMillis(newBuf); err != nil {
+			return UnionStringLongTimestampMillis{}, buf, fmt.Errorf("cannot decode binary union item 2: %s", err)
+		}
+	default:
+		return result, buf, fmt.Errorf("cannot decode binary union: index ought to be between 0 and 1; read index: %d", idx)
+	}
+	result.index = int(idx)
+	return result, newBuf, nil
+}
+
+func (u UnionStringLongTimestampMillis) MarshalAvro(buf []byte) ([]byte, error) {
+	var err error
+	newBuf := buf
+	switch u.index {
+	case 0:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 0)
+		if newBuf, err = goavro.StringBinaryFromNative(newBuf, u.valueString); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 1: %s", err)
+		}
+	case 1:
+		newBuf, _ = goavro.LongBinaryFromNative(newBuf, 1)
+		if newBuf, err = goavro.BinaryFromNativeTimeStampMillis(newBuf, u.valueLongTimestampMillis); err != nil {
+			return buf, fmt.Errorf("cannot encode binary union item 2: %s", err)
+		}
+	default:
+		return buf, fmt.Errorf("cannot encode binary union: index ought to be between 0 and 1; received: %d", u.index)
+	}
+	return newBuf, nil
+}
@@ -10,20 +10,71 @@
 package goavro
 
 import (
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"math"
 	"math/big"
+	"sync"
 	"time"
 )
 
 type toNativeFn func([]byte) (interface{}, []byte, error)
 type fromNativeFn func([]byte, interface{}) ([]byte, error)
 
+const secondsPerDay = 24 * 60 * 60
+
+// isRawTime reports whether d is an integer, which the encoders of the date and
+// time logical types accept as the value of their underlying type, such as
+// those decoded by codecs created with the RawTime option.
+func isRawTime(d interface{}) bool {
+	switch d.(type) {
+	case int, int32, int64:
+		return true
+	}
+	return false
+}
+
+// applyTimeOption replaces the decoders of the date and time logical types in
+// the symbol table with those that decode to the native representation chosen
+// by o.
+func applyTimeOption(st map[string]*Codec, o *CodecOption) {
+	if o.RawTime {
+		for _, key := range []string{"int.date", "int.time-millis"} {
+			st[key].nativeFromBinary, st[key].nativeFromTextual = intNativeFromBinary, intNativeFromTextual
+		}
+		for _, key := range []string{"long.time-micros", "long.timestamp-millis", "long.timestamp-micros", "long.local-timestamp-millis", "long.local-timestamp-micros"} {
+			st[key].nativeFromBinary, st[key].nativeFromTextual = longNativeFromBinary, longNativeFromTextual
+		}
+		return
+	}
+	loc := o.TimeLocation
+	if loc == nil {
+		return
+	}
+	st["int.date"].nativeFromBinary = nativeFromDateIn(intNativeFromBinary, loc)
+	st["int.date"].nativeFromTextual = nativeFromDateIn(intNativeFromTextual, loc)
+	st["int.date"].binaryFromNative = dateFromNativeIn(intBinaryFromNative, loc)
+	st["int.date"].textualFromNative = dateFromNativeIn(intTextualFromNative, loc)
+	st["long.timestamp-millis"].nativeFromBinary = nativeFromTimeStampMillisIn(longNativeFromBinary, loc)
+	st["long.timestamp-millis"].nativeFromTextual = nativeFromTimeStampMillisIn(longNativeFromTextual, loc)
+	st["long.timestamp-micros"].nativeFromBinary = nativeFromTimeStampMicrosIn(longNativeFromBinary, loc)
+	st["long.timestamp-micros"].nativeFromTextual = nativeFromTimeStampMicrosIn(longNativeFromTextual, loc)
+	st["long.local-timestamp-millis"].nativeFromBinary = nativeFromLocalTimeStampIn(nativeFromTimeStampMillis(longNativeFromBinary), loc)
+	st["long.local-timestamp-millis"].nativeFromTextual = nativeFromLocalTimeStampIn(nativeFromTimeStampMillis(longNativeFromTextual), loc)
+	st["long.local-timestamp-micros"].nativeFromBinary = nativeFromLocalTimeStampIn(nativeFromTimeStampMicros(longNativeFromBinary), loc)
+	st["long.local-timestamp-micros"].nativeFromTextual = nativeFromLocalTimeStampIn(nativeFromTimeStampMicros(longNativeFromTextual), loc)
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////
 // date logical type - to/from time.Time, time.UTC location
 //////////////////////////////////////////////////////////////////////////////////////////////
 func nativeFromDate(fn toNativeFn) toNativeFn {
+	return nativeFromDateIn(fn, time.UTC)
+}
+
+// nativeFromDateIn decodes dates to midnight of the date in loc.
+func nativeFromDateIn(fn toNativeFn, loc *time.Location) toNativeFn {
 	return func(bytes []byte) (interface{}, []byte, error) {
 		l, b, err := fn(bytes)
 		if err != nil {
@@ -33,13 +84,16 @@ func nativeFromDate(fn toNativeFn) toNativeFn {
 		if !ok {
 			return l, b, fmt.Errorf("cannot transform to native date, expected int, received %T", l)
 		}
-		t := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(i)).UTC()
-		return t, b, nil
+		t := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(i))
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), b, nil
 	}
 }
 
 func dateFromNative(fn fromNativeFn) fromNativeFn {
 	return func(b []byte, d interface{}) ([]byte, error) {
+		if isRawTime(d) {
+			return fn(b, d)
+		}
 		t, ok := d.(time.Time)
 		if !ok {
 			return nil, fmt.Errorf("cannot transform to binary date, expected time.Time, received %T", d)
@@ -53,6 +107,24 @@ func dateFromNative(fn fromNativeFn) fromNativeFn {
 	}
 }
 
+// dateFromNativeIn encodes the date of the calendar in loc of a time, so that
+// the dates that nativeFromDateIn decodes to midnight in loc encode to the
+// same day.
+func dateFromNativeIn(fn fromNativeFn, loc *time.Location) fromNativeFn {
+	return func(b []byte, d interface{}) ([]byte, error) {
+		if isRawTime(d) {
+			return fn(b, d)
+		}
+		t, ok := d.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("cannot transform to binary date, expected time.Time, received %T", d)
+		}
+		t = t.In(loc)
+		numDays := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / secondsPerDay
+		return fn(b, numDays)
+	}
+}
+
 //////////////////////////////////////////////////////////////////////////////////////////////
 // time-millis logical type - to/from time.Time, time.UTC location
 //////////////////////////////////////////////////////////////////////////////////////////////
@@ -73,6 +145,9 @@ func nativeFromTimeMillis(fn toNativeFn) toNativeFn {
 
 func timeMillisFromNative(fn fromNativeFn) fromNativeFn {
 	return func(b []byte, d interface{}) ([]byte, error) {
+		if isRawTime(d) {
+			return fn(b, d)
+		}
 		t, ok := d.(time.Duration)
 		if !ok {
 			return nil, fmt.Errorf("cannot transform to binary time-millis, expected time.Duration, received %T", d)
@@ -102,6 +177,9 @@ func nativeFromTimeMicros(fn toNativeFn) toNativeFn {
 
 func timeMicrosFromNative(fn fromNativeFn) fromNativeFn {
 	return func(b []byte, d interface{}) ([]byte, error) {
+		if isRawTime(d) {
+			return fn(b, d)
+		}
 		t, ok := d.(time.Duration)
 		if !ok {
 			return nil, fmt.Errorf("cannot transform to binary time-micros, expected time.Duration, received %T", d)
@@ -115,6 +193,11 @@ func timeMicrosFromNative(fn fromNativeFn) fromNativeFn {
 // timestamp-millis logical type - to/from time.Time, time.UTC location
 //////////////////////////////////////////////////////////////////////////////////////////////
 func nativeFromTimeStampMillis(fn toNativeFn) toNativeFn {
+	return nativeFromTimeStampMillisIn(fn, time.UTC)
+}
+
+// nativeFromTimeStampMillisIn decodes timestamps to times in loc.
+func nativeFromTimeStampMillisIn(fn toNativeFn, loc *time.Location) toNativeFn {
 	return func(bytes []byte) (interface{}, []byte, error) {
 		l, b, err := fn(bytes)
 		if err != nil {
@@ -126,12 +209,15 @@ func nativeFromTimeStampMillis(fn toNativeFn) toNativeFn {
 		}
 		secs := i / int64(time.Microsecond)
 		nanosecs := (i - secs*int64(time.Microsecond)) * int64(time.Millisecond)
-		return time.Unix(secs, nanosecs).UTC(), b, nil
+		return time.Unix(secs, nanosecs).In(loc), b, nil
 	}
 }
 
 func timeStampMillisFromNative(fn fromNativeFn) fromNativeFn {
 	return func(b []byte, d interface{}) ([]byte, error) {
+		if isRawTime(d) {
+			return fn(b, d)
+		}
 		t, ok := d.(time.Time)
 		if !ok {
 			return nil, fmt.Errorf("cannot transform binary timestamp-millis, expected time.Time, received %T", d)
@@ -145,6 +231,11 @@ func timeStampMillisFromNative(fn fromNativeFn) fromNativeFn {
 // timestamp-micros logical type - to/from time.Time, time.UTC location
 //////////////////////////////////////////////////////////////////////////////////////////////
 func nativeFromTimeStampMicros(fn toNativeFn) toNativeFn {
+	return nativeFromTimeStampMicrosIn(fn, time.UTC)
+}
+
+// nativeFromTimeStampMicrosIn decodes timestamps to times in loc.
+func nativeFromTimeStampMicrosIn(fn toNativeFn, loc *time.Location) toNativeFn {
 	return func(bytes []byte) (interface{}, []byte, error) {
 		l, b, err := fn(bytes)
 		if err != nil {
@@ -161,12 +252,15 @@ func nativeFromTimeStampMicros(fn toNativeFn) toNativeFn {
 		// zero-time overflows 64-bit integers.
 		seconds := microseconds / 1e6
 		nanoseconds := (microseconds - (seconds * 1e6)) * 1e3
-		return time.Unix(seconds, nanoseconds).UTC(), b, nil
+		return time.Unix(seconds, nanoseconds).In(loc), b, nil
 	}
 }
 
 func timeStampMicrosFromNative(fn fromNativeFn) fromNativeFn {
 	return func(b []byte, d interface{}) ([]byte, error) {
+		if isRawTime(d) {
+			return fn(b, d)
+		}
 		t, ok := d.(time.Time)
 		if !ok {
 			return nil, fmt.Errorf("cannot transform binary timestamp-micros, expected time.Time, received %T", d)
@@ -180,6 +274,217 @@ func timeStampMicrosFromNative(fn fromNativeFn) fromNativeFn {
 	}
 }
 
+//////////////////////////////////////////////////////////////////////////////////////////////
+// local-timestamp-millis logical type - to/from time.Time, wall clock time in time.UTC location
+//////////////////////////////////////////////////////////////////////////////////////////////
+
+// wallClock returns the time in the UTC location with the same year, month,
+// day, hour, minute, second and nanosecond as t has in its own location, which
+// is how local timestamps, which have no time zone, are represented.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// nativeFromLocalTimeStampIn decodes local timestamps, using the decoder of
+// the timestamp of the same precision, to their wall clock time in loc.
+func nativeFromLocalTimeStampIn(fn toNativeFn, loc *time.Location) toNativeFn {
+	return func(bytes []byte) (interface{}, []byte, error) {
+		l, b, err := fn(bytes)
+		if err != nil {
+			return l, b, err
+		}
+		t := l.(time.Time)
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc), b, nil
+	}
+}
+
+func localTimeStampMillisFromNative(fn fromNativeFn) fromNativeFn {
+	return func(b []byte, d interface{}) ([]byte, error) {
+		if isRawTime(d) {
+			return fn(b, d)
+		}
+		t, ok := d.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("cannot transform binary local-timestamp-millis, expected time.Time, received %T", d)
+		}
+		return timeStampMillisFromNative(fn)(b, wallClock(t))
+	}
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////
+// local-timestamp-micros logical type - to/from time.Time, wall clock time in time.UTC location
+//////////////////////////////////////////////////////////////////////////////////////////////
+func localTimeStampMicrosFromNative(fn fromNativeFn) fromNativeFn {
+	return func(b []byte, d interface{}) ([]byte, error) {
+		if isRawTime(d) {
+			return fn(b, d)
+		}
+		t, ok := d.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("cannot transform binary local-timestamp-micros, expected time.Time, received %T", d)
+		}
+		return timeStampMicrosFromNative(fn)(b, wallClock(t))
+	}
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////
+// uuid logical type - to/from string, validated, and from [16]byte
+//////////////////////////////////////////////////////////////////////////////////////////////
+func nativeFromUUID(fn toNativeFn) toNativeFn {
+	return func(bytes []byte) (interface{}, []byte, error) {
+		l, b, err := fn(bytes)
+		if err != nil {
+			return l, b, err
+		}
+		s, ok := l.(string)
+		if !ok {
+			return l, b, fmt.Errorf("cannot transform to native uuid, expected string, received %T", l)
+		}
+		if _, err = parseUUID(s); err != nil {
+			return nil, bytes, fmt.Errorf("cannot transform to native uuid: %s", err)
+		}
+		return s, b, nil
+	}
+}
+
+func uuidFromNative(fn fromNativeFn) fromNativeFn {
+	return func(b []byte, d interface{}) ([]byte, error) {
+		switch v := d.(type) {
+		case string:
+			if _, err := parseUUID(v); err != nil {
+				return nil, fmt.Errorf("cannot transform to binary uuid: %s", err)
+			}
+			return fn(b, v)
+		case [16]byte:
+			return fn(b, formatUUID(v))
+		default:
+			return nil, fmt.Errorf("cannot transform to binary uuid, expected string or [16]byte, received %T", d)
+		}
+	}
+}
+
+// applyUUIDOption replaces the decoders of the uuid logical type in the symbol
+// table with those that decode to [16]byte values when o asks for them.
+func applyUUIDOption(st map[string]*Codec, o *CodecOption) {
+	if !o.UUIDBytes {
+		return
+	}
+	st["string.uuid"].nativeFromBinary = nativeFromUUIDBytes(stringNativeFromBinary)
+	st["string.uuid"].nativeFromTextual = nativeFromUUIDBytes(stringNativeFromTextual)
+}
+
+func nativeFromUUIDBytes(fn toNativeFn) toNativeFn {
+	return func(bytes []byte) (interface{}, []byte, error) {
+		l, b, err := fn(bytes)
+		if err != nil {
+			return l, b, err
+		}
+		s, ok := l.(string)
+		if !ok {
+			return l, b, fmt.Errorf("cannot transform to native uuid, expected string, received %T", l)
+		}
+		u, err := parseUUID(s)
+		if err != nil {
+			return nil, bytes, fmt.Errorf("cannot transform to native uuid: %s", err)
+		}
+		return u, b, nil
+	}
+}
+
+// parseUUID returns the 16 bytes of a UUID from its textual form, 32
+// hexadecimal digits in groups of 8, 4, 4, 4 and 12 separated by hyphens, as
+// specified by RFC 4122.
+func parseUUID(s string) ([16]byte, error) {
+	var u [16]byte
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("UUID ought to be 32 hexadecimal digits in 5 groups separated by hyphens: %q", s)
+	}
+	digits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return u, fmt.Errorf("UUID ought to be 32 hexadecimal digits in 5 groups separated by hyphens: %q", s)
+	}
+	return u, nil
+}
+
+// formatUUID returns the textual form of the UUID, in lower case.
+func formatUUID(u [16]byte) string {
+	s := hex.EncodeToString(u[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+}
+
+//////////////////////////////////////////////////////////////////////////////////////////////
+// duration logical type - fixed(12) - to/from Duration
+//////////////////////////////////////////////////////////////////////////////////////////////
+
+// Duration is the native form of the Avro duration logical type, which is an
+// amount of time in months, days and milliseconds. The three amounts are
+// independent of each other, because the length of a month or a day varies.
+type Duration struct {
+	Months       uint32
+	Days         uint32
+	Milliseconds uint32
+}
+
+// durationSize is the size of the fixed type annotated with the duration
+// logical type.
+const durationSize = 12
+
+func makeDurationFixedCodec(st map[string]*Codec, enclosingNamespace string, schemaMap map[string]interface{}) (*Codec, error) {
+	c, err := makeFixedCodec(st, enclosingNamespace, schemaMap)
+	if err != nil {
+		return nil, err
+	}
+	size, err := sizeFromSchemaMap(c.typeName, schemaMap)
+	if err != nil {
+		return nil, err
+	}
+	if size != durationSize {
+		return nil, fmt.Errorf("cannot create duration logical type when fixed size is not %d: %d", durationSize, size)
+	}
+	c.binaryFromNative = durationFromNative(c.binaryFromNative)
+	c.textualFromNative = durationFromNative(c.textualFromNative)
+	c.nativeFromBinary = nativeFromDuration(c.nativeFromBinary)
+	c.nativeFromTextual = nativeFromDuration(c.nativeFromTextual)
+	c.nativeFromBinaryReuse = nil
+	c.generator = NewDurationCodecGenerator()
+	// NOTE: Generated code uses Duration rather than declaring a type for the
+	// fixed, but still inlines its schema in the schemas that refer to it.
+	c.generator.schema, c.generator.enclosingNamespace = schemaMap, enclosingNamespace
+	return c, nil
+}
+
+func nativeFromDuration(fn toNativeFn) toNativeFn {
+	return func(bytes []byte) (interface{}, []byte, error) {
+		l, b, err := fn(bytes)
+		if err != nil {
+			return l, b, err
+		}
+		bs, ok := l.([]byte)
+		if !ok || len(bs) != durationSize {
+			return nil, bytes, fmt.Errorf("cannot transform to native duration, expected [%d]byte, received %T", durationSize, l)
+		}
+		return Duration{
+			Months:       binary.LittleEndian.Uint32(bs[0:4]),
+			Days:         binary.LittleEndian.Uint32(bs[4:8]),
+			Milliseconds: binary.LittleEndian.Uint32(bs[8:12]),
+		}, b, nil
+	}
+}
+
+func durationFromNative(fn fromNativeFn) fromNativeFn {
+	return func(b []byte, d interface{}) ([]byte, error) {
+		v, ok := d.(Duration)
+		if !ok {
+			return nil, fmt.Errorf("cannot transform to binary duration, expected goavro.Duration, received %T", d)
+		}
+		bs := make([]byte, durationSize)
+		binary.LittleEndian.PutUint32(bs[0:4], v.Months)
+		binary.LittleEndian.PutUint32(bs[4:8], v.Days)
+		binary.LittleEndian.PutUint32(bs[8:12], v.Milliseconds)
+		return fn(b, bs)
+	}
+}
+
 /////////////////////////////////////////////////////////////////////////////////////////////
 // decimal logical-type - byte/fixed - to/from math/big.Rat
 // two's complement algorithm taken from:
@@ -219,14 +524,29 @@ func precisionAndScaleFromSchemaMap(schemaMap map[string]interface{}) (int, int,
 
 var one = big.NewInt(1)
 
+// withDefaultName returns schemaMap and st when schemaMap has a name, and
+// otherwise a copy of it named name, leaving the schema itself, of which the
+// canonical form is computed, unchanged, along with an empty symbol table. An
+// unnamed decimal is therefore never registered, so that decimals with
+// different precision and scale do not share a codec.
+func withDefaultName(st map[string]*Codec, schemaMap map[string]interface{}, name string) (map[string]*Codec, map[string]interface{}) {
+	if _, ok := schemaMap["name"]; ok {
+		return st, schemaMap
+	}
+	named := make(map[string]interface{}, len(schemaMap)+1)
+	for k, v := range schemaMap {
+		named[k] = v
+	}
+	named["name"] = name
+	return make(map[string]*Codec), named
+}
+
 func makeDecimalBytesCodec(st map[string]*Codec, enclosingNamespace string, schemaMap map[string]interface{}) (*Codec, error) {
 	precision, scale, err := precisionAndScaleFromSchemaMap(schemaMap)
 	if err != nil {
 		return nil, err
 	}
-	if _, ok := schemaMap["name"]; !ok {
-		schemaMap["name"] = "bytes.decimal"
-	}
+	st, schemaMap = withDefaultName(st, schemaMap, "bytes.decimal")
 	c, err := registerNewCodec(st, schemaMap, enclosingNamespace)
 	if err != nil {
 		return nil, fmt.Errorf("Bytes ought to have valid name: %s", err)
@@ -241,7 +561,28 @@ func makeDecimalBytesCodec(st map[string]*Codec, enclosingNamespace string, sche
 	return c, nil
 }
 
+// DecimalPrecisionError is the error returned when a decimal value has more
+// digits than the precision of its schema allows, once scaled.
+type DecimalPrecisionError struct {
+	Precision int
+	Scale     int
+	Value     *big.Rat
+}
+
+func (e DecimalPrecisionError) Error() string {
+	return fmt.Sprintf("cannot encode decimal: value ought to have at most %d digits with scale %d: %s", e.Precision, e.Scale, e.Value.FloatString(e.Scale))
+}
+
+var ten = big.NewInt(10)
+
+// pow10 returns 10 raised to the power of n, which does not overflow like
+// math.Pow10 does for the scale and precision of wide decimals.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
 func nativeFromDecimalBytes(fn toNativeFn, precision, scale int) toNativeFn {
+	denom := pow10(scale)
 	return func(bytes []byte) (interface{}, []byte, error) {
 		d, b, err := fn(bytes)
 		if err != nil {
@@ -251,31 +592,36 @@ func nativeFromDecimalBytes(fn toNativeFn, precision, scale int) toNativeFn {
 		if !ok {
 			return nil, bytes, fmt.Errorf("cannot transform to native decimal, expected []byte, received %T", d)
 		}
-		i := big.NewInt(0)
+		i := new(big.Int)
 		fromSignedBytes(i, bs)
-		if i.BitLen() > 64 {
-			// Avro spec specifies we return underlying type if the logicalType is invalid
-			return d, b, err
-		}
-		r := big.NewRat(i.Int64(), int64(math.Pow10(scale)))
-		return r, b, nil
+		return new(big.Rat).SetFrac(i, denom), b, nil
 	}
 }
 
+// decimalBytesFromNative encodes *big.Rat values, which are rounded to the
+// nearest value with the scale of the schema, and halves away from zero, such
+// that -1.005 encodes as -1.01 with a scale of 2. It returns a
+// DecimalPrecisionError for values that have more digits than the precision
+// of the schema once rounded.
 func decimalBytesFromNative(fromNativeFn fromNativeFn, toBytesFn toBytesFn, precision, scale int) fromNativeFn {
+	multiplier := pow10(scale)
+	limit := pow10(precision)
 	return func(b []byte, d interface{}) ([]byte, error) {
 		r, ok := d.(*big.Rat)
 		if !ok {
 			return nil, fmt.Errorf("cannot transform to bytes, expected *big.Rat, received %T", d)
 		}
-		// we reduce accuracy to precision by dividing and multiplying by digit length
-		num := big.NewInt(0).Set(r.Num())
-		denom := big.NewInt(0).Set(r.Denom())
-
 		// we get the scaled decimal representation
-		i := new(big.Int).Mul(num, big.NewInt(int64(math.Pow10(scale))))
-		// divide that by the denominator
-		precnum := new(big.Int).Div(i, denom)
+		i := new(big.Int).Mul(r.Num(), multiplier)
+		// divide that by the denominator, truncating towards zero, then round
+		// the digits beyond the scale
+		precnum, remainder := i.QuoRem(i, r.Denom(), new(big.Int))
+		if remainder.Lsh(remainder.Abs(remainder), 1).Cmp(r.Denom()) >= 0 {
+			precnum.Add(precnum, big.NewInt(int64(r.Sign())))
+		}
+		if new(big.Int).Abs(precnum).Cmp(limit) >= 0 {
+			return nil, DecimalPrecisionError{Precision: precision, Scale: scale, Value: r}
+		}
 		bout, err := toBytesFn(precnum)
 		if err != nil {
 			return nil, err
@@ -289,9 +635,7 @@ func makeDecimalFixedCodec(st map[string]*Codec, enclosingNamespace string, sche
 	if err != nil {
 		return nil, err
 	}
-	if _, ok := schemaMap["name"]; !ok {
-		schemaMap["name"] = "fixed.decimal"
-	}
+	st, schemaMap = withDefaultName(st, schemaMap, "fixed.decimal")
 	c, err := makeFixedCodec(st, enclosingNamespace, schemaMap)
 	if err != nil {
 		return nil, err
@@ -304,18 +648,13 @@ func makeDecimalFixedCodec(st map[string]*Codec, enclosingNamespace string, sche
 	c.textualFromNative = decimalBytesFromNative(c.textualFromNative, toSignedFixedBytes(size), precision, scale)
 	c.nativeFromBinary = nativeFromDecimalBytes(c.nativeFromBinary, precision, scale)
 	c.nativeFromTextual = nativeFromDecimalBytes(c.nativeFromTextual, precision, scale)
+	c.generator = NewDecimalFixedCodecGenerator(size, precision, scale)
+	// NOTE: Generated code uses *big.Rat rather than declaring a type for the
+	// fixed, but still inlines its schema in the schemas that refer to it.
+	c.generator.schema, c.generator.enclosingNamespace = schemaMap, enclosingNamespace
 	return c, nil
 }
 
-func padBytes(bytes []byte, fixedSize uint) []byte {
-	s := int(fixedSize)
-	padded := make([]byte, s, s)
-	if s >= len(bytes) {
-		copy(padded[s-len(bytes):], bytes)
-	}
-	return padded
-}
-
 type toBytesFn func(n *big.Int) ([]byte, error)
 
 // fromSignedBytes sets the value of n to the big-endian two's complement
@@ -358,21 +697,157 @@ func toSignedBytes(n *big.Int) ([]byte, error) {
 // form of n for a given length of bytes.
 func toSignedFixedBytes(size uint) func(*big.Int) ([]byte, error) {
 	return func(n *big.Int) ([]byte, error) {
-		switch n.Sign() {
-		case 0:
-			return []byte{0}, nil
-		case 1:
-			b := n.Bytes()
-			if b[0]&0x80 > 0 {
-				b = append([]byte{0}, b...)
+		b, err := toSignedBytes(n)
+		if err != nil {
+			return nil, err
+		}
+		if uint(len(b)) > size {
+			return nil, fmt.Errorf("cannot encode decimal: value ought to fit in %d bytes: %s", size, n)
+		}
+		padded := make([]byte, size)
+		if n.Sign() < 0 {
+			// sign extend negative numbers
+			for i := range padded {
+				padded[i] = 0xff
 			}
-			return padBytes(b, size), nil
-		case -1:
-			length := size * 8
-			b := new(big.Int).Add(n, new(big.Int).Lsh(one, length)).Bytes()
-			// Unlike a variable length byte length we need the extra bits to meet byte length
-			return b, nil
-		}
-		return nil, fmt.Errorf("toSignedBytes: error big.Int.Sign() returned unexpected value")
+		}
+		copy(padded[size-uint(len(b)):], b)
+		return padded, nil
+	}
+}
+
+/////////////////////////////////////////////////////////////////////////////////////////////
+// custom logical types - registered by the program, wrapping any Avro type
+/////////////////////////////////////////////////////////////////////////////////////////////
+
+// customLogicalType converts between the native values of a registered
+// logical type and those of its underlying Avro type.
+type customLogicalType struct {
+	name       string
+	toNative   func(interface{}) (interface{}, error)
+	fromNative func(interface{}) (interface{}, error)
+}
+
+var (
+	customLogicalTypesLock sync.RWMutex
+	customLogicalTypes     = make(map[string]customLogicalType)
+)
+
+// builtinLogicalTypes are the logical types that need more than the base type
+// to build their codecs, and therefore are not in the symbol table.
+var builtinLogicalTypes = map[string]struct{}{
+	"bytes.decimal":  {},
+	"fixed.decimal":  {},
+	"fixed.duration": {},
+}
+
+// RegisterLogicalType registers a logical type, so that every codec built
+// afterwards from a schema of the base type with the logicalType attribute
+// set to name decodes and encodes values of that logical type.
+//
+// The base is the name of a primitive type, such as "string", or of a complex
+// type, such as "fixed" or "record". When decoding, toNative is called with
+// the value decoded for the base type, and returns the value of the logical
+// type. When encoding, fromNative is called with the value of the logical
+// type, and returns the value to encode for the base type.
+//
+//     err := goavro.RegisterLogicalType("fixed", "ip-address",
+//         func(datum interface{}) (interface{}, error) {
+//             return net.IP(datum.([]byte)), nil
+//         },
+//         func(datum interface{}) (interface{}, error) {
+//             ip, ok := datum.(net.IP)
+//             if !ok {
+//                 return nil, fmt.Errorf("expected net.IP, received %T", datum)
+//             }
+//             return []byte(ip.To16()), nil
+//         })
+//
+// Values of logical types of primitive base types are named "base.name" in
+// unions, such as "string.geohash", while those of named types keep the name
+// of their type. It returns an error when the logical type is already
+// registered or built in.
+//
+// The registry of logical types is shared by the whole program, and logical
+// types cannot be unregistered, so RegisterLogicalType ought to be called from
+// an init function, before any codec is built. Codecs built before a logical
+// type is registered do not use it. Like the other attributes of schemas that
+// do not change how data is encoded, the logicalType attribute is not part of
+// the canonical form of a schema, whether or not it is registered.
+func RegisterLogicalType(base, name string, toNative, fromNative func(interface{}) (interface{}, error)) error {
+	switch base {
+	case "array", "boolean", "bytes", "double", "enum", "fixed", "float", "int", "long", "map", "null", "record", "string":
+	default:
+		return fmt.Errorf("cannot register logical type %q: unknown base type: %q", name, base)
+	}
+	if name == "" {
+		return fmt.Errorf("cannot register logical type of base type %q: name ought to be non-empty", base)
+	}
+	if toNative == nil || fromNative == nil {
+		return fmt.Errorf("cannot register logical type %q: conversion functions ought to be non-nil", name)
+	}
+	key := base + "." + name
+	if _, ok := builtinLogicalTypes[key]; ok {
+		return fmt.Errorf("cannot register logical type %q: already built in: %q", name, key)
+	}
+	if _, ok := newSymbolTable()[key]; ok {
+		return fmt.Errorf("cannot register logical type %q: already built in: %q", name, key)
+	}
+	customLogicalTypesLock.Lock()
+	defer customLogicalTypesLock.Unlock()
+	if _, ok := customLogicalTypes[key]; ok {
+		return fmt.Errorf("cannot register logical type %q: already registered: %q", name, key)
+	}
+	customLogicalTypes[key] = customLogicalType{name: name, toNative: toNative, fromNative: fromNative}
+	return nil
+}
+
+func lookupCustomLogicalType(key string) (customLogicalType, bool) {
+	customLogicalTypesLock.RLock()
+	lt, ok := customLogicalTypes[key]
+	customLogicalTypesLock.RUnlock()
+	return lt, ok
+}
+
+// makeCustomLogicalTypeCodec returns a codec for the registered logical type
+// that wraps the codec c of its base type. Because codecs of named types are
+// referred to by their names, those are modified in place, while those of
+// other types, which may be shared, are copied.
+func makeCustomLogicalTypeCodec(c *Codec, key string, lt customLogicalType) *Codec {
+	switch c.typeName.fullName {
+	case "array", "boolean", "bytes", "double", "float", "int", "long", "map", "null", "string":
+		wrapped := *c
+		wrapped.typeName = &name{key, nullNamespace}
+		c = &wrapped
+	}
+	c.binaryFromNative = lt.wrapFromNative(c.binaryFromNative)
+	c.textualFromNative = lt.wrapFromNative(c.textualFromNative)
+	c.nativeFromBinary = lt.wrapToNative(c.nativeFromBinary)
+	c.nativeFromTextual = lt.wrapToNative(c.nativeFromTextual)
+	c.nativeFromBinaryReuse = nil
+	return c
+}
+
+func (lt customLogicalType) wrapToNative(fn toNativeFn) toNativeFn {
+	return func(bytes []byte) (interface{}, []byte, error) {
+		d, b, err := fn(bytes)
+		if err != nil {
+			return d, b, err
+		}
+		v, err := lt.toNative(d)
+		if err != nil {
+			return nil, bytes, fmt.Errorf("cannot transform to native %s: %s", lt.name, err)
+		}
+		return v, b, nil
+	}
+}
+
+func (lt customLogicalType) wrapFromNative(fn fromNativeFn) fromNativeFn {
+	return func(b []byte, d interface{}) ([]byte, error) {
+		v, err := lt.fromNative(d)
+		if err != nil {
+			return nil, fmt.Errorf("cannot transform to binary %s: %s", lt.name, err)
+		}
+		return fn(b, v)
 	}
 }
@@ -10,8 +10,11 @@
 package goavro
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
+	"net"
+	"strings"
 	"testing"
 	"time"
 )
@@ -92,6 +95,70 @@ func TestDateLogicalTypeEncode(t *testing.T) {
 	testBinaryCodecPass(t, schema, time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC), []byte("\xbc\xcd\x01"))
 }
 
+func TestLocalTimeStampMillisLogicalTypeEncode(t *testing.T) {
+	schema := `{"type": "long", "logicalType": "local-timestamp-millis"}`
+	testBinaryDecodeFail(t, schema, []byte(""), "short buffer")
+	testBinaryEncodeFail(t, schema, "test", "cannot transform binary local-timestamp-millis, expected time.Time")
+	testBinaryCodecPass(t, schema, time.Date(2006, 1, 2, 15, 04, 05, 565000000, time.UTC), []byte("\xfa\x82\xac\xba\x91\x42"))
+	testTextCodecPass(t, schema, time.Date(2006, 1, 2, 15, 04, 05, 565000000, time.UTC), []byte("1136214245565"))
+	// The wall clock time is encoded, regardless of its location.
+	est := time.FixedZone("EST", -5*60*60)
+	testBinaryEncodePass(t, schema, time.Date(2006, 1, 2, 15, 04, 05, 565000000, est), []byte("\xfa\x82\xac\xba\x91\x42"))
+	testTextEncodePass(t, schema, time.Date(2006, 1, 2, 15, 04, 05, 565000000, est), []byte("1136214245565"))
+}
+
+func TestLocalTimeStampMicrosLogicalTypeEncode(t *testing.T) {
+	schema := `{"type": "long", "logicalType": "local-timestamp-micros"}`
+	testBinaryDecodeFail(t, schema, []byte(""), "short buffer")
+	testBinaryEncodeFail(t, schema, "test", "cannot transform binary local-timestamp-micros, expected time.Time")
+	testBinaryCodecPass(t, schema, time.Date(2006, 1, 2, 15, 04, 05, 565283000, time.UTC), []byte("\xc6\x8d\xf7\xe7\xaf\xd8\x84\x04"))
+	testTextCodecPass(t, schema, time.Date(2006, 1, 2, 15, 04, 05, 565283000, time.UTC), []byte("1136214245565283"))
+	est := time.FixedZone("EST", -5*60*60)
+	testBinaryEncodePass(t, schema, time.Date(2006, 1, 2, 15, 04, 05, 565283000, est), []byte("\xc6\x8d\xf7\xe7\xaf\xd8\x84\x04"))
+}
+
+func TestLocalTimeStampLogicalTypeUnionEncode(t *testing.T) {
+	schema := `{"type": ["null", {"type": "long", "logicalType": "local-timestamp-millis"}]}`
+	testBinaryCodecPass(t, schema, Union("long.local-timestamp-millis", time.Date(2006, 1, 2, 15, 04, 05, 565000000, time.UTC)), []byte("\x02\xfa\x82\xac\xba\x91\x42"))
+}
+
+func TestUUIDLogicalTypeEncode(t *testing.T) {
+	schema := `{"type": "string", "logicalType": "uuid"}`
+	testSchemaValid(t, schema)
+	uuid := "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+	testBinaryCodecPass(t, schema, uuid, []byte("\x48"+uuid))
+	testTextCodecPass(t, schema, uuid, []byte(`"`+uuid+`"`))
+
+	// Upper case hexadecimal digits are accepted, and encoded as given.
+	testBinaryCodecPass(t, schema, "F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6", []byte("\x48F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6"))
+
+	// The [16]byte form is encoded as its lower case string.
+	raw := [16]byte{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}
+	testBinaryEncodePass(t, schema, raw, []byte("\x48"+uuid))
+	testTextEncodePass(t, schema, raw, []byte(`"`+uuid+`"`))
+
+	testBinaryEncodeFail(t, schema, "f81d4fae7dec11d0a76500a0c91e6bf6", "hyphens")
+	testBinaryEncodeFail(t, schema, "f81d4fae-7dec-11d0-a765-00a0c91e6bfg", "cannot transform to binary uuid")
+	testBinaryEncodeFail(t, schema, 42, "cannot transform to binary uuid, expected string or [16]byte, received int")
+	testBinaryDecodeFail(t, schema, []byte("\x06abc"), "cannot transform to native uuid")
+	testTextEncodeFail(t, schema, "not-a-uuid", "cannot transform to binary uuid")
+	testTextDecodeFail(t, schema, []byte(`"not-a-uuid"`), "cannot transform to native uuid")
+}
+
+func TestDurationLogicalTypeEncode(t *testing.T) {
+	schema := `{"type": "fixed", "name": "interval", "size": 12, "logicalType": "duration"}`
+	testSchemaValid(t, schema)
+	testSchemaInvalid(t, `{"type": "fixed", "name": "interval", "size": 8, "logicalType": "duration"}`, "size is not 12")
+
+	d := Duration{Months: 1, Days: 2, Milliseconds: 259200003}
+	encoded := []byte("\x01\x00\x00\x00\x02\x00\x00\x00\x03\x14\x73\x0f")
+	testBinaryCodecPass(t, schema, d, encoded)
+	testTextCodecPass(t, schema, d, []byte(`"\u0001\u0000\u0000\u0000\u0002\u0000\u0000\u0000\u0003\u0014s\u000F"`))
+	testBinaryDecodeFail(t, schema, encoded[:8], "short buffer")
+	testBinaryEncodeFail(t, schema, time.Hour, "cannot transform to binary duration, expected goavro.Duration, received time.Duration")
+	testTextEncodeFail(t, schema, "test", "cannot transform to binary duration, expected goavro.Duration, received string")
+}
+
 func TestDecimalBytesLogicalTypeEncode(t *testing.T) {
 	schema := `{"type": "bytes", "logicalType": "decimal", "precision": 4, "scale": 2}`
 	testBinaryCodecPass(t, schema, big.NewRat(617, 50), []byte("\x04\x04\xd2"))
@@ -111,12 +178,87 @@ func TestDecimalFixedLogicalTypeEncode(t *testing.T) {
 	testBinaryDecodePass(t, schema0scale, big.NewRat(12, 1), []byte("\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0c"))
 }
 
+func TestDecimalLogicalTypeBeyond64Bits(t *testing.T) {
+	// decimal(38, 18) needs 126 bits for its unscaled value, and a
+	// denominator larger than math.MaxInt64.
+	unscaled, _ := new(big.Int).SetString("12345678901234567890123456789012345678", 10)
+	r := new(big.Rat).SetFrac(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	positive := "\x09\x49\xb0\xf6\xf0\x02\x33\x13\xc4\x49\x90\x50\xde\x38\xf3\x4e"
+	negative := "\xf6\xb6\x4f\x09\x0f\xfd\xcc\xec\x3b\xb6\x6f\xaf\x21\xc7\x0c\xb2"
+
+	schema := `{"type": "bytes", "logicalType": "decimal", "precision": 38, "scale": 18}`
+	testBinaryCodecPass(t, schema, r, []byte("\x20"+positive))
+	testBinaryCodecPass(t, schema, new(big.Rat).Neg(r), []byte("\x20"+negative))
+
+	schema = `{"type": "fixed", "name": "amount", "size": 16, "logicalType": "decimal", "precision": 38, "scale": 18}`
+	testBinaryCodecPass(t, schema, r, []byte(positive))
+	testBinaryCodecPass(t, schema, new(big.Rat).Neg(r), []byte(negative))
+	testBinaryCodecPass(t, schema, big.NewRat(0, 1), make([]byte, 16))
+	testBinaryCodecPass(t, schema, big.NewRat(-1, 1000000000000000000), []byte("\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff"))
+}
+
+func TestDecimalLogicalTypePrecision(t *testing.T) {
+	schema := `{"type": "bytes", "logicalType": "decimal", "precision": 4, "scale": 2}`
+	testBinaryCodecPass(t, schema, big.NewRat(-9999, 100), []byte("\x04\xd8\xf1"))
+	testBinaryEncodeFail(t, schema, big.NewRat(100, 1), "cannot encode decimal: value ought to have at most 4 digits with scale 2: 100.00")
+	testBinaryEncodeFail(t, schema, big.NewRat(-10000, 100), "cannot encode decimal: value ought to have at most 4 digits with scale 2: -100.00")
+	testTextEncodeFail(t, schema, big.NewRat(100, 1), "cannot encode decimal")
+
+	codec, err := NewCodec(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = codec.BinaryFromNative(nil, big.NewRat(12345, 1))
+	precisionError, ok := err.(DecimalPrecisionError)
+	if !ok {
+		t.Fatalf("GOT: %#v; WANT: %T", err, DecimalPrecisionError{})
+	}
+	if precisionError.Precision != 4 || precisionError.Scale != 2 || precisionError.Value.Cmp(big.NewRat(12345, 1)) != 0 {
+		t.Errorf("GOT: %#v; WANT: %v", precisionError, "precision 4, scale 2, value 12345")
+	}
+
+	// Digits beyond the scale are rounded to the nearest, and halves away from
+	// zero, whatever the sign, before the precision is enforced.
+	testBinaryEncodePass(t, schema, big.NewRat(-12344, 1000), []byte("\x04\xfb\x2e"))  // -12.34
+	testBinaryEncodePass(t, schema, big.NewRat(-12345, 1000), []byte("\x04\xfb\x2d"))  // -12.35
+	testBinaryEncodePass(t, schema, big.NewRat(12345, 1000), []byte("\x04\x04\xd3"))   // 12.35
+	testBinaryEncodePass(t, schema, big.NewRat(-1, 3), []byte("\x02\xdf"))             // -0.33
+	testBinaryEncodePass(t, schema, big.NewRat(-99994, 1000), []byte("\x04\xd8\xf1"))  // -99.99
+	testBinaryEncodeFail(t, schema, big.NewRat(-99995, 1000), "cannot encode decimal") // -100.00
+
+	// A value within the precision still has to fit in the size of a fixed.
+	schema = `{"type": "fixed", "name": "small", "size": 2, "logicalType": "decimal", "precision": 6, "scale": 2}`
+	testBinaryCodecPass(t, schema, big.NewRat(-32768, 100), []byte("\x80\x00"))
+	testBinaryEncodeFail(t, schema, big.NewRat(32768, 100), "cannot encode decimal: value ought to fit in 2 bytes: 32768")
+	testBinaryEncodeFail(t, schema, big.NewRat(-32769, 100), "cannot encode decimal: value ought to fit in 2 bytes: -32769")
+}
+
 func TestDecimalBytesLogicalTypeInRecordEncode(t *testing.T) {
 	schema := `{"type": "record", "name": "myrecord", "fields" : [
 	       {"name": "mydecimal", "type": "bytes", "logicalType": "decimal", "precision": 4, "scale": 2}]}`
 	testBinaryCodecPass(t, schema, map[string]interface{}{"mydecimal": big.NewRat(617, 50)}, []byte("\x04\x04\xd2"))
 }
 
+func TestDecimalLogicalTypesWithDifferentScalesInRecord(t *testing.T) {
+	// Unnamed decimals with different precision and scale in the same schema
+	// each encode with their own scale.
+	third, _ := new(big.Rat).SetString("333333333333333333/1000000000000000000")
+
+	schema := `{"type": "record", "name": "r", "fields": [
+		{"name": "a", "type": {"type": "bytes", "logicalType": "decimal", "precision": 4, "scale": 2}},
+		{"name": "b", "type": {"type": "bytes", "logicalType": "decimal", "precision": 38, "scale": 18}}]}`
+	encoded := []byte("\x04\x04\xd2\x10\x04\xa0\x3c\xe6\x8d\x21\x55\x55")
+	testBinaryEncodePass(t, schema, map[string]interface{}{"a": big.NewRat(617, 50), "b": big.NewRat(1, 3)}, encoded)
+	testBinaryDecodePass(t, schema, map[string]interface{}{"a": big.NewRat(617, 50), "b": third}, encoded)
+
+	schema = `{"type": "record", "name": "r", "fields": [
+		{"name": "a", "type": {"type": "fixed", "size": 2, "logicalType": "decimal", "precision": 4, "scale": 2}},
+		{"name": "b", "type": {"type": "fixed", "size": 8, "logicalType": "decimal", "precision": 18, "scale": 18}}]}`
+	encoded = []byte("\x04\xd2\x04\xa0\x3c\xe6\x8d\x21\x55\x55")
+	testBinaryEncodePass(t, schema, map[string]interface{}{"a": big.NewRat(617, 50), "b": big.NewRat(1, 3)}, encoded)
+	testBinaryDecodePass(t, schema, map[string]interface{}{"a": big.NewRat(617, 50), "b": third}, encoded)
+}
+
 func ExampleUnion_logicalType() {
 	// Supported logical types and their native go types:
 	// * timestamp-millis - time.Time
@@ -145,3 +287,304 @@ func ExampleUnion_logicalType() {
 	fmt.Printf("%#v\n", out["long.timestamp-millis"].(time.Time).String())
 	// Output: "2006-01-02 15:04:05 +0000 UTC"
 }
+
+type testMoney struct {
+	Currency string
+	Cents    int64
+}
+
+type testGeohash string
+
+func init() {
+	err := RegisterLogicalType("fixed", "ip-address",
+		func(datum interface{}) (interface{}, error) {
+			return net.IP(datum.([]byte)), nil
+		},
+		func(datum interface{}) (interface{}, error) {
+			ip, ok := datum.(net.IP)
+			if !ok || ip.To16() == nil {
+				return nil, fmt.Errorf("expected net.IP, received %T", datum)
+			}
+			return []byte(ip.To16()), nil
+		})
+	if err != nil {
+		panic(err)
+	}
+	err = RegisterLogicalType("record", "money",
+		func(datum interface{}) (interface{}, error) {
+			m := datum.(map[string]interface{})
+			return testMoney{Currency: m["currency"].(string), Cents: m["cents"].(int64)}, nil
+		},
+		func(datum interface{}) (interface{}, error) {
+			m, ok := datum.(testMoney)
+			if !ok {
+				return nil, fmt.Errorf("expected money, received %T", datum)
+			}
+			return map[string]interface{}{"currency": m.Currency, "cents": m.Cents}, nil
+		})
+	if err != nil {
+		panic(err)
+	}
+	err = RegisterLogicalType("string", "geohash",
+		func(datum interface{}) (interface{}, error) {
+			s := datum.(string)
+			if strings.Trim(s, "0123456789bcdefghjkmnpqrstuvwxyz") != "" {
+				return nil, fmt.Errorf("invalid geohash: %q", s)
+			}
+			return testGeohash(s), nil
+		},
+		func(datum interface{}) (interface{}, error) {
+			g, ok := datum.(testGeohash)
+			if !ok {
+				return nil, fmt.Errorf("expected geohash, received %T", datum)
+			}
+			return string(g), nil
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisterLogicalTypeErrors(t *testing.T) {
+	identity := func(datum interface{}) (interface{}, error) { return datum, nil }
+	ensureError(t, RegisterLogicalType("fixed", "ip-address", identity, identity), `cannot register logical type "ip-address": already registered`)
+	ensureError(t, RegisterLogicalType("int", "date", identity, identity), `already built in: "int.date"`)
+	ensureError(t, RegisterLogicalType("fixed", "decimal", identity, identity), `already built in: "fixed.decimal"`)
+	ensureError(t, RegisterLogicalType("text", "markdown", identity, identity), `unknown base type: "text"`)
+	ensureError(t, RegisterLogicalType("string", "", identity, identity), "name ought to be non-empty")
+	ensureError(t, RegisterLogicalType("string", "markdown", nil, identity), "conversion functions ought to be non-nil")
+}
+
+func TestCustomLogicalTypeFixed(t *testing.T) {
+	schema := `{"type": "fixed", "name": "ipv6", "size": 16, "logicalType": "ip-address"}`
+	encoded := []byte("\x20\x01\x0d\xb8\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01")
+	testBinaryCodecPass(t, schema, net.ParseIP("2001:db8::1"), encoded)
+	testTextCodecPass(t, schema, net.ParseIP("2001:db8::1"), []byte(`" \u0001\r\u00B8\u0000\u0000\u0000\u0000\u0000\u0000\u0000\u0000\u0000\u0000\u0000\u0001"`))
+	testBinaryEncodeFail(t, schema, "2001:db8::1", "cannot transform to binary ip-address: expected net.IP, received string")
+
+	// References to the named type use the logical type too.
+	schema = `{"type": "record", "name": "hop", "fields": [
+		{"name": "from", "type": {"type": "fixed", "name": "ipv6", "size": 16, "logicalType": "ip-address"}},
+		{"name": "to", "type": ["null", "ipv6"]}]}`
+	testBinaryCodecPass(t, schema, map[string]interface{}{
+		"from": net.ParseIP("2001:db8::1"),
+		"to":   Union("ipv6", net.ParseIP("2001:db8::1")),
+	}, append(append(append([]byte{}, encoded...), 2), encoded...))
+}
+
+func TestCustomLogicalTypeRecord(t *testing.T) {
+	schema := `{"type": "record", "name": "money", "logicalType": "money", "fields": [
+		{"name": "currency", "type": "string"},
+		{"name": "cents", "type": "long"}]}`
+	testBinaryCodecPass(t, schema, testMoney{Currency: "USD", Cents: 1234}, []byte("\x06USD\xa4\x13"))
+	testTextDecodePass(t, schema, testMoney{Currency: "USD", Cents: 1234}, []byte(`{"currency":"USD","cents":1234}`))
+	testTextEncodeFail(t, schema, "USD 12.34", "cannot transform to binary money: expected money, received string")
+	testBinaryEncodeFail(t, schema, map[string]interface{}{"currency": "USD", "cents": 1234}, "cannot transform to binary money: expected money, received map[string]interface {}")
+
+	// The logical type is kept by the schema of the codec.
+	codec, err := NewCodec(schema)
+	ensureError(t, err)
+	if !strings.Contains(codec.Schema(), `"logicalType": "money"`) {
+		t.Errorf("GOT: %v; WANT: %v", codec.Schema(), "logicalType")
+	}
+	if strings.Contains(codec.CanonicalSchema(), "logicalType") {
+		t.Errorf("GOT: %v; WANT: %v", codec.CanonicalSchema(), "no logicalType")
+	}
+}
+
+func TestCustomLogicalTypePrimitive(t *testing.T) {
+	schema := `{"type": "string", "logicalType": "geohash"}`
+	testBinaryCodecPass(t, schema, testGeohash("u4pruyd"), []byte("\x0eu4pruyd"))
+	testTextCodecPass(t, schema, testGeohash("u4pruyd"), []byte(`"u4pruyd"`))
+	testBinaryDecodeFail(t, schema, []byte("\x0eu4pruya"), `cannot transform to native geohash: invalid geohash: "u4pruya"`)
+	testBinaryEncodeFail(t, schema, "u4pruyd", "cannot transform to binary geohash: expected geohash, received string")
+
+	// Plain strings are not affected.
+	testBinaryCodecPass(t, `"string"`, "u4pruya", []byte("\x0eu4pruya"))
+
+	// The logical type is not part of the canonical form of the schema, so that
+	// it has the fingerprint of its base type.
+	codec, err := NewCodec(schema)
+	ensureError(t, err)
+	if actual, expected := codec.CanonicalSchema(), `"string"`; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	record, err := NewCodec(`{"type": "record", "name": "place", "fields": [{"name": "at", "type": {"type": "string", "logicalType": "geohash"}}]}`)
+	ensureError(t, err)
+	if actual, expected := record.CanonicalSchema(), `{"name":"place","type":"record","fields":[{"name":"at","type":"string"}]}`; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	schema = `["null", "string", {"type": "string", "logicalType": "geohash"}]`
+	testBinaryCodecPass(t, schema, Union("string.geohash", testGeohash("u4pruyd")), []byte("\x04\x0eu4pruyd"))
+	testBinaryCodecPass(t, schema, Union("string", "u4pruya"), []byte("\x02\x0eu4pruya"))
+}
+
+func TestCodecOptionTimeLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	option := &CodecOption{TimeLocation: tokyo}
+	decode := func(schema string, buf []byte) interface{} {
+		t.Helper()
+		codec, err := NewCodecWithOptions(schema, option)
+		ensureError(t, err)
+		datum, _, err := codec.NativeFromBinary(buf)
+		ensureError(t, err)
+		buf2, err := codec.BinaryFromNative(nil, datum)
+		ensureError(t, err)
+		if !bytes.Equal(buf2, buf) {
+			t.Errorf("GOT: %#v; WANT: %#v", buf2, buf)
+		}
+		return datum
+	}
+
+	// Timestamps decode to the same instant in the location.
+	datum := decode(`{"type": "long", "logicalType": "timestamp-millis"}`, []byte("\xfa\x82\xac\xba\x91\x42"))
+	if actual, expected := datum.(time.Time), time.Date(2006, 1, 3, 0, 04, 05, 565000000, tokyo); actual.String() != expected.String() {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	datum = decode(`{"type": "long", "logicalType": "timestamp-micros"}`, []byte("\xc6\x8d\xf7\xe7\xaf\xd8\x84\x04"))
+	if actual, expected := datum.(time.Time), time.Date(2006, 1, 3, 0, 04, 05, 565283000, tokyo); actual.String() != expected.String() {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	// Local timestamps decode to the same wall clock time in the location.
+	datum = decode(`{"type": "long", "logicalType": "local-timestamp-millis"}`, []byte("\xfa\x82\xac\xba\x91\x42"))
+	if actual, expected := datum.(time.Time), time.Date(2006, 1, 2, 15, 04, 05, 565000000, tokyo); actual.String() != expected.String() {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	// Dates decode to midnight of the same day in the location, which encodes
+	// to the same day even though it is the previous day in UTC.
+	datum = decode(`{"type": "int", "logicalType": "date"}`, []byte("\xbc\xcd\x01"))
+	if actual, expected := datum.(time.Time), time.Date(2006, 1, 2, 0, 0, 0, 0, tokyo); actual.String() != expected.String() {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	// Textual data decodes to the location too.
+	codec, err := NewCodecWithOptions(`{"type": "long", "logicalType": "timestamp-millis"}`, option)
+	ensureError(t, err)
+	datum, _, err = codec.NativeFromTextual([]byte("1136214245565"))
+	ensureError(t, err)
+	if actual, expected := datum.(time.Time), time.Date(2006, 1, 3, 0, 04, 05, 565000000, tokyo); actual.String() != expected.String() {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	// Without options, times decode to UTC.
+	codec, err = NewCodecWithOptions(`{"type": "long", "logicalType": "timestamp-millis"}`, nil)
+	ensureError(t, err)
+	datum, _, err = codec.NativeFromBinary([]byte("\xfa\x82\xac\xba\x91\x42"))
+	ensureError(t, err)
+	if actual, expected := datum.(time.Time).Location(), time.UTC; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+}
+
+func TestCodecOptionRawTime(t *testing.T) {
+	option := &CodecOption{RawTime: true}
+	cases := []struct {
+		schema  string
+		raw     interface{}
+		native  interface{}
+		encoded string
+	}{
+		{`{"type": "int", "logicalType": "date"}`, int32(13150), time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC), "\xbc\xcd\x01"},
+		{`{"type": "int", "logicalType": "time-millis"}`, int32(66904022), 66904022 * time.Millisecond, "\xac\xff\xe6\x3f"},
+		{`{"type": "long", "logicalType": "time-micros"}`, int64(66904022566), 66904022566 * time.Microsecond, "\xcc\xf8\xd2\xbc\xf2\x03"},
+		{`{"type": "long", "logicalType": "timestamp-millis"}`, int64(1136214245565), time.Date(2006, 1, 2, 15, 04, 05, 565000000, time.UTC), "\xfa\x82\xac\xba\x91\x42"},
+		{`{"type": "long", "logicalType": "timestamp-micros"}`, int64(1136214245565283), time.Date(2006, 1, 2, 15, 04, 05, 565283000, time.UTC), "\xc6\x8d\xf7\xe7\xaf\xd8\x84\x04"},
+		{`{"type": "long", "logicalType": "local-timestamp-millis"}`, int64(1136214245565), time.Date(2006, 1, 2, 15, 04, 05, 565000000, time.UTC), "\xfa\x82\xac\xba\x91\x42"},
+		{`{"type": "long", "logicalType": "local-timestamp-micros"}`, int64(1136214245565283), time.Date(2006, 1, 2, 15, 04, 05, 565283000, time.UTC), "\xc6\x8d\xf7\xe7\xaf\xd8\x84\x04"},
+	}
+	for _, c := range cases {
+		codec, err := NewCodecWithOptions(c.schema, option)
+		ensureError(t, err)
+		datum, _, err := codec.NativeFromBinary([]byte(c.encoded))
+		ensureError(t, err)
+		if datum != c.raw {
+			t.Errorf("schema: %s; GOT: %#v; WANT: %#v", c.schema, datum, c.raw)
+		}
+		datum, _, err = codec.NativeFromTextual([]byte(fmt.Sprint(c.raw)))
+		ensureError(t, err)
+		if datum != c.raw {
+			t.Errorf("schema: %s; GOT: %#v; WANT: %#v", c.schema, datum, c.raw)
+		}
+
+		// Both the raw and the time values encode, whatever the options.
+		testBinaryEncodePass(t, c.schema, c.raw, []byte(c.encoded))
+		testBinaryEncodePass(t, c.schema, c.native, []byte(c.encoded))
+		testTextEncodePass(t, c.schema, c.raw, []byte(fmt.Sprint(c.raw)))
+		for _, datum := range []interface{}{c.raw, c.native} {
+			buf, err := codec.BinaryFromNative(nil, datum)
+			ensureError(t, err)
+			if string(buf) != c.encoded {
+				t.Errorf("schema: %s; GOT: %#v; WANT: %#v", c.schema, buf, c.encoded)
+			}
+		}
+	}
+}
+
+func TestDateLogicalTypeEncodesUTCDate(t *testing.T) {
+	schema := `{"type": "int", "logicalType": "date"}`
+	// The date of a time is the one of its instant in UTC, 2020-01-01.
+	eastern := time.Date(2020, 1, 2, 1, 0, 0, 0, time.FixedZone("+05", 5*60*60))
+	testBinaryEncodePass(t, schema, eastern, []byte("\xac\x9d\x02"))
+
+	// A codec with a location encodes the date of the time in the location,
+	// 2020-01-02, like the dates it decodes.
+	codec, err := NewCodecWithOptions(schema, &CodecOption{TimeLocation: eastern.Location()})
+	ensureError(t, err)
+	buf, err := codec.BinaryFromNative(nil, eastern)
+	ensureError(t, err)
+	if actual, expected := buf, []byte("\xae\x9d\x02"); !bytes.Equal(actual, expected) {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+}
+
+func TestCodecOptionUUIDBytes(t *testing.T) {
+	uuid := "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+	raw := [16]byte{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}
+	codec, err := NewCodecWithOptions(`{"type": "string", "logicalType": "uuid"}`, &CodecOption{UUIDBytes: true})
+	ensureError(t, err)
+
+	datum, _, err := codec.NativeFromBinary([]byte("\x48" + uuid))
+	ensureError(t, err)
+	if datum != raw {
+		t.Errorf("GOT: %#v; WANT: %#v", datum, raw)
+	}
+	datum, _, err = codec.NativeFromTextual([]byte(`"` + uuid + `"`))
+	ensureError(t, err)
+	if datum != raw {
+		t.Errorf("GOT: %#v; WANT: %#v", datum, raw)
+	}
+	_, _, err = codec.NativeFromBinary([]byte("\x06abc"))
+	ensureError(t, err, "cannot transform to native uuid")
+
+	// Both the string and the [16]byte values encode, whatever the options.
+	for _, datum := range []interface{}{uuid, raw} {
+		buf, err := codec.BinaryFromNative(nil, datum)
+		ensureError(t, err)
+		if actual, expected := string(buf), "\x48"+uuid; actual != expected {
+			t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+		}
+	}
+}
+
+func TestLogicalTypeFingerprint(t *testing.T) {
+	// NOTE: The logicalType attribute is not part of the parsing canonical
+	// form, so these are the fingerprints of "string" and of a record with a
+	// string field, which goavro has always computed for these schemas.
+	cases := []struct {
+		schema   string
+		expected int64
+	}{
+		{`{"type": "string", "logicalType": "uuid"}`, -8142146995180207161},
+		{`{"type": "record", "name": "r", "fields": [{"name": "id", "type": {"type": "string", "logicalType": "uuid"}}]}`, 70980697466832957},
+	}
+	for _, c := range cases {
+		codec, err := NewCodec(c.schema)
+		ensureError(t, err)
+		if actual := codec.SchemaCRC64Avro(); actual != c.expected {
+			t.Errorf("schema: %s; GOT: %v; WANT: %v", c.schema, actual, c.expected)
+		}
+	}
+}
@@ -28,22 +28,75 @@ func makeMapCodec(st map[string]*Codec, namespace string, schemaMap map[string]i
 		return nil, fmt.Errorf("Map values ought to be valid Avro type: %s", err)
 	}
 
-	return &Codec{
-		typeName: &name{"map", nullNamespace},
-		nativeFromBinary: func(buf []byte) (interface{}, []byte, error) {
-			var err error
-			var value interface{}
+	// mapNativeFromBinary decodes a binary map, recycling the provided map after
+	// removing its keys when it is not nil.
+	mapNativeFromBinary := func(buf []byte, mapValues map[string]interface{}) (interface{}, []byte, error) {
+		var err error
+		var value interface{}
 
-			// block count and block size
+		// block count and block size
+		if value, buf, err = longNativeFromBinary(buf); err != nil {
+			return nil, nil, fmt.Errorf("cannot decode binary map block count: %s", err)
+		}
+		blockCount := value.(int64)
+		if blockCount < 0 {
+			// NOTE: A negative block count implies there is a long encoded
+			// block size following the negative block count. We have no use
+			// for the block size in this decoder, so we read and discard
+			// the value.
+			if blockCount == math.MinInt64 {
+				// The minimum number for any signed numerical type can
+				// never be made positive
+				return nil, nil, fmt.Errorf("cannot decode binary map with block count: %d", blockCount)
+			}
+			blockCount = -blockCount // convert to its positive equivalent
+			if _, buf, err = longNativeFromBinary(buf); err != nil {
+				return nil, nil, fmt.Errorf("cannot decode binary map block size: %s", err)
+			}
+		}
+		// Ensure block count does not exceed some sane value.
+		if blockCount > MaxBlockCount {
+			return nil, nil, fmt.Errorf("cannot decode binary map when block count exceeds MaxBlockCount: %d > %d", blockCount, MaxBlockCount)
+		}
+		// NOTE: While the attempt of a RAM optimization shown below is not
+		// necessary, many encoders will encode all items in a single block.
+		// We can optimize amount of RAM allocated by runtime for the array
+		// by initializing the array for that number of items.
+		if mapValues == nil {
+			mapValues = make(map[string]interface{}, blockCount)
+		} else {
+			for key := range mapValues {
+				delete(mapValues, key)
+			}
+		}
+
+		for blockCount != 0 {
+			// Decode `blockCount` datum values from buffer
+			for i := int64(0); i < blockCount; i++ {
+				// first decode the key string
+				if value, buf, err = stringNativeFromBinary(buf); err != nil {
+					return nil, nil, fmt.Errorf("cannot decode binary map key: %s", err)
+				}
+				key := value.(string) // string decoder always returns a string
+				if _, ok := mapValues[key]; ok {
+					return nil, nil, fmt.Errorf("cannot decode binary map: duplicate key: %q", key)
+				}
+				// then decode the value
+				if value, buf, err = valueCodec.nativeFromBinary(buf); err != nil {
+					return nil, nil, fmt.Errorf("cannot decode binary map value for key %q: %s", key, err)
+				}
+				mapValues[key] = value
+			}
+			// Decode next blockCount from buffer, because there may be more blocks
 			if value, buf, err = longNativeFromBinary(buf); err != nil {
 				return nil, nil, fmt.Errorf("cannot decode binary map block count: %s", err)
 			}
-			blockCount := value.(int64)
+			blockCount = value.(int64)
 			if blockCount < 0 {
-				// NOTE: A negative block count implies there is a long encoded
-				// block size following the negative block count. We have no use
-				// for the block size in this decoder, so we read and discard
-				// the value.
+				// NOTE: A negative block count implies there is a long
+				// encoded block size following the negative block count. We
+				// have no use for the block size in this decoder, so we
+				// read and discard the value.
 				if blockCount == math.MinInt64 {
 					// The minimum number for any signed numerical type can
 					// never be made positive
@@ -58,55 +111,19 @@ func makeMapCodec(st map[string]*Codec, namespace string, schemaMap map[string]i
 			if blockCount > MaxBlockCount {
 				return nil, nil, fmt.Errorf("cannot decode binary map when block count exceeds MaxBlockCount: %d > %d", blockCount, MaxBlockCount)
 			}
-			// NOTE: While the attempt of a RAM optimization shown below is not
-			// necessary, many encoders will encode all items in a single block.
-			// We can optimize amount of RAM allocated by runtime for the array
-			// by initializing the array for that number of items.
-			mapValues := make(map[string]interface{}, blockCount)
+		}
+		return mapValues, buf, nil
+	}
 
-			for blockCount != 0 {
-				// Decode `blockCount` datum values from buffer
-				for i := int64(0); i < blockCount; i++ {
-					// first decode the key string
-					if value, buf, err = stringNativeFromBinary(buf); err != nil {
-						return nil, nil, fmt.Errorf("cannot decode binary map key: %s", err)
-					}
-					key := value.(string) // string decoder always returns a string
-					if _, ok := mapValues[key]; ok {
-						return nil, nil, fmt.Errorf("cannot decode binary map: duplicate key: %q", key)
-					}
-					// then decode the value
-					if value, buf, err = valueCodec.nativeFromBinary(buf); err != nil {
-						return nil, nil, fmt.Errorf("cannot decode binary map value for key %q: %s", key, err)
-					}
-					mapValues[key] = value
-				}
-				// Decode next blockCount from buffer, because there may be more blocks
-				if value, buf, err = longNativeFromBinary(buf); err != nil {
-					return nil, nil, fmt.Errorf("cannot decode binary map block count: %s", err)
-				}
-				blockCount = value.(int64)
-				if blockCount < 0 {
-					// NOTE: A negative block count implies there is a long
-					// encoded block size following the negative block count. We
-					// have no use for the block size in this decoder, so we
-					// read and discard the value.
-					if blockCount == math.MinInt64 {
-						// The minimum number for any signed numerical type can
-						// never be made positive
-						return nil, nil, fmt.Errorf("cannot decode binary map with block count: %d", blockCount)
-					}
-					blockCount = -blockCount // convert to its positive equivalent
-					if _, buf, err = longNativeFromBinary(buf); err != nil {
-						return nil, nil, fmt.Errorf("cannot decode binary map block size: %s", err)
-					}
-				}
-				// Ensure block count does not exceed some sane value.
-				if blockCount > MaxBlockCount {
-					return nil, nil, fmt.Errorf("cannot decode binary map when block count exceeds MaxBlockCount: %d > %d", blockCount, MaxBlockCount)
-				}
-			}
-			return mapValues, buf, nil
+	return &Codec{
+		typeName:      &name{"map", nullNamespace},
+		elementCodecs: []*Codec{valueCodec},
+		nativeFromBinary: func(buf []byte) (interface{}, []byte, error) {
+			return mapNativeFromBinary(buf, nil)
+		},
+		nativeFromBinaryReuse: func(buf []byte, datum interface{}) (interface{}, []byte, error) {
+			recycled, _ := datum.(map[string]interface{})
+			return mapNativeFromBinary(buf, recycled)
 		},
 		binaryFromNative: func(buf []byte, datum interface{}) ([]byte, error) {
 			mapValues, err := convertMap(datum)
@@ -146,6 +163,7 @@ func makeMapCodec(st map[string]*Codec, namespace string, schemaMap map[string]i
 		textualFromNative: func(buf []byte, datum interface{}) ([]byte, error) {
 			return genericMapTextEncoder(buf, datum, valueCodec, nil)
 		},
+		generator: NewMapCodecGenerator(valueCodec),
 	}, nil
 }
 
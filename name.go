@@ -121,8 +121,13 @@ func newNameFromSchemaMap(enclosingNamespace string, schemaMap map[string]interf
 	}
 	if namespace, ok := schemaMap["namespace"]; ok {
 		namespaceString, ok = namespace.(string)
-		if !ok || namespaceString == nullNamespace {
-			return nil, fmt.Errorf("schema namespace, if provided, ought to be non-empty string; received: %T: %v", namespace, namespace)
+		if !ok {
+			return nil, fmt.Errorf("schema namespace, if provided, ought to be string; received: %T: %v", namespace, namespace)
+		}
+		if namespaceString == nullNamespace {
+			// NOTE: The empty namespace is the null namespace, rather than
+			// the enclosing namespace.
+			return newName(nameString, nullNamespace, nullNamespace)
 		}
 	}
 
@@ -82,3 +82,19 @@ func TestNameWithoutDotsButWithEmptyNamespaceAndEnclosingName(t *testing.T) {
 		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
 	}
 }
+
+func TestNameFromSchemaMapWithEmptyNamespace(t *testing.T) {
+	n, err := newNameFromSchemaMap("org.foo", map[string]interface{}{"name": "X", "namespace": ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := n.fullName, "X"; actual != expected {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+	if actual, expected := n.namespace, nullNamespace; actual != expected {
+		t.Errorf("GOT: %#v; WANT: %#v", actual, expected)
+	}
+
+	_, err = newNameFromSchemaMap("org.foo", map[string]interface{}{"name": "X", "namespace": 13})
+	ensureError(t, err, "namespace", "ought to be string")
+}
@@ -0,0 +1,251 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// NamedTypes is a registry of the named types, that is the records, enums and
+// fixed types, that many schemas define, so that a schema may refer to named
+// types that other schemas define, such as those of other .avsc files.
+//
+//     types := goavro.NewNamedTypes()
+//     _, err := types.NewCodec(`{"type":"record","name":"com.acme.Address","fields":[{"name":"city","type":"string"}]}`)
+//     if err != nil {
+//         return err
+//     }
+//     codec, err := types.NewCodec(`{"type":"record","name":"com.acme.Customer","fields":[{"name":"address","type":"com.acme.Address"}]}`)
+//
+// A NamedTypes may be used by multiple goroutines.
+type NamedTypes struct {
+	lock        sync.Mutex
+	symbolTable map[string]*Codec
+	definitions map[string]string // definitions of the named types by full name
+}
+
+// NewNamedTypes returns an empty registry of named types.
+func NewNamedTypes() *NamedTypes {
+	return &NamedTypes{
+		symbolTable: newSymbolTable(),
+		definitions: make(map[string]string),
+	}
+}
+
+// NewCodec returns a Codec for the schema, which may refer to the named types
+// of the registry, and adds the named types the schema defines to the
+// registry.
+//
+// When the schema refers to named types that other schemas define, the Schema
+// of the codec is the schema with the definitions of those types inlined, so
+// that its data may be read without the registry.
+//
+// A schema may define a named type of the registry again, provided it defines
+// it the same way once every name is fully qualified. Otherwise NewCodec
+// returns an error, like it does when the schema is invalid, and adds none of
+// the named types the schema defines.
+func (n *NamedTypes) NewCodec(schemaSpecification string) (*Codec, error) {
+	var schema interface{}
+	if err := json.Unmarshal([]byte(schemaSpecification), &schema); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal schema JSON: %s", err)
+	}
+	definitions := make(map[string]string)
+	namedTypeDefinitions(nullNamespace, schema, definitions)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	// NOTE: The schema is built with a copy of the symbol table, so that the
+	// registry is unchanged when it is invalid. The named types it defines
+	// again are removed from the copy, because building a named type that is
+	// already in the symbol table modifies its codec, which other codecs use.
+	st := make(map[string]*Codec, len(n.symbolTable))
+	for k, v := range n.symbolTable {
+		st[k] = v
+	}
+	for fullName, definition := range definitions {
+		if existing, ok := n.definitions[fullName]; ok {
+			if existing != definition {
+				return nil, fmt.Errorf("cannot define %q again: ought to match its existing definition: %s; received: %s", fullName, existing, definition)
+			}
+			delete(st, fullName)
+		}
+	}
+
+	c, err := newCodecWithSymbolTable(schemaSpecification, st)
+	if err != nil {
+		return nil, err
+	}
+	if standalone, inlined := inlineNamedTypes(st, nullNamespace, schema); inlined {
+		// NOTE: The schema refers to named types that other schemas define, so
+		// the codec has a schema in which they are inlined, which may be read
+		// without the registry, such as from the header of an OCF file.
+		original, err := json.Marshal(standalone)
+		if err != nil {
+			return nil, fmt.Errorf("cannot inline named types: %s", err)
+		}
+		c.schemaOriginal = string(original)
+		if c.schemaCanonical, err = parsingCanonicalForm(standalone); err != nil {
+			return nil, fmt.Errorf("cannot inline named types: %s", err)
+		}
+	}
+	// NOTE: Only the named types the schema defines are added to the symbol
+	// table of the registry, so that no other codec built along the way, such
+	// as that of a type without a name, is used by later schemas.
+	symbolTable := make(map[string]*Codec, len(n.symbolTable)+len(definitions))
+	for k, v := range n.symbolTable {
+		symbolTable[k] = v
+	}
+	for fullName, definition := range definitions {
+		named, ok := st[fullName]
+		if !ok {
+			continue // should not get here because the schema defines it
+		}
+		symbolTable[fullName] = named
+		if named.schemaOriginal == "" {
+			// NOTE: Named types defined within another type have a schema of
+			// their own, so that their codecs may be used by themselves.
+			schema := standaloneSchema(st, named)
+			original, err := json.Marshal(schema)
+			if err != nil {
+				return nil, fmt.Errorf("cannot define %q: %s", fullName, err)
+			}
+			named.schemaOriginal = string(original)
+			if named.schemaCanonical, err = parsingCanonicalForm(schema); err != nil {
+				return nil, fmt.Errorf("cannot define %q: %s", fullName, err)
+			}
+		}
+		n.definitions[fullName] = definition
+	}
+	n.symbolTable = symbolTable
+	return c, nil
+}
+
+// Add adds the named types the schemas define to the registry. Unlike NewCodec,
+// a schema may refer to named types defined by schemas that follow it. When
+// some schemas cannot be added, Add returns the error of the first of them,
+// and the named types of the others are added nonetheless.
+func (n *NamedTypes) Add(schemaSpecifications ...string) error {
+	pending := make([]int, len(schemaSpecifications))
+	for i := range pending {
+		pending[i] = i
+	}
+	for {
+		var failed []int
+		var firstErr error
+		for _, i := range pending {
+			if _, err := n.NewCodec(schemaSpecifications[i]); err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("cannot add schema %d: %s", i+1, err)
+				}
+				failed = append(failed, i)
+			}
+		}
+		if len(failed) == 0 {
+			return nil
+		}
+		if len(failed) == len(pending) {
+			return firstErr // no schema was added during this pass
+		}
+		pending = failed
+	}
+}
+
+// Codec returns the Codec of the named type of the registry with the full
+// name.
+func (n *NamedTypes) Codec(fullName string) (*Codec, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if _, ok := n.definitions[fullName]; !ok {
+		return nil, fmt.Errorf("cannot find named type %q", fullName)
+	}
+	return n.symbolTable[fullName], nil
+}
+
+// Names returns the sorted full names of the named types of the registry.
+func (n *NamedTypes) Names() []string {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	names := make([]string, 0, len(n.definitions))
+	for fullName := range n.definitions {
+		names = append(names, fullName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// namedTypeDefinitions adds the definition of each named type the schema
+// defines to definitions, by its full name. A definition is the parsing
+// canonical form of the named type with every name fully qualified, and with
+// the named types it defines in turn referred to by name, so that a named type
+// is defined the same way whether or not the types it refers to are defined
+// within it.
+func namedTypeDefinitions(enclosingNamespace string, schema interface{}, definitions map[string]string) {
+	var walk func(enclosingNamespace string, schema interface{}) interface{}
+	walk = func(enclosingNamespace string, schema interface{}) interface{} {
+		switch v := schema.(type) {
+		case string:
+			return qualifySchemaReference(enclosingNamespace, v)
+		case []interface{}:
+			members := make([]interface{}, len(v))
+			for i, member := range v {
+				members[i] = walk(enclosingNamespace, member)
+			}
+			return members
+		case map[string]interface{}:
+			result := make(map[string]interface{}, len(v))
+			for key, value := range v {
+				result[key] = value
+			}
+			switch t := v["type"]; t {
+			case "record", "error", "enum", "fixed":
+				n, err := newNameFromSchemaMap(enclosingNamespace, v)
+				if err != nil {
+					return v // invalid schemas are reported when building their codecs
+				}
+				result["name"] = n.fullName
+				delete(result, "namespace")
+				if fields, ok := v["fields"].([]interface{}); ok {
+					resultFields := make([]interface{}, len(fields))
+					for i, field := range fields {
+						fieldMap, ok := field.(map[string]interface{})
+						if !ok {
+							resultFields[i] = field
+							continue
+						}
+						resultField := make(map[string]interface{}, len(fieldMap))
+						for key, value := range fieldMap {
+							resultField[key] = value
+						}
+						resultField["type"] = walk(n.namespace, fieldMap["type"])
+						resultFields[i] = resultField
+					}
+					result["fields"] = resultFields
+				}
+				if definition, err := parsingCanonicalForm(result); err == nil {
+					definitions[n.fullName] = definition
+				}
+				return n.fullName
+			case "array":
+				result["items"] = walk(enclosingNamespace, v["items"])
+			case "map":
+				result["values"] = walk(enclosingNamespace, v["values"])
+			default:
+				result["type"] = walk(enclosingNamespace, t)
+			}
+			return result
+		}
+		return schema
+	}
+	walk(enclosingNamespace, schema)
+}
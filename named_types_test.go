@@ -0,0 +1,197 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+const (
+	testAddressSchema  = `{"type":"record","name":"Address","namespace":"com.acme","fields":[{"name":"city","type":"string"}]}`
+	testCustomerSchema = `{"type":"record","name":"com.acme.Customer","fields":[{"name":"name","type":"string"},{"name":"address","type":"Address"}]}`
+)
+
+func TestNamedTypesNewCodec(t *testing.T) {
+	types := NewNamedTypes()
+
+	_, err := types.NewCodec(testCustomerSchema)
+	ensureError(t, err, "Address")
+
+	_, err = types.NewCodec(testAddressSchema)
+	ensureError(t, err)
+	c, err := types.NewCodec(testCustomerSchema)
+	ensureError(t, err)
+
+	buf, err := c.BinaryFromNative(nil, map[string]interface{}{
+		"name":    "Ann",
+		"address": map[string]interface{}{"city": "Oslo"},
+	})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x06Ann\x08Oslo"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+	// NOTE: The schema of the codec inlines the named types it refers to.
+	if got, want := c.Schema(), `{"fields":[{"name":"name","type":"string"},{"name":"address","type":{"fields":[{"name":"city","type":"string"}],"name":"com.acme.Address","type":"record"}}],"name":"com.acme.Customer","type":"record"}`; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	if got, want := types.Names(), []string{"com.acme.Address", "com.acme.Customer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestNamedTypesRedefinition(t *testing.T) {
+	types := NewNamedTypes()
+	address, err := types.NewCodec(testAddressSchema)
+	ensureError(t, err)
+
+	// NOTE: The same definition, with its name qualified differently.
+	_, err = types.NewCodec(`{"type":"record","name":"com.acme.Address","doc":"An address.","fields":[{"name":"city","type":"string"}]}`)
+	ensureError(t, err)
+
+	_, err = types.NewCodec(`{"type":"record","name":"com.acme.Address","fields":[{"name":"city","type":"string"},{"name":"zip","type":"string"}]}`)
+	ensureError(t, err, "cannot define \"com.acme.Address\" again")
+
+	// NOTE: A schema that defines a named type again within another is
+	// rejected as a whole.
+	_, err = types.NewCodec(`{"type":"record","name":"com.acme.Order","fields":[{"name":"to","type":{"type":"record","name":"com.acme.Address","fields":[{"name":"street","type":"string"}]}}]}`)
+	ensureError(t, err, "cannot define \"com.acme.Address\" again")
+	if got, want := types.Names(), []string{"com.acme.Address"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	// NOTE: Codecs built before a named type is defined again still work.
+	buf, err := address.BinaryFromNative(nil, map[string]interface{}{"city": "Oslo"})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x08Oslo"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+}
+
+func TestNamedTypesInvalidSchemaAddsNothing(t *testing.T) {
+	types := NewNamedTypes()
+	_, err := types.NewCodec(`{"type":"record","name":"com.acme.Order","fields":[{"name":"to","type":{"type":"fixed","name":"Id","size":4}},{"name":"bad","type":"Missing"}]}`)
+	ensureError(t, err, "Missing")
+	if got := types.Names(); len(got) != 0 {
+		t.Errorf("GOT: %v; WANT: %v", got, []string{})
+	}
+	_, err = types.Codec("com.acme.Id")
+	ensureError(t, err, "cannot find named type")
+
+	_, err = types.NewCodec(`{`)
+	ensureError(t, err, "cannot unmarshal schema JSON")
+}
+
+func TestNamedTypesAdd(t *testing.T) {
+	types := NewNamedTypes()
+	ensureError(t, types.Add(testCustomerSchema, `{"type":"enum","name":"Kind","symbols":["A"]}`, testAddressSchema))
+	if got, want := types.Names(), []string{"Kind", "com.acme.Address", "com.acme.Customer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	err := types.Add(`{"type":"record","name":"R","fields":[{"name":"f","type":"Missing"}]}`, `{"type":"fixed","name":"F","size":1}`)
+	ensureError(t, err, "cannot add schema 1", "Missing")
+	if got, want := types.Names(), []string{"F", "Kind", "com.acme.Address", "com.acme.Customer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestNamedTypesCodecOfNestedType(t *testing.T) {
+	types := NewNamedTypes()
+	_, err := types.NewCodec(`{"type":"record","name":"Order","namespace":"com.acme","fields":[{"name":"to","type":{"type":"record","name":"Address","fields":[{"name":"kind","type":{"type":"enum","name":"Kind","symbols":["HOME","WORK"]}}]}}]}`)
+	ensureError(t, err)
+
+	c, err := types.Codec("com.acme.Address")
+	ensureError(t, err)
+	if got, want := c.CanonicalSchema(), `{"name":"com.acme.Address","type":"record","fields":[{"name":"kind","type":{"name":"com.acme.Kind","type":"enum","symbols":["HOME","WORK"]}}]}`; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	buf, err := c.BinaryFromNative(nil, map[string]interface{}{"kind": "WORK"})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x02"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+}
+
+func TestNamedTypesCodecWritesReadableOCF(t *testing.T) {
+	types := NewNamedTypes()
+	ensureError(t, types.Add(testAddressSchema))
+	c, err := types.NewCodec(testCustomerSchema)
+	ensureError(t, err)
+
+	buf := new(bytes.Buffer)
+	ocfw, err := NewOCFWriter(OCFConfig{W: buf, Codec: c})
+	ensureError(t, err)
+	customer := map[string]interface{}{
+		"name":    "Ann",
+		"address": map[string]interface{}{"city": "Oslo"},
+	}
+	ensureError(t, ocfw.Append([]interface{}{customer}))
+
+	ocfr, err := NewOCFReader(buf)
+	ensureError(t, err)
+	var got []interface{}
+	for ocfr.Scan() {
+		datum, err := ocfr.Read()
+		ensureError(t, err)
+		got = append(got, datum)
+	}
+	ensureError(t, ocfr.Err())
+	if want := []interface{}{customer}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestNamedTypesCodecFingerprint(t *testing.T) {
+	types := NewNamedTypes()
+	ensureError(t, types.Add(testAddressSchema))
+	c, err := types.NewCodec(testCustomerSchema)
+	ensureError(t, err)
+
+	// NOTE: The same schema, defining the type it refers to.
+	standalone, err := NewCodec(`{"type":"record","name":"com.acme.Customer","fields":[{"name":"name","type":"string"},{"name":"address","type":` + testAddressSchema + `}]}`)
+	ensureError(t, err)
+
+	if got, want := c.CanonicalSchema(), standalone.CanonicalSchema(); got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	got, err := c.Fingerprint(FingerprintCRC64Avro)
+	ensureError(t, err)
+	want, err := standalone.Fingerprint(FingerprintCRC64Avro)
+	ensureError(t, err)
+	if !bytes.Equal(got, want) {
+		t.Errorf("GOT: %x; WANT: %x", got, want)
+	}
+}
+
+func TestNamedTypesDecimalsOfDifferentSchemas(t *testing.T) {
+	types := NewNamedTypes()
+	_, err := types.NewCodec(`{"type":"record","name":"A","fields":[{"name":"d","type":{"type":"bytes","logicalType":"decimal","precision":4,"scale":2}}]}`)
+	ensureError(t, err)
+	c, err := types.NewCodec(`{"type":"record","name":"B","fields":[{"name":"d","type":{"type":"bytes","logicalType":"decimal","precision":38,"scale":18}}]}`)
+	ensureError(t, err)
+
+	buf, err := c.BinaryFromNative(nil, map[string]interface{}{"d": big.NewRat(1, 3)})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x10\x04\xa0\x3c\xe6\x8d\x21\x55\x55"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+
+	// NOTE: The registry only keeps the named types, besides the primitives.
+	primitives := newSymbolTable()
+	for k := range types.symbolTable {
+		if _, ok := primitives[k]; !ok && k != "A" && k != "B" {
+			t.Errorf("GOT: %v; WANT: %v", k, types.Names())
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 )
 
 const (
@@ -214,6 +215,9 @@ func writeOCFHeader(header *ocfHeader, iow io.Writer) (err error) {
 	//
 	// file metadata, including the schema
 	//
+	if err = checkReservedMetadata(header.metadata, schema, avroCodec); err != nil {
+		return fmt.Errorf("cannot write OCF header: %s", err)
+	}
 	meta := make(map[string]interface{})
 	for k, v := range header.metadata {
 		meta[k] = v
@@ -238,3 +242,117 @@ func writeOCFHeader(header *ocfHeader, iow io.Writer) (err error) {
 	}
 	return nil
 }
+
+// scanOCFBlocks reads the blocks of an OCF stream that follow its header,
+// validating the block framing and sync markers without decoding any datum
+// values. When visit is not nil it is invoked with the count and size of each
+// block. When readBlocks is true, the raw, possibly compressed, block bytes are
+// read and provided to visit; otherwise the block bytes are discarded and visit
+// is provided a nil byte slice. It returns nil upon reaching the end of the
+// stream at a block boundary.
+func scanOCFBlocks(ior io.Reader, header *ocfHeader, readBlocks bool, visit func(blockCount, blockSize int64, block []byte) error) error {
+	sync := make([]byte, ocfSyncLength)
+	for {
+		// Read and validate block count
+		blockCount, err := longBinaryReader(ior)
+		if err != nil {
+			if err == io.EOF {
+				return nil // merely end of file, rather than error
+			}
+			return fmt.Errorf("cannot read block count: %s", err)
+		}
+		if blockCount <= 0 {
+			return fmt.Errorf("cannot read when block count is not greater than 0: %d", blockCount)
+		}
+		if blockCount > MaxBlockCount {
+			return fmt.Errorf("cannot read when block count exceeds MaxBlockCount: %d > %d", blockCount, MaxBlockCount)
+		}
+		// Read block size
+		blockSize, err := longBinaryReader(ior)
+		if err != nil {
+			return fmt.Errorf("cannot read block size: %s", err)
+		}
+		if blockSize <= 0 {
+			return fmt.Errorf("cannot read when block size is not greater than 0: %d", blockSize)
+		}
+		if blockSize > MaxBlockSize {
+			return fmt.Errorf("cannot read when block size exceeds MaxBlockSize: %d > %d", blockSize, MaxBlockSize)
+		}
+		var block []byte
+		if readBlocks {
+			// Read entire block into buffer
+			block = make([]byte, blockSize)
+			if _, err = io.ReadFull(ior, block); err != nil {
+				return fmt.Errorf("cannot read block: %s", err)
+			}
+		} else {
+			// Advance reader to end of block
+			if _, err = io.CopyN(ioutil.Discard, ior, blockSize); err != nil {
+				return fmt.Errorf("cannot seek to next block: %s", err)
+			}
+		}
+		// Read and validate sync marker
+		var n int
+		if n, err = io.ReadFull(ior, sync); err != nil {
+			return fmt.Errorf("cannot read sync marker: read %d out of %d bytes: %s", n, ocfSyncLength, err)
+		}
+		if !bytes.Equal(sync, header.syncMarker[:]) {
+			return fmt.Errorf("sync marker mismatch: %v != %v", sync, header.syncMarker)
+		}
+		if visit != nil {
+			if err = visit(blockCount, blockSize, block); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// checkReservedMetadata returns an error when application specific metadata
+// would override either of the metadata keys the OCF header uses to store its
+// schema and compression algorithm. Reserved keys are tolerated when their
+// values match what the header will store, so the metadata returned by
+// OCFReader.MetaData may be modified and written back.
+func checkReservedMetadata(metadata map[string][]byte, schema, avroCodec string) error {
+	if value, ok := metadata["avro.schema"]; ok && string(value) != schema {
+		return errors.New("metadata ought not override reserved key: \"avro.schema\"")
+	}
+	if value, ok := metadata["avro.codec"]; ok && string(value) != avroCodec {
+		return fmt.Errorf("metadata ought not override reserved key: \"avro.codec\": %q != %q", value, avroCodec)
+	}
+	return nil
+}
+
+// RewriteOCFMetadata copies the Avro Object Container File (OCF) read from ior
+// to iow, replacing the application specific metadata in its header with the
+// provided metadata. The schema, compression algorithm, and sync marker of the
+// original header are preserved, and the data blocks that follow the header are
+// copied unchanged, without being decompressed or decoded.
+//
+// This is the way to update metadata, such as record counts or lineage
+// identifiers, after an OCF has been written, because OCFWriter ignores
+// OCFConfig.MetaData when appending to an existing OCF. The length of the
+// header changes along with its metadata, so the rewritten OCF ought to be
+// written to a new file rather than over the original one.
+//
+//     func updateRowCount(src io.Reader, dst io.Writer, rows int) error {
+//         return goavro.RewriteOCFMetadata(src, dst, map[string][]byte{
+//             "rows": []byte(strconv.Itoa(rows)),
+//         })
+//     }
+//
+// The keys "avro.schema" and "avro.codec" are reserved, and may only be
+// included in metadata when their values match the original header.
+func RewriteOCFMetadata(ior io.Reader, iow io.Writer, metadata map[string][]byte) error {
+	header, err := readOCFHeader(ior)
+	if err != nil {
+		return fmt.Errorf("cannot rewrite OCF metadata: %s", err)
+	}
+	header.metadata = metadata
+	if err = writeOCFHeader(header, iow); err != nil {
+		return fmt.Errorf("cannot rewrite OCF metadata: %s", err)
+	}
+	if _, err = io.Copy(iow, ior); err != nil {
+		return fmt.Errorf("cannot rewrite OCF metadata: cannot copy blocks: %s", err)
+	}
+	return nil
+}
@@ -117,6 +117,101 @@ func (ocfr *OCFReader) Read() (interface{}, error) {
 	return datum, nil
 }
 
+// ReadFunc consumes one datum value from the Avro OCF stream like Read, but
+// rather than decoding it using the codec of the OCF file, it calls decode with
+// the remaining decompressed bytes of the current block. The decode function
+// ought to decode the datum at the start of buf, and return the bytes that
+// follow it. ReadFunc is used by generated code to decode data items directly
+// into generated types.
+//
+//     var user *User
+//     for ocfr.Scan() {
+//         err := ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+//             var err error
+//             user, buf, err = NewUser(buf)
+//             return buf, err
+//         })
+//         if err != nil {
+//             return err
+//         }
+//         fmt.Println(user.Name)
+//     }
+func (ocfr *OCFReader) ReadFunc(decode func(buf []byte) ([]byte, error)) error {
+	// NOTE: Test previous error before testing readReady to prevent overwriting
+	// previous error.
+	if ocfr.rerr != nil {
+		return ocfr.rerr
+	}
+	if !ocfr.readReady {
+		ocfr.rerr = errors.New("ReadFunc called without successful Scan")
+		return ocfr.rerr
+	}
+	ocfr.readReady = false
+
+	// decode one datum value from block
+	if ocfr.block, ocfr.rerr = decode(ocfr.block); ocfr.rerr != nil {
+		return ocfr.rerr
+	}
+	ocfr.remainingBlockItems--
+
+	return nil
+}
+
+// ReadInto consumes one datum value from the Avro OCF stream like Read, but
+// rather than returning a new map for each datum, it decodes the datum into the
+// map pointed to by dst. The map, along with the nested maps and slices of the
+// previously decoded datum, are recycled where the schema allows, reducing
+// allocations when reading many data items. When *dst is nil, a new map is
+// allocated and stored there. ReadInto requires an OCF schema whose data items
+// decode to a map, such as a record.
+//
+// Because each invocation overwrites the contents of the map, along with its
+// nested maps and slices, callers that retain any part of a datum beyond the
+// next invocation ought to copy it first.
+//
+//     var datum map[string]interface{}
+//     for ocfr.Scan() {
+//         if err := ocfr.ReadInto(&datum); err != nil {
+//             return err
+//         }
+//         fmt.Println(datum["name"])
+//     }
+func (ocfr *OCFReader) ReadInto(dst *map[string]interface{}) error {
+	// NOTE: Test previous error before testing readReady to prevent overwriting
+	// previous error.
+	if ocfr.rerr != nil {
+		return ocfr.rerr
+	}
+	if !ocfr.readReady {
+		ocfr.rerr = errors.New("ReadInto called without successful Scan")
+		return ocfr.rerr
+	}
+	ocfr.readReady = false
+
+	var recycled interface{}
+	if *dst != nil {
+		recycled = *dst
+	}
+
+	// decode one datum value from block
+	var datum interface{}
+	datum, ocfr.block, ocfr.rerr = ocfr.header.codec.nativeFromBinaryReusing(ocfr.block, recycled)
+	if ocfr.rerr != nil {
+		return ocfr.rerr
+	}
+	ocfr.remainingBlockItems--
+
+	switch v := datum.(type) {
+	case map[string]interface{}:
+		*dst = v
+	case nil:
+		*dst = nil
+	default:
+		return fmt.Errorf("cannot read into map when datum is not a map: %T", datum)
+	}
+	return nil
+}
+
 // RemainingBlockItems returns the number of items remaining in the block being
 // processed.
 func (ocfr *OCFReader) RemainingBlockItems() int64 {
@@ -184,46 +279,8 @@ func (ocfr *OCFReader) Scan() bool {
 			return false
 		}
 
-		switch ocfr.header.compressionID {
-		case compressionNull:
-			// no-op
-
-		case compressionDeflate:
-			// NOTE: flate.NewReader wraps with io.ByteReader if argument does
-			// not implement that interface.
-			rc := flate.NewReader(bytes.NewBuffer(ocfr.block))
-			ocfr.block, ocfr.rerr = ioutil.ReadAll(rc)
-			if ocfr.rerr != nil {
-				_ = rc.Close()
-				return false
-			}
-			if ocfr.rerr = rc.Close(); ocfr.rerr != nil {
-				return false
-			}
-
-		case compressionSnappy:
-			index := len(ocfr.block) - 4 // last 4 bytes is crc32 of decoded block
-			if index <= 0 {
-				ocfr.rerr = fmt.Errorf("cannot decompress snappy without CRC32 checksum: %d", len(ocfr.block))
-				return false
-			}
-			decoded, err := snappy.Decode(nil, ocfr.block[:index])
-			if err != nil {
-				ocfr.rerr = fmt.Errorf("cannot decompress: %s", err)
-				return false
-			}
-			actualCRC := crc32.ChecksumIEEE(decoded)
-			expectedCRC := binary.BigEndian.Uint32(ocfr.block[index : index+4])
-			if actualCRC != expectedCRC {
-				ocfr.rerr = fmt.Errorf("snappy CRC32 checksum mismatch: %x != %x", actualCRC, expectedCRC)
-				return false
-			}
-			ocfr.block = decoded
-
-		default:
-			ocfr.rerr = fmt.Errorf("should not get here: cannot compress block using unrecognized compression: %d", ocfr.header.compressionID)
+		if ocfr.block, ocfr.rerr = decompressOCFBlock(ocfr.header.compressionID, ocfr.block); ocfr.rerr != nil {
 			return false
-
 		}
 
 		// read and ensure sync marker matches
@@ -243,6 +300,48 @@ func (ocfr *OCFReader) Scan() bool {
 	return true
 }
 
+// decompressOCFBlock returns the decompressed contents of an OCF block that was
+// compressed using the specified compression algorithm.
+func decompressOCFBlock(cID compressionID, block []byte) ([]byte, error) {
+	switch cID {
+	case compressionNull:
+		return block, nil
+
+	case compressionDeflate:
+		// NOTE: flate.NewReader wraps with io.ByteReader if argument does
+		// not implement that interface.
+		rc := flate.NewReader(bytes.NewBuffer(block))
+		decoded, err := ioutil.ReadAll(rc)
+		if err != nil {
+			_ = rc.Close()
+			return nil, err
+		}
+		if err = rc.Close(); err != nil {
+			return nil, err
+		}
+		return decoded, nil
+
+	case compressionSnappy:
+		index := len(block) - 4 // last 4 bytes is crc32 of decoded block
+		if index <= 0 {
+			return nil, fmt.Errorf("cannot decompress snappy without CRC32 checksum: %d", len(block))
+		}
+		decoded, err := snappy.Decode(nil, block[:index])
+		if err != nil {
+			return nil, fmt.Errorf("cannot decompress: %s", err)
+		}
+		actualCRC := crc32.ChecksumIEEE(decoded)
+		expectedCRC := binary.BigEndian.Uint32(block[index : index+4])
+		if actualCRC != expectedCRC {
+			return nil, fmt.Errorf("snappy CRC32 checksum mismatch: %x != %x", actualCRC, expectedCRC)
+		}
+		return decoded, nil
+
+	default:
+		return nil, fmt.Errorf("should not get here: cannot compress block using unrecognized compression: %d", cID)
+	}
+}
+
 // SkipThisBlockAndReset can be called after an error occurs while reading or
 // decoding datum values from an OCF stream. OCF specifies each OCF stream
 // contain one or more blocks of data. Each block consists of a block count, the
@@ -11,6 +11,9 @@ package goavro
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -99,3 +102,184 @@ func TestReadOCFHeaderMetadataSyncMarker(t *testing.T) {
 // func TestOCFReaderRead(t *testing.T) {
 // 	testOCFReader(t,
 // }
+
+func TestOCFReaderReadInto(t *testing.T) {
+	schema := `{"type":"record","name":"r1","fields":[
+		{"name":"id","type":"long"},
+		{"name":"tags","type":{"type":"array","items":"string"}},
+		{"name":"points","type":{"type":"array","items":{"type":"record","name":"point","fields":[{"name":"x","type":"int"}]}}},
+		{"name":"counts","type":{"type":"map","values":"long"}},
+		{"name":"parent","type":["null","point"]}
+	]}`
+	data := []interface{}{
+		map[string]interface{}{
+			"id":     int64(1),
+			"tags":   []interface{}{"a", "b", "c"},
+			"points": []interface{}{map[string]interface{}{"x": int32(1)}, map[string]interface{}{"x": int32(2)}},
+			"counts": map[string]interface{}{"one": int64(1), "two": int64(2)},
+			"parent": map[string]interface{}{"point": map[string]interface{}{"x": int32(3)}},
+		},
+		map[string]interface{}{
+			"id":     int64(2),
+			"tags":   []interface{}{"d"},
+			"points": []interface{}{map[string]interface{}{"x": int32(4)}, map[string]interface{}{"x": int32(5)}, map[string]interface{}{"x": int32(6)}},
+			"counts": map[string]interface{}{"three": int64(3)},
+			"parent": map[string]interface{}{"point": map[string]interface{}{"x": int32(7)}},
+		},
+		map[string]interface{}{
+			"id":     int64(3),
+			"tags":   []interface{}{},
+			"points": []interface{}{},
+			"counts": map[string]interface{}{},
+			"parent": nil,
+		},
+	}
+
+	bb := new(bytes.Buffer)
+	ocfw, err := NewOCFWriter(OCFConfig{W: bb, Schema: schema})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append(data); err != nil {
+		t.Fatal(err)
+	}
+
+	ocfr, err := NewOCFReader(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var datum map[string]interface{}
+	var previousMap, previousTags uintptr
+	var i int
+	for ocfr.Scan() {
+		if err = ocfr.ReadInto(&datum); err != nil {
+			t.Fatal(err)
+		}
+		if actual, expected := fmt.Sprint(datum), fmt.Sprint(data[i]); actual != expected {
+			t.Errorf("GOT: %v; WANT: %v", actual, expected)
+		}
+		mapPointer := reflect.ValueOf(datum).Pointer()
+		tagsPointer := reflect.ValueOf(datum["tags"]).Pointer()
+		if i > 0 {
+			if actual, expected := mapPointer, previousMap; actual != expected {
+				t.Errorf("GOT: %v; WANT: %v", actual, expected)
+			}
+			if actual, expected := tagsPointer, previousTags; actual != expected {
+				t.Errorf("GOT: %v; WANT: %v", actual, expected)
+			}
+		}
+		previousMap, previousTags = mapPointer, tagsPointer
+		i++
+	}
+	if err = ocfr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := i, len(data); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+}
+
+func TestOCFReaderReadIntoDiscardsForeignKeys(t *testing.T) {
+	bb := new(bytes.Buffer)
+	ocfw, err := NewOCFWriter(OCFConfig{W: bb, Schema: `{"type":"record","name":"r1","fields":[{"name":"id","type":"long"}]}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append([]interface{}{map[string]interface{}{"id": 13}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ocfr, err := NewOCFReader(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	datum := map[string]interface{}{"id": int64(42), "foreign": "value"}
+	if !ocfr.Scan() {
+		t.Fatal(ocfr.Err())
+	}
+	if err = ocfr.ReadInto(&datum); err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := fmt.Sprint(datum), "map[id:13]"; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	err = ocfr.ReadInto(&datum)
+	ensureError(t, err, "ReadInto called without successful Scan")
+}
+
+func TestOCFReaderReadIntoRequiresMap(t *testing.T) {
+	bb := new(bytes.Buffer)
+	ocfw, err := NewOCFWriter(OCFConfig{W: bb, Schema: `"long"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append([]int64{13}); err != nil {
+		t.Fatal(err)
+	}
+
+	ocfr, err := NewOCFReader(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var datum map[string]interface{}
+	if !ocfr.Scan() {
+		t.Fatal(ocfr.Err())
+	}
+	err = ocfr.ReadInto(&datum)
+	ensureError(t, err, "cannot read into map", "int64")
+}
+
+func TestOCFReaderReadFunc(t *testing.T) {
+	bb := new(bytes.Buffer)
+	ocfw, err := NewOCFWriter(OCFConfig{W: bb, Schema: `"long"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append([]int64{13, 42}); err != nil {
+		t.Fatal(err)
+	}
+
+	ocfr, err := NewOCFReader(bytes.NewReader(bb.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []int64
+	for ocfr.Scan() {
+		err = ocfr.ReadFunc(func(buf []byte) ([]byte, error) {
+			var value int64
+			var err error
+			value, buf, err = LongNativeFromBinary(buf)
+			values = append(values, value)
+			return buf, err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = ocfr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := fmt.Sprint(values), "[13 42]"; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	err = ocfr.ReadFunc(func(buf []byte) ([]byte, error) { return buf, nil })
+	ensureError(t, err, "ReadFunc called without successful Scan")
+
+	// An error returned by the decode function stops the reader.
+	ocfr, err = NewOCFReader(bytes.NewReader(bb.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ocfr.Scan() {
+		t.Fatal(ocfr.Err())
+	}
+	err = ocfr.ReadFunc(func(buf []byte) ([]byte, error) { return buf, errors.New("cannot decode datum") })
+	ensureError(t, err, "cannot decode datum")
+	if ocfr.Scan() {
+		t.Errorf("GOT: %v; WANT: %v", true, false)
+	}
+	ensureError(t, ocfr.Err(), "cannot decode datum")
+}
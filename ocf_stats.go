@@ -0,0 +1,139 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"fmt"
+	"io"
+)
+
+// Stats summarizes the contents of an Avro Object Container File (OCF), as
+// returned by OCFStats and OCFStatsDecompressed.
+type Stats struct {
+	// MetaData is the file metadata map found within the OCF header,
+	// including the "avro.schema" and "avro.codec" keys.
+	MetaData map[string][]byte
+
+	// CompressionName is the name of the compression algorithm used by the
+	// OCF.
+	CompressionName string
+
+	// Records is the total number of data items in all blocks.
+	Records int64
+
+	// Blocks is the number of blocks.
+	Blocks int64
+
+	// MinBlockBytes and MaxBlockBytes are the sizes of the smallest and
+	// largest blocks, as stored in the OCF, possibly compressed.
+	MinBlockBytes int64
+	MaxBlockBytes int64
+
+	// CompressedBytes is the total size of all blocks, as stored in the OCF,
+	// possibly compressed.
+	CompressedBytes int64
+
+	// UncompressedBytes is the total size of all blocks after decompression.
+	// It is only computed by OCFStatsDecompressed, or when the OCF blocks are
+	// not compressed, and is zero otherwise.
+	UncompressedBytes int64
+}
+
+// AvgBlockBytes returns the average size of the blocks, as stored in the OCF,
+// or zero when there are no blocks.
+func (s *Stats) AvgBlockBytes() float64 {
+	if s.Blocks == 0 {
+		return 0
+	}
+	return float64(s.CompressedBytes) / float64(s.Blocks)
+}
+
+// CompressionRatio returns the ratio of uncompressed to compressed block bytes,
+// or zero when the number of uncompressed bytes was not computed.
+func (s *Stats) CompressionRatio() float64 {
+	if s.CompressedBytes == 0 || s.UncompressedBytes == 0 {
+		return 0
+	}
+	return float64(s.UncompressedBytes) / float64(s.CompressedBytes)
+}
+
+// OCFStats reads an Avro Object Container File (OCF) from ior and returns
+// statistics about its blocks, along with its header metadata. Like appending
+// to an existing OCF with OCFWriter, rather than reading each encoded block,
+// decompressing it, and decoding its data items, it merely reads the count and
+// size of each block, then skips ahead to the following block, which makes it
+// suitable for cheap diagnostics of large files. Because blocks are not
+// decompressed, UncompressedBytes is only computed when the OCF is not
+// compressed; use OCFStatsDecompressed to compute it for compressed OCFs.
+//
+//     func example(ior io.Reader) error {
+//         stats, err := goavro.OCFStats(bufio.NewReader(ior))
+//         if err != nil {
+//             return err
+//         }
+//         fmt.Println(stats.Records, stats.Blocks, stats.AvgBlockBytes())
+//         return nil
+//     }
+func OCFStats(ior io.Reader) (*Stats, error) {
+	return ocfStats(ior, false)
+}
+
+// OCFStatsDecompressed returns the same statistics as OCFStats, but also
+// decompresses each block to compute UncompressedBytes. Data items are still
+// not decoded.
+func OCFStatsDecompressed(ior io.Reader) (*Stats, error) {
+	return ocfStats(ior, true)
+}
+
+func ocfStats(ior io.Reader, decompress bool) (*Stats, error) {
+	header, err := readOCFHeader(ior)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read OCF stats: %s", err)
+	}
+
+	stats := &Stats{MetaData: header.metadata}
+	switch header.compressionID {
+	case compressionNull:
+		stats.CompressionName = CompressionNullLabel
+		decompress = false // block sizes are already uncompressed sizes
+	case compressionDeflate:
+		stats.CompressionName = CompressionDeflateLabel
+	case compressionSnappy:
+		stats.CompressionName = CompressionSnappyLabel
+	}
+
+	err = scanOCFBlocks(ior, header, decompress, func(blockCount, blockSize int64, block []byte) error {
+		if stats.Blocks == 0 || blockSize < stats.MinBlockBytes {
+			stats.MinBlockBytes = blockSize
+		}
+		if blockSize > stats.MaxBlockBytes {
+			stats.MaxBlockBytes = blockSize
+		}
+		stats.Blocks++
+		stats.Records += blockCount
+		stats.CompressedBytes += blockSize
+
+		switch {
+		case header.compressionID == compressionNull:
+			stats.UncompressedBytes += blockSize
+		case decompress:
+			decoded, err := decompressOCFBlock(header.compressionID, block)
+			if err != nil {
+				return fmt.Errorf("cannot decompress block %d: %s", stats.Blocks, err)
+			}
+			stats.UncompressedBytes += int64(len(decoded))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot read OCF stats: %s", err)
+	}
+	return stats, nil
+}
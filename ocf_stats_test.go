@@ -0,0 +1,155 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestOCFStats(t *testing.T) {
+	bb := new(bytes.Buffer)
+	ocfw, err := NewOCFWriter(OCFConfig{
+		W:        bb,
+		Schema:   `{"type":"long"}`,
+		MetaData: map[string][]byte{"rows": []byte("6")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// block sizes are 1, 3, and 2 bytes
+	if err = ocfw.Append([]int64{1}); err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append([]int64{2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append([]int64{5, 6}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := OCFStats(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := string(stats.MetaData["rows"]), "6"; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := stats.CompressionName, CompressionNullLabel; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := stats.Records, int64(6); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := stats.Blocks, int64(3); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := stats.MinBlockBytes, int64(1); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := stats.MaxBlockBytes, int64(3); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := stats.AvgBlockBytes(), 2.0; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := stats.CompressedBytes, int64(6); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	// uncompressed size is known without decompressing when blocks are not
+	// compressed
+	if actual, expected := stats.UncompressedBytes, int64(6); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := stats.CompressionRatio(), 1.0; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+}
+
+func TestOCFStatsCompressed(t *testing.T) {
+	expected, err := ioutil.ReadFile("fixtures/quickstop-null.avro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nullStats, err := OCFStats(bytes.NewReader(expected))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pathname := range []string{"fixtures/quickstop-deflate.avro", "fixtures/quickstop-snappy.avro"} {
+		fh, err := os.Open(pathname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stats, err := OCFStats(fh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = fh.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if actual, expected := stats.Records, nullStats.Records; actual != expected {
+			t.Errorf("%s: GOT: %v; WANT: %v", pathname, actual, expected)
+		}
+		if actual, expected := stats.UncompressedBytes, int64(0); actual != expected {
+			t.Errorf("%s: GOT: %v; WANT: %v", pathname, actual, expected)
+		}
+		if actual, expected := stats.CompressionRatio(), 0.0; actual != expected {
+			t.Errorf("%s: GOT: %v; WANT: %v", pathname, actual, expected)
+		}
+
+		fh, err = os.Open(pathname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stats, err = OCFStatsDecompressed(fh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = fh.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if actual, expected := stats.Records, nullStats.Records; actual != expected {
+			t.Errorf("%s: GOT: %v; WANT: %v", pathname, actual, expected)
+		}
+		if actual, expected := stats.UncompressedBytes, nullStats.CompressedBytes; actual != expected {
+			t.Errorf("%s: GOT: %v; WANT: %v", pathname, actual, expected)
+		}
+		if stats.CompressionRatio() <= 1 {
+			t.Errorf("%s: GOT: %v; WANT: > 1", pathname, stats.CompressionRatio())
+		}
+	}
+}
+
+func TestOCFStatsErrors(t *testing.T) {
+	_, err := OCFStats(bytes.NewReader([]byte("Obj\x02")))
+	ensureError(t, err, "cannot read OCF stats", "invalid magic bytes")
+
+	fh, err := os.Open("fixtures/syncMarkerMismatch.avro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := fh.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	_, err = OCFStats(fh)
+	ensureError(t, err, "cannot read OCF stats", "sync marker mismatch")
+
+	// corrupt deflate block is only detected when decompressing
+	corrupt := []byte("Obj\x01\x04\x14avro.codec\x0edeflate\x16avro.schema\x1e{\"type\":\"long\"}\x000123456789abcdef\x02\x04\xff\xff0123456789abcdef")
+	_, err = OCFStats(bytes.NewReader(corrupt))
+	ensureError(t, err)
+	_, err = OCFStatsDecompressed(bytes.NewReader(corrupt))
+	ensureError(t, err, "cannot read OCF stats", "cannot decompress block 1")
+}
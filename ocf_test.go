@@ -95,3 +95,104 @@ func TestOCFWriterCompressionSnappy(t *testing.T) {
 func TestOCFWriterWithApplicationMetaData(t *testing.T) {
 	testOCFRoundTripWithHeaders(t, CompressionNullLabel, map[string][]byte{"foo": []byte("BOING"), "goo": []byte("zoo")})
 }
+
+func TestOCFWriterRejectsReservedMetaData(t *testing.T) {
+	_, err := NewOCFWriter(OCFConfig{
+		W:        new(bytes.Buffer),
+		Schema:   `{"type":"long"}`,
+		MetaData: map[string][]byte{"avro.schema": []byte(`{"type":"int"}`)},
+	})
+	ensureError(t, err, "cannot write OCF header", "reserved key", "avro.schema")
+
+	_, err = NewOCFWriter(OCFConfig{
+		W:               new(bytes.Buffer),
+		Schema:          `{"type":"long"}`,
+		CompressionName: CompressionDeflateLabel,
+		MetaData:        map[string][]byte{"avro.codec": []byte(CompressionSnappyLabel)},
+	})
+	ensureError(t, err, "cannot write OCF header", "reserved key", "avro.codec")
+
+	// reserved keys that agree with the header are permitted
+	_, err = NewOCFWriter(OCFConfig{
+		W:               new(bytes.Buffer),
+		Schema:          `{"type":"long"}`,
+		CompressionName: CompressionDeflateLabel,
+		MetaData: map[string][]byte{
+			"avro.schema": []byte(`{"type":"long"}`),
+			"avro.codec":  []byte(CompressionDeflateLabel),
+		},
+	})
+	ensureError(t, err)
+}
+
+func TestRewriteOCFMetadata(t *testing.T) {
+	original := new(bytes.Buffer)
+	ocfw, err := NewOCFWriter(OCFConfig{
+		W:               original,
+		Schema:          `{"type":"long"}`,
+		CompressionName: CompressionDeflateLabel,
+		MetaData:        map[string][]byte{"rows": []byte("0"), "lineage": []byte("abc")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append([]int64{13, 42}); err != nil {
+		t.Fatal(err)
+	}
+	if err = ocfw.Append([]int64{-12}); err != nil {
+		t.Fatal(err)
+	}
+
+	ocfr, err := NewOCFReader(bytes.NewReader(original.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := ocfr.MetaData()
+	metadata["rows"] = []byte("3")
+	delete(metadata, "lineage")
+
+	rewritten := new(bytes.Buffer)
+	if err = RewriteOCFMetadata(bytes.NewReader(original.Bytes()), rewritten, metadata); err != nil {
+		t.Fatal(err)
+	}
+
+	ocfr, err = NewOCFReader(rewritten)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := string(ocfr.MetaData()["rows"]), "3"; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if _, ok := ocfr.MetaData()["lineage"]; ok {
+		t.Errorf("GOT: %v; WANT: %v", ok, false)
+	}
+	if actual, expected := ocfr.CompressionName(), CompressionDeflateLabel; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	var values []int64
+	for ocfr.Scan() {
+		value, err := ocfr.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, value.(int64))
+	}
+	if err = ocfr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := fmt.Sprint(values), "[13 42 -12]"; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+}
+
+func TestRewriteOCFMetadataRejectsReservedMetaData(t *testing.T) {
+	original := new(bytes.Buffer)
+	if _, err := NewOCFWriter(OCFConfig{W: original, Schema: `{"type":"long"}`}); err != nil {
+		t.Fatal(err)
+	}
+	err := RewriteOCFMetadata(original, new(bytes.Buffer), map[string][]byte{"avro.codec": []byte(CompressionSnappyLabel)})
+	ensureError(t, err, "cannot rewrite OCF metadata", "reserved key", "avro.codec")
+
+	err = RewriteOCFMetadata(bytes.NewReader([]byte("Obj\x02")), new(bytes.Buffer), nil)
+	ensureError(t, err, "cannot rewrite OCF metadata", "invalid magic bytes")
+}
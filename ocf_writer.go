@@ -17,7 +17,6 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
-	"io/ioutil"
 	"os"
 
 	"github.com/golang/snappy"
@@ -52,17 +51,37 @@ type OCFConfig struct {
 	// this field is ignored.
 	CompressionName string
 
+	// CompressionLevel specifies the compression level used by compression
+	// algorithms that support levels, (optional). For "deflate" it is one of
+	// the compress/flate levels, from flate.BestSpeed to flate.BestCompression,
+	// or flate.HuffmanOnly to select the Huffman-only compression strategy. If
+	// omitted, defaults to the default level of the compression algorithm,
+	// flate.DefaultCompression for "deflate"; because of this flate.NoCompression
+	// cannot be selected, but the "null" codec serves the same purpose. It is an
+	// error to specify a level for a compression algorithm without levels.
+	// Unlike CompressionName, this field is honored when appending to an
+	// existing OCF.
+	CompressionLevel int
+
 	//MetaData specifies application specific meta data to be added to
 	//the OCF file.  When appending to an existing OCF, this field
-	//is ignored
+	//is ignored; use RewriteOCFMetadata to change the metadata of an
+	//existing OCF. The reserved "avro.schema" and "avro.codec" keys may
+	//not be used to override the schema or compression codec.
 	MetaData map[string][]byte
 }
 
 // OCFWriter is used to create a new or append to an existing Avro Object
 // Container File (OCF).
 type OCFWriter struct {
-	header *ocfHeader
-	iow    io.Writer
+	header           *ocfHeader
+	iow              io.Writer
+	compressionLevel int
+
+	// deflater and deflated are reused to compress each block when using the
+	// deflate compression algorithm.
+	deflater *flate.Writer
+	deflated bytes.Buffer
 }
 
 // NewOCFWriter returns a new OCFWriter instance that may be used for appending
@@ -88,6 +107,9 @@ func NewOCFWriter(config OCFConfig) (*OCFWriter, error) {
 			if ocf.header, err = readOCFHeader(file); err != nil {
 				return nil, fmt.Errorf("cannot create OCFWriter: %s", err)
 			}
+			if ocf.compressionLevel, err = ocfCompressionLevel(ocf.header.compressionID, config.CompressionLevel); err != nil {
+				return nil, fmt.Errorf("cannot create OCFWriter: %s", err)
+			}
 			// prepare for appending data to existing OCF
 			if err = ocf.quickScanToTail(file); err != nil {
 				return nil, fmt.Errorf("cannot create OCFWriter: %s", err)
@@ -100,58 +122,43 @@ func NewOCFWriter(config OCFConfig) (*OCFWriter, error) {
 	if ocf.header, err = newOCFHeader(config); err != nil {
 		return nil, fmt.Errorf("cannot create OCFWriter: %s", err)
 	}
+	if ocf.compressionLevel, err = ocfCompressionLevel(ocf.header.compressionID, config.CompressionLevel); err != nil {
+		return nil, fmt.Errorf("cannot create OCFWriter: %s", err)
+	}
 	if err = writeOCFHeader(ocf.header, config.W); err != nil {
 		return nil, fmt.Errorf("cannot create OCFWriter: %s", err)
 	}
 	return ocf, nil // another happy case for creation of new OCF
 }
 
+// ocfCompressionLevel returns the compression level to use with the specified
+// compression algorithm, or an error when the algorithm does not support the
+// requested level.
+func ocfCompressionLevel(cID compressionID, level int) (int, error) {
+	switch cID {
+	case compressionDeflate:
+		if level == 0 {
+			return flate.DefaultCompression, nil
+		}
+		if level < flate.HuffmanOnly || level > flate.BestCompression {
+			return 0, fmt.Errorf("cannot use deflate compression level outside range %d to %d: %d", flate.HuffmanOnly, flate.BestCompression, level)
+		}
+		return level, nil
+	default:
+		if level != 0 {
+			return 0, fmt.Errorf("cannot use compression level with compression algorithm that does not support levels: %d", level)
+		}
+		return 0, nil
+	}
+}
+
 // quickScanToTail advances the stream reader to the tail end of the
 // file. Rather than reading each encoded block, optionally decompressing it,
 // and then decoding it, this method reads the block count, ignoring it, then
 // reads the block size, then skips ahead to the followig block. It does this
 // repeatedly until attempts to read the file return io.EOF.
 func (ocfw *OCFWriter) quickScanToTail(ior io.Reader) error {
-	sync := make([]byte, ocfSyncLength)
-	for {
-		// Read and validate block count
-		blockCount, err := longBinaryReader(ior)
-		if err != nil {
-			if err == io.EOF {
-				return nil // merely end of file, rather than error
-			}
-			return fmt.Errorf("cannot read block count: %s", err)
-		}
-		if blockCount <= 0 {
-			return fmt.Errorf("cannot read when block count is not greater than 0: %d", blockCount)
-		}
-		if blockCount > MaxBlockCount {
-			return fmt.Errorf("cannot read when block count exceeds MaxBlockCount: %d > %d", blockCount, MaxBlockCount)
-		}
-		// Read block size
-		blockSize, err := longBinaryReader(ior)
-		if err != nil {
-			return fmt.Errorf("cannot read block size: %s", err)
-		}
-		if blockSize <= 0 {
-			return fmt.Errorf("cannot read when block size is not greater than 0: %d", blockSize)
-		}
-		if blockSize > MaxBlockSize {
-			return fmt.Errorf("cannot read when block size exceeds MaxBlockSize: %d > %d", blockSize, MaxBlockSize)
-		}
-		// Advance reader to end of block
-		if _, err = io.CopyN(ioutil.Discard, ior, blockSize); err != nil {
-			return fmt.Errorf("cannot seek to next block: %s", err)
-		}
-		// Read and validate sync marker
-		var n int
-		if n, err = io.ReadFull(ior, sync); err != nil {
-			return fmt.Errorf("cannot read sync marker: read %d out of %d bytes: %s", n, ocfSyncLength, err)
-		}
-		if !bytes.Equal(sync, ocfw.header.syncMarker[:]) {
-			return fmt.Errorf("sync marker mismatch: %v != %v", sync, ocfw.header.syncMarker)
-		}
-	}
+	return scanOCFBlocks(ior, ocfw.header, false, nil)
 }
 
 // Append appends one or more data items to an OCF file in a block. If there are
@@ -174,6 +181,37 @@ func (ocfw *OCFWriter) Append(data interface{}) error {
 	return ocfw.appendDataIntoBlock(arrayValues)
 }
 
+// AppendFunc appends count data items to an OCF file like Append, but rather
+// than encoding them using the codec of the OCF file, it calls encode for each
+// item in turn, which ought to append the binary encoding of the item at index i
+// to buf. AppendFunc is used by generated code to encode generated types
+// directly.
+//
+//     users := []*User{{Name: "alice"}, {Name: "bob"}}
+//     err := ocfw.AppendFunc(len(users), func(buf []byte, i int) ([]byte, error) {
+//         return users[i].MarshalAvro(buf)
+//     })
+func (ocfw *OCFWriter) AppendFunc(count int, encode func(buf []byte, i int) ([]byte, error)) error {
+	// Chunk data so no block has more than MaxBlockCount items.
+	for first := 0; first < count; first += int(MaxBlockCount) {
+		last := count
+		if int64(last-first) > MaxBlockCount {
+			last = first + int(MaxBlockCount)
+		}
+		var block []byte // working buffer for encoding data values
+		var err error
+		for i := first; i < last; i++ {
+			if block, err = encode(block, i); err != nil {
+				return fmt.Errorf("cannot translate datum to binary: %d; %s", i, err)
+			}
+		}
+		if err = ocfw.appendBlock(block, last-first); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (ocfw *OCFWriter) appendDataIntoBlock(data []interface{}) error {
 	var block []byte // working buffer for encoding data values
 	var err error
@@ -185,23 +223,36 @@ func (ocfw *OCFWriter) appendDataIntoBlock(data []interface{}) error {
 		}
 	}
 
+	return ocfw.appendBlock(block, len(data))
+}
+
+// appendBlock compresses the binary encoding of count data items, and writes it
+// to the OCF file as a block.
+func (ocfw *OCFWriter) appendBlock(block []byte, count int) error {
+	var err error
+
 	switch ocfw.header.compressionID {
 	case compressionNull:
 		// no-op
 
 	case compressionDeflate:
-		// compress into new bytes buffer.
-		bb := bytes.NewBuffer(make([]byte, 0, len(block)))
-
-		cw, _ := flate.NewWriter(bb, flate.DefaultCompression)
-		// writing bytes to cw will compress bytes and send to bb.
-		if _, err := cw.Write(block); err != nil {
+		// compress into bytes buffer reused for each block.
+		ocfw.deflated.Reset()
+		if ocfw.deflater == nil {
+			if ocfw.deflater, err = flate.NewWriter(&ocfw.deflated, ocfw.compressionLevel); err != nil {
+				return err
+			}
+		} else {
+			ocfw.deflater.Reset(&ocfw.deflated)
+		}
+		// writing bytes to deflater will compress bytes and send to deflated.
+		if _, err := ocfw.deflater.Write(block); err != nil {
 			return err
 		}
-		if err := cw.Close(); err != nil {
+		if err := ocfw.deflater.Close(); err != nil {
 			return err
 		}
-		block = bb.Bytes()
+		block = ocfw.deflated.Bytes()
 
 	case compressionSnappy:
 		compressed := snappy.Encode(nil, block)
@@ -219,7 +270,7 @@ func (ocfw *OCFWriter) appendDataIntoBlock(data []interface{}) error {
 
 	// create file data block
 	buf := make([]byte, 0, len(block)+ocfBlockConst) // pre-allocate block bytes
-	buf, _ = longBinaryFromNative(buf, len(data))    // block count (number of data items)
+	buf, _ = longBinaryFromNative(buf, count)        // block count (number of data items)
 	buf, _ = longBinaryFromNative(buf, len(block))   // block size (number of bytes in block)
 	buf = append(buf, block...)                      // serialized objects
 	buf = append(buf, ocfw.header.syncMarker[:]...)  // sync marker
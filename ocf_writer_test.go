@@ -11,7 +11,11 @@ package goavro
 
 import (
 	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"testing"
 )
@@ -293,3 +297,167 @@ func TestOCFWriterAppendSomeItemsToSomeItems(t *testing.T) {
 		t.Errorf("GOT: %v; WANT: %v", actual, expected)
 	}
 }
+
+func testOCFWriterCompressionLevel(t *testing.T, level int) int {
+	bb := new(bytes.Buffer)
+	ocfw, err := NewOCFWriter(OCFConfig{
+		W:                bb,
+		Schema:           `{"type":"string"}`,
+		CompressionName:  CompressionDeflateLabel,
+		CompressionLevel: level,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// append multiple blocks to exercise reuse of the deflate writer
+	var values []string
+	for i := 0; i < 3; i++ {
+		block := make([]string, 100)
+		for j := range block {
+			block[j] = fmt.Sprintf("some fairly compressible text %d", j%7)
+		}
+		if err = ocfw.Append(block); err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, block...)
+	}
+	size := bb.Len()
+
+	ocfr, err := NewOCFReader(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	for ocfr.Scan() {
+		value, err := ocfr.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual, expected := value, values[count]; actual != expected {
+			t.Errorf("GOT: %v; WANT: %v", actual, expected)
+		}
+		count++
+	}
+	if err = ocfr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := count, len(values); actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	return size
+}
+
+func TestOCFWriterCompressionLevel(t *testing.T) {
+	huffman := testOCFWriterCompressionLevel(t, flate.HuffmanOnly)
+	speed := testOCFWriterCompressionLevel(t, flate.BestSpeed)
+	best := testOCFWriterCompressionLevel(t, flate.BestCompression)
+	testOCFWriterCompressionLevel(t, 0)
+
+	if best > speed {
+		t.Errorf("GOT: %v; WANT: <= %v", best, speed)
+	}
+	if speed > huffman {
+		t.Errorf("GOT: %v; WANT: <= %v", speed, huffman)
+	}
+}
+
+func TestOCFWriterCompressionLevelInvalid(t *testing.T) {
+	_, err := NewOCFWriter(OCFConfig{
+		W:                new(bytes.Buffer),
+		Schema:           `{"type":"long"}`,
+		CompressionName:  CompressionDeflateLabel,
+		CompressionLevel: flate.BestCompression + 1,
+	})
+	ensureError(t, err, "cannot create OCFWriter", "deflate compression level outside range")
+
+	_, err = NewOCFWriter(OCFConfig{
+		W:                new(bytes.Buffer),
+		Schema:           `{"type":"long"}`,
+		CompressionName:  CompressionSnappyLabel,
+		CompressionLevel: flate.BestSpeed,
+	})
+	ensureError(t, err, "cannot create OCFWriter", "does not support levels")
+}
+
+func TestOCFWriterCompressionLevelWhenAppending(t *testing.T) {
+	appender, err := ioutil.TempFile("", "goavro-compression-level")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := appender.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Remove(appender.Name()); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if _, err = appender.Write([]byte("Obj\x01\x04\x14avro.codec\x0edeflate\x16avro.schema\x1e{\"type\":\"long\"}\x000123456789abcdef")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = appender.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	// CompressionName is ignored when appending, but the level is honored
+	ocfw, err := NewOCFWriter(OCFConfig{W: appender, CompressionLevel: flate.BestCompression})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := ocfw.compressionLevel, flate.BestCompression; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if err = ocfw.Append([]int64{13, 42}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOCFWriterAppendFunc(t *testing.T) {
+	defer func(max int64) { MaxBlockCount = max }(MaxBlockCount)
+	MaxBlockCount = 2
+
+	bb := new(bytes.Buffer)
+	ocfw, err := NewOCFWriter(OCFConfig{W: bb, Schema: `"long"`, CompressionName: CompressionSnappyLabel})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := []int64{13, 42, -1, 0, 7}
+	err = ocfw.AppendFunc(len(values), func(buf []byte, i int) ([]byte, error) {
+		return LongBinaryFromNative(buf, values[i])
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ocfr, err := NewOCFReader(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var blocks int
+	var data []interface{}
+	for ocfr.Scan() {
+		datum, err := ocfr.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ocfr.RemainingBlockItems() == 0 {
+			blocks++
+		}
+		data = append(data, datum)
+	}
+	if err = ocfr.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := fmt.Sprint(data), "[13 42 -1 0 7]"; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+	if actual, expected := blocks, 3; actual != expected {
+		t.Errorf("GOT: %v; WANT: %v", actual, expected)
+	}
+
+	err = ocfw.AppendFunc(1, func(buf []byte, i int) ([]byte, error) {
+		return nil, errors.New("cannot encode datum")
+	})
+	ensureError(t, err, "cannot translate datum to binary", "cannot encode datum")
+}
@@ -0,0 +1,261 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// jsonMember is a member of a JSON object.
+type jsonMember struct {
+	key   string
+	value interface{}
+}
+
+// jsonObject is a JSON object whose members are kept in the order they are
+// declared, so that they are written in that order. Its values, like those of
+// the arrays it contains, are nil, bool, json.Number, string, []interface{}
+// or jsonObject.
+type jsonObject []jsonMember
+
+// get returns the value of the member with the given key.
+func (o jsonObject) get(key string) (interface{}, bool) {
+	for _, m := range o {
+		if m.key == key {
+			return m.value, true
+		}
+	}
+	return nil, false
+}
+
+// parseOrderedJSON parses a single JSON value, whose objects are returned as
+// jsonObject values, and whose numbers are returned as json.Number values.
+func parseOrderedJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrderedJSON(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("cannot parse JSON: ought to have a single value")
+	}
+	return value, nil
+}
+
+func decodeOrderedJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse JSON: %s", err)
+	}
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			object := jsonObject{}
+			for decoder.More() {
+				token, err = decoder.Token()
+				if err != nil {
+					return nil, fmt.Errorf("cannot parse JSON: %s", err)
+				}
+				value, err := decodeOrderedJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+				object = append(object, jsonMember{token.(string), value})
+			}
+			_, err = decoder.Token()
+			return object, err
+		case '[':
+			array := []interface{}{}
+			for decoder.More() {
+				value, err := decodeOrderedJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, value)
+			}
+			_, err = decoder.Token()
+			return array, err
+		}
+		return nil, fmt.Errorf("cannot parse JSON: unexpected %s", t)
+	default:
+		return t, nil
+	}
+}
+
+// jsonWriter writes JSON either compactly, or indented the way the default
+// pretty printer of the Jackson library, which Avro's own implementation uses,
+// indents it: object members on their own lines, and array elements on the
+// same line.
+type jsonWriter struct {
+	b       strings.Builder
+	pretty  bool
+	nesting int   // the number of objects being written
+	counts  []int // the number of members or elements of the objects and arrays being written
+}
+
+func (w *jsonWriter) String() string {
+	return w.b.String()
+}
+
+func (w *jsonWriter) newline() {
+	w.b.WriteByte('\n')
+	for i := 0; i < w.nesting; i++ {
+		w.b.WriteString("  ")
+	}
+}
+
+// separate writes what precedes the next member or element of the object or
+// array being written.
+func (w *jsonWriter) separate(member bool) {
+	count := &w.counts[len(w.counts)-1]
+	if *count > 0 {
+		w.b.WriteByte(',')
+	}
+	*count++
+	if w.pretty {
+		if member {
+			w.newline()
+		} else {
+			w.b.WriteByte(' ')
+		}
+	}
+}
+
+func (w *jsonWriter) startObject() {
+	w.b.WriteByte('{')
+	w.nesting++
+	w.counts = append(w.counts, 0)
+}
+
+func (w *jsonWriter) endObject() {
+	w.nesting--
+	count := w.counts[len(w.counts)-1]
+	w.counts = w.counts[:len(w.counts)-1]
+	if w.pretty {
+		if count > 0 {
+			w.newline()
+		} else {
+			w.b.WriteByte(' ')
+		}
+	}
+	w.b.WriteByte('}')
+}
+
+func (w *jsonWriter) startArray() {
+	w.b.WriteByte('[')
+	w.counts = append(w.counts, 0)
+}
+
+func (w *jsonWriter) endArray() {
+	w.counts = w.counts[:len(w.counts)-1]
+	if w.pretty {
+		w.b.WriteByte(' ')
+	}
+	w.b.WriteByte(']')
+}
+
+// key writes the key of the next member of the object being written.
+func (w *jsonWriter) key(key string) {
+	w.separate(true)
+	w.string(key)
+	if w.pretty {
+		w.b.WriteString(" : ")
+	} else {
+		w.b.WriteByte(':')
+	}
+}
+
+// element prepares to write the next element of the array being written.
+func (w *jsonWriter) element() {
+	w.separate(false)
+}
+
+func (w *jsonWriter) stringMember(key, value string) {
+	w.key(key)
+	w.string(value)
+}
+
+func (w *jsonWriter) members(o jsonObject) {
+	for _, m := range o {
+		w.key(m.key)
+		w.value(m.value)
+	}
+}
+
+func (w *jsonWriter) rawValue(s string) {
+	w.b.WriteString(s)
+}
+
+func (w *jsonWriter) value(v interface{}) {
+	switch t := v.(type) {
+	case nil:
+		w.b.WriteString("null")
+	case bool:
+		if t {
+			w.b.WriteString("true")
+		} else {
+			w.b.WriteString("false")
+		}
+	case json.Number:
+		w.b.WriteString(t.String())
+	case string:
+		w.string(t)
+	case []interface{}:
+		w.startArray()
+		for _, e := range t {
+			w.element()
+			w.value(e)
+		}
+		w.endArray()
+	case jsonObject:
+		w.startObject()
+		w.members(t)
+		w.endObject()
+	default:
+		// NOTE: Values only come from parseOrderedJSON and the IDL parser.
+		panic(fmt.Sprintf("cannot write JSON value of type %T", v))
+	}
+}
+
+// string writes s as a JSON string, escaping the characters Jackson escapes.
+func (w *jsonWriter) string(s string) {
+	const hex = "0123456789ABCDEF"
+	w.b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			w.b.WriteByte('\\')
+			w.b.WriteRune(r)
+		case r == '\n':
+			w.b.WriteString(`\n`)
+		case r == '\r':
+			w.b.WriteString(`\r`)
+		case r == '\t':
+			w.b.WriteString(`\t`)
+		case r == '\b':
+			w.b.WriteString(`\b`)
+		case r == '\f':
+			w.b.WriteString(`\f`)
+		case r < 0x20:
+			w.b.WriteString(`\u00`)
+			w.b.WriteByte(hex[r>>4])
+			w.b.WriteByte(hex[r&0xf])
+		default:
+			w.b.WriteRune(r)
+		}
+	}
+	w.b.WriteByte('"')
+}
@@ -0,0 +1,130 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"fmt"
+	"strings"
+)
+
+// reservedSchemaAttributes are the attributes of named types, and
+// reservedFieldAttributes are those of record fields, that describe the
+// structure of the data, and are not returned as properties.
+var (
+	reservedSchemaAttributes = map[string]struct{}{
+		"aliases":     {},
+		"default":     {}, // of enums
+		"fields":      {},
+		"logicalType": {},
+		"name":        {},
+		"namespace":   {},
+		"precision":   {}, // of decimals
+		"scale":       {}, // of decimals
+		"size":        {},
+		"symbols":     {},
+		"type":        {},
+	}
+	reservedFieldAttributes = map[string]struct{}{
+		"aliases": {},
+		"default": {},
+		"name":    {},
+		"order":   {},
+		"type":    {},
+	}
+)
+
+// Props returns the doc and the other attributes of the schema of the codec
+// that Avro does not reserve, such as "connect.name" or "java-class", when its
+// schema is a record, enum or fixed type. The doc string, when the schema has
+// one, is returned with the "doc" key. Props returns nil for other schemas.
+//
+//     codec, err := goavro.NewCodec(`{"type":"fixed","name":"md5","size":16,"doc":"A hash.","encoding":"hex"}`)
+//     if err != nil {
+//         fmt.Println(err)
+//     }
+//     fmt.Println(codec.Props())
+//     // Output: map[doc:A hash. encoding:hex]
+func (c *Codec) Props() map[string]interface{} {
+	if c.generator == nil || c.generator.schema == nil {
+		return nil
+	}
+	return schemaProps(c.generator.schema, reservedSchemaAttributes)
+}
+
+// FieldProps returns the doc and the other attributes of a field that Avro
+// does not reserve, such as the "pii" attribute of the field below. The path
+// of the field is the dot-separated names of the fields that lead to it from
+// the record of the codec, through the members of unions, and the items and
+// values of arrays and maps.
+//
+//     codec, err := goavro.NewCodec(`{"type":"record","name":"order","fields":[
+//         {"name":"customer","type":{"type":"record","name":"customer","fields":[
+//             {"name":"ssn","type":"string","doc":"Social security number.","pii":true}
+//         ]}}
+//     ]}`)
+//     if err != nil {
+//         fmt.Println(err)
+//     }
+//     props, err := codec.FieldProps("customer.ssn")
+//     if err != nil {
+//         fmt.Println(err)
+//     }
+//     fmt.Println(props)
+//     // Output: map[doc:Social security number. pii:true]
+func (c *Codec) FieldProps(path string) (map[string]interface{}, error) {
+	codec := c
+	fieldNames := strings.Split(path, ".")
+	for i, fieldName := range fieldNames {
+		record, fieldCodec := fieldOf(codec, fieldName)
+		if record == nil {
+			return nil, fmt.Errorf("cannot find field %q: %q ought to be a record field", path, strings.Join(fieldNames[:i+1], "."))
+		}
+		if i < len(fieldNames)-1 {
+			codec = fieldCodec
+			continue
+		}
+		fields, _ := record.generator.schema["fields"].([]interface{})
+		for _, field := range fields {
+			if fieldMap, ok := field.(map[string]interface{}); ok && fieldMap["name"] == fieldName {
+				return schemaProps(fieldMap, reservedFieldAttributes), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cannot find field %q", path) // should not get here because the record has the field
+}
+
+// fieldOf returns the record that has the named field, and the codec of that
+// field, looking into the members of unions, and the items and values of
+// arrays and maps, when codec is not a record.
+func fieldOf(codec *Codec, fieldName string) (*Codec, *Codec) {
+	if codec.fieldCodecs != nil {
+		if fieldCodec, ok := codec.fieldCodecs[fieldName]; ok && codec.generator != nil && codec.generator.schema != nil {
+			return codec, fieldCodec
+		}
+		return nil, nil
+	}
+	for _, element := range codec.elementCodecs {
+		if record, fieldCodec := fieldOf(element, fieldName); record != nil {
+			return record, fieldCodec
+		}
+	}
+	return nil, nil
+}
+
+// schemaProps returns the attributes of the schema that are not reserved.
+func schemaProps(schema map[string]interface{}, reserved map[string]struct{}) map[string]interface{} {
+	props := make(map[string]interface{})
+	for key, value := range schema {
+		if _, ok := reserved[key]; !ok {
+			props[key] = value
+		}
+	}
+	return props
+}
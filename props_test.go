@@ -0,0 +1,135 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testPropsSchema = `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "com.acme",
+  "doc": "An order.",
+  "connect.name": "com.acme.Order",
+  "aliases": ["Purchase"],
+  "fields": [
+    {"name": "id", "type": {"type": "fixed", "name": "Id", "size": 4, "doc": "An identifier.", "encoding": "hex"}},
+    {"name": "customer", "type": ["null", {
+      "type": "record",
+      "name": "Customer",
+      "fields": [
+        {"name": "name", "type": "string"},
+        {"name": "ssn", "type": "string", "doc": "Social security number.", "pii": true, "default": "", "order": "ignore"}
+      ]
+    }], "default": null, "java-class": "com.acme.Customer"},
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["OPEN", "CLOSED"], "default": "OPEN", "doc": "Whether it is open."}},
+    {"name": "lines", "type": {"type": "array", "items": {
+      "type": "record",
+      "name": "Line",
+      "fields": [
+        {"name": "attributes", "type": {"type": "map", "values": {
+          "type": "record",
+          "name": "Attribute",
+          "fields": [{"name": "value", "type": "string", "pii": false}]
+        }}}
+      ]
+    }}},
+    {"name": "previous", "type": ["null", "Order"], "default": null}
+  ]
+}`
+
+func TestCodecProps(t *testing.T) {
+	c, err := NewCodec(testPropsSchema)
+	ensureError(t, err)
+
+	if got, want := c.Props(), map[string]interface{}{"doc": "An order.", "connect.name": "com.acme.Order"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	c, err = NewCodec(`{"type":"enum","name":"Status","symbols":["OPEN"],"default":"OPEN","doc":"Whether it is open."}`)
+	ensureError(t, err)
+	if got, want := c.Props(), map[string]interface{}{"doc": "Whether it is open."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	c, err = NewCodec(`{"type":"fixed","name":"Id","size":4,"encoding":"hex"}`)
+	ensureError(t, err)
+	if got, want := c.Props(), map[string]interface{}{"encoding": "hex"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	c, err = NewCodec(`{"type":"fixed","name":"Price","size":8,"logicalType":"decimal","precision":18,"scale":2,"currency":"EUR"}`)
+	ensureError(t, err)
+	if got, want := c.Props(), map[string]interface{}{"currency": "EUR"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	c, err = NewCodec(`{"type":"fixed","name":"Wait","size":12,"logicalType":"duration"}`)
+	ensureError(t, err)
+	if got, want := c.Props(), map[string]interface{}{}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	c, err = NewCodec(`{"type":"array","items":"string","java-class":"java.util.List"}`)
+	ensureError(t, err)
+	if got := c.Props(); got != nil {
+		t.Errorf("GOT: %v; WANT: %v", got, nil)
+	}
+}
+
+func TestCodecFieldProps(t *testing.T) {
+	c, err := NewCodec(testPropsSchema)
+	ensureError(t, err)
+
+	cases := []struct {
+		path string
+		want map[string]interface{}
+	}{
+		{"id", map[string]interface{}{}},
+		{"customer", map[string]interface{}{"java-class": "com.acme.Customer"}},
+		{"customer.name", map[string]interface{}{}},
+		{"customer.ssn", map[string]interface{}{"doc": "Social security number.", "pii": true}},
+		{"lines.attributes.value", map[string]interface{}{"pii": false}},
+		{"previous.customer.ssn", map[string]interface{}{"doc": "Social security number.", "pii": true}},
+	}
+	for _, tc := range cases {
+		got, err := c.FieldProps(tc.path)
+		ensureError(t, err)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: GOT: %v; WANT: %v", tc.path, got, tc.want)
+		}
+	}
+
+	_, err = c.FieldProps("missing")
+	ensureError(t, err, `cannot find field "missing"`)
+	_, err = c.FieldProps("customer.missing")
+	ensureError(t, err, `"customer.missing" ought to be a record field`)
+	_, err = c.FieldProps("id.value")
+	ensureError(t, err, `"id.value" ought to be a record field`)
+	_, err = c.FieldProps("")
+	ensureError(t, err, "ought to be a record field")
+}
+
+func TestCodecFieldPropsOfNamedTypes(t *testing.T) {
+	types := NewNamedTypes()
+	ensureError(t, types.Add(
+		`{"type":"record","name":"com.acme.Customer","fields":[{"name":"ssn","type":"string","pii":true}]}`,
+		`{"type":"record","name":"com.acme.Order","fields":[{"name":"customer","type":"com.acme.Customer"}]}`,
+	))
+	c, err := types.Codec("com.acme.Order")
+	ensureError(t, err)
+	props, err := c.FieldProps("customer.ssn")
+	ensureError(t, err)
+	if got, want := props, map[string]interface{}{"pii": true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
@@ -0,0 +1,952 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Protocol is an Avro protocol, which declares named types, and messages whose
+// requests are made of values of those types, and which are answered by
+// responses or errors. A Protocol is parsed from the JSON of an Avro protocol
+// by ParseProtocol, or from Avro IDL by ParseIDL.
+type Protocol struct {
+	name      string
+	namespace string
+	doc       string
+	props     jsonObject
+
+	types    []*protocolSchema          // named types, in the order they are declared
+	named    map[string]*protocolSchema // named types by full name
+	messages []*Message
+
+	codecs map[string]*Codec // codecs of the named types by full name, which share a symbol table
+	md5    [md5.Size]byte
+}
+
+// Message is a message of an Avro protocol.
+type Message struct {
+	name     string
+	doc      string
+	props    jsonObject
+	request  []*protocolField
+	response *protocolSchema
+	errors   []*protocolSchema
+	oneWay   bool
+
+	requestCodec  *Codec
+	responseCodec *Codec
+	errorsCodec   *Codec
+}
+
+// ParseProtocol returns the protocol declared by the JSON of an Avro protocol,
+// such as the contents of an .avpr file:
+//
+//     {
+//       "protocol": "Shop",
+//       "namespace": "com.example",
+//       "types": [
+//         {"type": "record", "name": "Customer", "fields": [{"name": "name", "type": "string"}]},
+//         {"type": "error", "name": "NotFound", "fields": [{"name": "message", "type": "string"}]}
+//       ],
+//       "messages": {
+//         "lookup": {
+//           "request": [{"name": "name", "type": "string"}],
+//           "response": "Customer",
+//           "errors": ["NotFound"]
+//         }
+//       }
+//     }
+func ParseProtocol(protocolSpecification string) (*Protocol, error) {
+	v, err := parseOrderedJSON([]byte(protocolSpecification))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse protocol: %s", err)
+	}
+	p, err := protocolFromJSON(v)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.resolve(); err != nil {
+		return nil, fmt.Errorf("cannot parse protocol %q: %s", p.name, err)
+	}
+	if err = p.compile(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// protocolSchema is a schema of a protocol. Unlike the parsed JSON from which
+// codecs are built, it keeps the order of its attributes, its documentation
+// and its other properties, and its named type references, so that it is
+// written the same way Avro's own implementation writes it.
+type protocolSchema struct {
+	typ string // a primitive type name, "array", "map", "union", "record", "error", "enum", "fixed", or "" for a reference
+
+	fullName string           // of named types
+	doc      string           // of named types
+	aliases  []string         // full names, of named types
+	props    jsonObject       // other attributes, such as logicalType
+	fields   []*protocolField // of records and errors
+	symbols  []string         // of enums
+	enumDflt string           // of enums, or the empty string when none
+	size     int              // of fixed
+	items    *protocolSchema  // of arrays, or values of maps
+	branches []*protocolSchema
+
+	// ref is the name of the type a reference refers to, resolved against
+	// refNamespace, and resolved is that type.
+	ref          string
+	refNamespace string
+	resolved     *protocolSchema
+}
+
+// protocolField is a field of a record, or a parameter of a message.
+type protocolField struct {
+	name       string
+	doc        string
+	typ        *protocolSchema
+	dflt       interface{}
+	hasDefault bool
+	order      string // "ascending" when empty
+	aliases    []string
+	props      jsonObject
+}
+
+func newProtocol() *Protocol {
+	return &Protocol{named: make(map[string]*protocolSchema)}
+}
+
+// Name returns the name of the protocol, without its namespace.
+func (p *Protocol) Name() string { return p.name }
+
+// Namespace returns the namespace of the protocol.
+func (p *Protocol) Namespace() string { return p.namespace }
+
+// Doc returns the documentation of the protocol.
+func (p *Protocol) Doc() string { return p.doc }
+
+// TypeNames returns the full names of the named types of the protocol, in the
+// order they are declared.
+func (p *Protocol) TypeNames() []string {
+	names := make([]string, len(p.types))
+	for i, t := range p.types {
+		names[i] = t.fullName
+	}
+	return names
+}
+
+// Messages returns the messages of the protocol, in the order they are
+// declared.
+func (p *Protocol) Messages() []*Message {
+	return append([]*Message(nil), p.messages...)
+}
+
+// Message returns the message with the given name, or nil when the protocol
+// has no such message.
+func (p *Protocol) Message(name string) *Message {
+	for _, m := range p.messages {
+		if m.name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// lookupType returns the named type with the given full name, or with the
+// given name in the namespace of the protocol.
+func (p *Protocol) lookupType(typeName string) (*protocolSchema, error) {
+	if t, ok := p.named[typeName]; ok {
+		return t, nil
+	}
+	if p.namespace != "" {
+		if t, ok := p.named[p.namespace+"."+typeName]; ok {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot find type %q in protocol %q", typeName, p.name)
+}
+
+// Codec returns the codec of the named type, which is either a full name, or a
+// name in the namespace of the protocol. The codecs of the named types of a
+// protocol share a symbol table, like those of the named types of a single
+// schema do.
+func (p *Protocol) Codec(typeName string) (*Codec, error) {
+	t, err := p.lookupType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	return p.codecs[t.fullName], nil
+}
+
+// MD5 returns the MD5 hash of the compact JSON declaration of the protocol,
+// by which the Avro RPC handshake identifies it.
+func (p *Protocol) MD5() []byte {
+	return append([]byte(nil), p.md5[:]...)
+}
+
+// Schema returns the JSON schema of the named type, in which the types it
+// refers to are declared too, formatted the way the idl2schemata command of
+// avro-tools writes it to a file named after the type.
+func (p *Protocol) Schema(typeName string) (string, error) {
+	t, err := p.lookupType(typeName)
+	if err != nil {
+		return "", err
+	}
+	w := newSchemaWriter(true, nullNamespace)
+	w.schema(t)
+	return w.String(), nil
+}
+
+// JSON returns the JSON declaration of the protocol, formatted the way the idl
+// command of avro-tools writes it.
+func (p *Protocol) JSON() string {
+	w := newSchemaWriter(true, p.namespace)
+	p.write(w)
+	return w.String()
+}
+
+// String returns the compact JSON declaration of the protocol.
+func (p *Protocol) String() string {
+	w := newSchemaWriter(false, p.namespace)
+	p.write(w)
+	return w.String()
+}
+
+func (p *Protocol) write(w *schemaWriter) {
+	w.startObject()
+	w.stringMember("protocol", p.name)
+	if p.namespace != "" {
+		w.stringMember("namespace", p.namespace)
+	}
+	if p.doc != "" {
+		w.stringMember("doc", p.doc)
+	}
+	w.members(p.props)
+	w.key("types")
+	w.startArray()
+	for _, t := range p.types {
+		if !w.known[t.fullName] {
+			w.element()
+			w.schema(t)
+		}
+	}
+	w.endArray()
+	w.key("messages")
+	w.startObject()
+	for _, m := range p.messages {
+		w.key(m.name)
+		m.write(w)
+	}
+	w.endObject()
+	w.endObject()
+}
+
+// Name returns the name of the message.
+func (m *Message) Name() string { return m.name }
+
+// Doc returns the documentation of the message.
+func (m *Message) Doc() string { return m.doc }
+
+// OneWay returns true when the message has no response.
+func (m *Message) OneWay() bool { return m.oneWay }
+
+// Request returns the codec of the request of the message, which is a record
+// whose fields are the parameters of the message.
+func (m *Message) Request() *Codec { return m.requestCodec }
+
+// Response returns the codec of the response of the message, which is the null
+// codec for one-way messages.
+func (m *Message) Response() *Codec { return m.responseCodec }
+
+// Errors returns the codec of the errors of the message, which is a union of
+// "string", for errors that are not declared, such as those of the RPC system
+// itself, and of the errors the message declares.
+func (m *Message) Errors() *Codec { return m.errorsCodec }
+
+func (m *Message) write(w *schemaWriter) {
+	w.startObject()
+	if m.doc != "" {
+		w.stringMember("doc", m.doc)
+	}
+	w.members(m.props)
+	w.key("request")
+	w.fields(m.request)
+	if m.oneWay {
+		w.stringMember("response", "null")
+		w.key("one-way")
+		w.value(true)
+	} else {
+		w.key("response")
+		w.schema(m.response)
+		if len(m.errors) > 0 {
+			w.key("errors")
+			w.startArray()
+			for _, e := range m.errors {
+				w.element()
+				w.schema(e)
+			}
+			w.endArray()
+		}
+	}
+	w.endObject()
+}
+
+// resolve resolves every named type reference of the protocol, and returns an
+// error when one refers to a type the protocol does not declare.
+func (p *Protocol) resolve() error {
+	var resolveSchema func(s *protocolSchema) error
+	resolveSchema = func(s *protocolSchema) error {
+		switch s.typ {
+		case "":
+			if s.resolved != nil {
+				return nil
+			}
+			candidates := []string{s.ref}
+			if s.refNamespace != nullNamespace && !strings.ContainsRune(s.ref, '.') {
+				candidates = []string{s.refNamespace + "." + s.ref, s.ref}
+			}
+			for _, candidate := range candidates {
+				if t, ok := p.named[candidate]; ok {
+					s.resolved = t
+					return nil
+				}
+			}
+			return fmt.Errorf("cannot resolve type %q: not declared", candidates[0])
+		case "array", "map":
+			return resolveSchema(s.items)
+		case "union":
+			for _, b := range s.branches {
+				if err := resolveSchema(b); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	resolveFields := func(fields []*protocolField) error {
+		for _, f := range fields {
+			if err := resolveSchema(f.typ); err != nil {
+				return fmt.Errorf("field %q: %s", f.name, err)
+			}
+		}
+		return nil
+	}
+
+	for _, t := range p.types {
+		if err := resolveFields(t.fields); err != nil {
+			return fmt.Errorf("cannot resolve %q: %s", t.fullName, err)
+		}
+	}
+	for _, m := range p.messages {
+		if err := resolveFields(m.request); err != nil {
+			return fmt.Errorf("cannot resolve message %q: %s", m.name, err)
+		}
+		if err := resolveSchema(m.response); err != nil {
+			return fmt.Errorf("cannot resolve message %q response: %s", m.name, err)
+		}
+		for _, e := range m.errors {
+			if err := resolveSchema(e); err != nil {
+				return fmt.Errorf("cannot resolve message %q errors: %s", m.name, err)
+			}
+			if t := e.resolved; t == nil || t.typ != "error" {
+				return fmt.Errorf("cannot resolve message %q errors: %q ought to be an error", m.name, e.ref)
+			}
+		}
+	}
+	return nil
+}
+
+// compile builds the codecs of the named types and of the messages of the
+// resolved protocol, and computes its MD5 hash.
+func (p *Protocol) compile() error {
+	st := newSymbolTable()
+	p.codecs = make(map[string]*Codec, len(p.types))
+
+	// NOTE: Each named type is declared in the schema from which its codec is
+	// built where it first appears, and is referred to by name afterwards, so
+	// that it is only built once.
+	w := newSchemaWriter(false, nullNamespace)
+	build := func(s *protocolSchema, st map[string]*Codec) (*Codec, error) {
+		w.jsonWriter = jsonWriter{}
+		w.schema(s)
+		var schema interface{}
+		if err := json.Unmarshal([]byte(w.String()), &schema); err != nil {
+			return nil, err // should not get here because the writer writes valid JSON
+		}
+		return buildCodec(st, nullNamespace, schema)
+	}
+
+	for _, t := range p.types {
+		if !w.known[t.fullName] {
+			if _, err := build(t, st); err != nil {
+				return fmt.Errorf("cannot build codec for %q: %s", t.fullName, err)
+			}
+		}
+		c := st[t.fullName]
+		standalone, _ := p.Schema(t.fullName)
+		var schema interface{}
+		if err := json.Unmarshal([]byte(standalone), &schema); err != nil {
+			return fmt.Errorf("cannot build codec for %q: %s", t.fullName, err)
+		}
+		canonical, err := parsingCanonicalForm(schema)
+		if err != nil {
+			return fmt.Errorf("cannot build codec for %q: %s", t.fullName, err)
+		}
+		c.schemaOriginal, c.schemaCanonical = standalone, canonical
+		p.codecs[t.fullName] = c
+	}
+
+	for _, m := range p.messages {
+		// NOTE: The request record is anonymous in Avro's own implementation.
+		// Here it is named after the message, in a copy of the symbol table,
+		// so that its name cannot collide with a named type.
+		request := &protocolSchema{typ: "record", fullName: m.name, fields: m.request}
+		requestSymbolTable := make(map[string]*Codec, len(st))
+		for k, v := range st {
+			requestSymbolTable[k] = v
+		}
+		delete(requestSymbolTable, m.name)
+		known := w.known[m.name]
+		w.known[m.name] = false
+		var err error
+		if m.requestCodec, err = build(request, requestSymbolTable); err != nil {
+			return fmt.Errorf("cannot build codec for message %q request: %s", m.name, err)
+		}
+		w.known[m.name] = known
+		if m.responseCodec, err = build(m.response, st); err != nil {
+			return fmt.Errorf("cannot build codec for message %q response: %s", m.name, err)
+		}
+		errors := &protocolSchema{typ: "union", branches: append([]*protocolSchema{{typ: "string"}}, m.errors...)}
+		if m.errorsCodec, err = build(errors, st); err != nil {
+			return fmt.Errorf("cannot build codec for message %q errors: %s", m.name, err)
+		}
+	}
+
+	p.md5 = md5.Sum([]byte(p.String()))
+	return nil
+}
+
+// define adds the named type to the protocol, and returns an error when the
+// protocol already declares another type by that name.
+func (p *Protocol) define(t *protocolSchema) error {
+	if _, err := newName(t.fullName, nullNamespace, nullNamespace); err != nil {
+		return fmt.Errorf("cannot declare %s %q: %s", t.typ, t.fullName, err)
+	}
+	if _, ok := p.named[t.fullName]; ok {
+		return fmt.Errorf("cannot declare %s %q: already declared", t.typ, t.fullName)
+	}
+	p.named[t.fullName] = t
+	p.types = append(p.types, t)
+	return nil
+}
+
+// merge adds the types and messages of other, imported, protocol to p. Types
+// declared by both are not declared again.
+func (p *Protocol) merge(other *Protocol) error {
+	for _, t := range other.types {
+		if existing, ok := p.named[t.fullName]; ok && existing == t {
+			continue
+		}
+		if err := p.define(t); err != nil {
+			return err
+		}
+	}
+	for _, m := range other.messages {
+		if p.Message(m.name) != nil {
+			return fmt.Errorf("cannot declare message %q: already declared", m.name)
+		}
+		p.messages = append(p.messages, m)
+	}
+	return nil
+}
+
+// namespaceOf returns the namespace of a full name.
+func namespaceOf(fullName string) string {
+	if i := strings.LastIndexByte(fullName, '.'); i >= 0 {
+		return fullName[:i]
+	}
+	return nullNamespace
+}
+
+// shortNameOf returns a full name without its namespace.
+func shortNameOf(fullName string) string {
+	return fullName[strings.LastIndexByte(fullName, '.')+1:]
+}
+
+// schemaWriter writes schemas as JSON the way Avro's own implementation does:
+// each named type is declared where it first appears and referred to by name
+// afterwards, and names are written relative to the namespace of their
+// enclosing named type.
+type schemaWriter struct {
+	jsonWriter
+	known map[string]bool
+	space string
+}
+
+func newSchemaWriter(pretty bool, space string) *schemaWriter {
+	return &schemaWriter{jsonWriter: jsonWriter{pretty: pretty}, known: make(map[string]bool), space: space}
+}
+
+// relativeName returns the full name, without its namespace when that is the
+// namespace of the enclosing named type.
+func relativeName(fullName, space string) string {
+	if ns := namespaceOf(fullName); ns == nullNamespace || ns == space {
+		return shortNameOf(fullName)
+	}
+	return fullName
+}
+
+func (w *schemaWriter) schema(s *protocolSchema) {
+	if s.typ == "" {
+		s = s.resolved
+	}
+	switch s.typ {
+	case "record", "error", "enum", "fixed":
+		if w.known[s.fullName] {
+			w.value(relativeName(s.fullName, w.space))
+			return
+		}
+		w.known[s.fullName] = true
+		w.startObject()
+		w.stringMember("type", s.typ)
+		w.stringMember("name", shortNameOf(s.fullName))
+		if ns := namespaceOf(s.fullName); ns != w.space {
+			w.stringMember("namespace", ns)
+		}
+		if s.doc != "" {
+			w.stringMember("doc", s.doc)
+		}
+		switch s.typ {
+		case "enum":
+			w.key("symbols")
+			w.startArray()
+			for _, symbol := range s.symbols {
+				w.element()
+				w.value(symbol)
+			}
+			w.endArray()
+			if s.enumDflt != "" {
+				w.stringMember("default", s.enumDflt)
+			}
+		case "fixed":
+			w.key("size")
+			w.rawValue(fmt.Sprint(s.size))
+		default:
+			space := w.space
+			w.space = namespaceOf(s.fullName)
+			w.key("fields")
+			w.fields(s.fields)
+			w.space = space
+		}
+		w.members(s.props)
+		if len(s.aliases) > 0 {
+			w.key("aliases")
+			w.startArray()
+			for _, alias := range s.aliases {
+				w.element()
+				w.value(relativeName(alias, namespaceOf(s.fullName)))
+			}
+			w.endArray()
+		}
+		w.endObject()
+	case "array", "map":
+		w.startObject()
+		w.stringMember("type", s.typ)
+		if s.typ == "array" {
+			w.key("items")
+		} else {
+			w.key("values")
+		}
+		w.schema(s.items)
+		w.members(s.props)
+		w.endObject()
+	case "union":
+		w.startArray()
+		for _, b := range s.branches {
+			w.element()
+			w.schema(b)
+		}
+		w.endArray()
+	default:
+		if len(s.props) == 0 {
+			w.value(s.typ)
+			return
+		}
+		w.startObject()
+		w.stringMember("type", s.typ)
+		w.members(s.props)
+		w.endObject()
+	}
+}
+
+func (w *schemaWriter) fields(fields []*protocolField) {
+	w.startArray()
+	for _, f := range fields {
+		w.element()
+		w.startObject()
+		w.stringMember("name", f.name)
+		w.key("type")
+		w.schema(f.typ)
+		if f.doc != "" {
+			w.stringMember("doc", f.doc)
+		}
+		if f.hasDefault {
+			w.key("default")
+			w.value(f.dflt)
+		}
+		if f.order != "" && f.order != "ascending" {
+			w.stringMember("order", f.order)
+		}
+		if len(f.aliases) > 0 {
+			w.key("aliases")
+			w.startArray()
+			for _, alias := range f.aliases {
+				w.element()
+				w.value(alias)
+			}
+			w.endArray()
+		}
+		w.members(f.props)
+		w.endObject()
+	}
+	w.endArray()
+}
+
+// protocolFromJSON returns the protocol declared by the parsed JSON of a
+// protocol, whose named type references are not yet resolved.
+func protocolFromJSON(v interface{}) (*Protocol, error) {
+	o, ok := v.(jsonObject)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse protocol: ought to be JSON object; received: %T", v)
+	}
+	p := newProtocol()
+	var types, messages interface{}
+	for _, m := range o {
+		switch m.key {
+		case "protocol":
+			p.name, ok = m.value.(string)
+		case "namespace":
+			p.namespace, ok = m.value.(string)
+		case "doc":
+			p.doc, ok = m.value.(string)
+		case "types":
+			types = m.value
+		case "messages":
+			messages = m.value
+		default:
+			p.props = append(p.props, m)
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot parse protocol: %q ought to be string; received: %T", m.key, m.value)
+		}
+	}
+	if p.name == "" {
+		return nil, fmt.Errorf("cannot parse protocol: ought to have non-empty protocol name")
+	}
+	if types != nil {
+		typeList, ok := types.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot parse protocol %q: types ought to be array; received: %T", p.name, types)
+		}
+		for _, t := range typeList {
+			if _, err := p.schemaFromJSON(p.namespace, t); err != nil {
+				return nil, fmt.Errorf("cannot parse protocol %q: %s", p.name, err)
+			}
+		}
+	}
+	if messages != nil {
+		messageObject, ok := messages.(jsonObject)
+		if !ok {
+			return nil, fmt.Errorf("cannot parse protocol %q: messages ought to be object; received: %T", p.name, messages)
+		}
+		for _, m := range messageObject {
+			message, err := p.messageFromJSON(m.key, m.value)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse protocol %q: %s", p.name, err)
+			}
+			p.messages = append(p.messages, message)
+		}
+	}
+	return p, nil
+}
+
+func (p *Protocol) messageFromJSON(name string, v interface{}) (*Message, error) {
+	o, ok := v.(jsonObject)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse message %q: ought to be JSON object; received: %T", name, v)
+	}
+	m := &Message{name: name}
+	var err error
+	for _, member := range o {
+		switch member.key {
+		case "doc":
+			if m.doc, ok = member.value.(string); !ok {
+				return nil, fmt.Errorf("cannot parse message %q: doc ought to be string; received: %T", name, member.value)
+			}
+		case "request":
+			if m.request, err = p.fieldsFromJSON(p.namespace, member.value); err != nil {
+				return nil, fmt.Errorf("cannot parse message %q request: %s", name, err)
+			}
+		case "response":
+			if m.response, err = p.schemaFromJSON(p.namespace, member.value); err != nil {
+				return nil, fmt.Errorf("cannot parse message %q response: %s", name, err)
+			}
+		case "errors":
+			errs, ok := member.value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("cannot parse message %q: errors ought to be array; received: %T", name, member.value)
+			}
+			for _, e := range errs {
+				s, err := p.schemaFromJSON(p.namespace, e)
+				if err != nil {
+					return nil, fmt.Errorf("cannot parse message %q errors: %s", name, err)
+				}
+				m.errors = append(m.errors, s)
+			}
+		case "one-way":
+			if m.oneWay, ok = member.value.(bool); !ok {
+				return nil, fmt.Errorf("cannot parse message %q: one-way ought to be boolean; received: %T", name, member.value)
+			}
+		default:
+			m.props = append(m.props, member)
+		}
+	}
+	if m.response == nil {
+		return nil, fmt.Errorf("cannot parse message %q: ought to have response", name)
+	}
+	if m.oneWay && (m.response.typ != "null" || len(m.errors) > 0) {
+		return nil, fmt.Errorf("cannot parse message %q: one-way message ought to have null response and no errors", name)
+	}
+	return m, nil
+}
+
+// schemaFromJSON returns the schema described by parsed JSON, declaring each
+// named type it declares in the protocol. Named type references are resolved
+// against namespace when the protocol is resolved.
+func (p *Protocol) schemaFromJSON(namespace string, v interface{}) (*protocolSchema, error) {
+	switch t := v.(type) {
+	case string:
+		switch t {
+		case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+			return &protocolSchema{typ: t}, nil
+		}
+		return &protocolSchema{ref: t, refNamespace: namespace}, nil
+	case []interface{}:
+		s := &protocolSchema{typ: "union"}
+		for _, member := range t {
+			b, err := p.schemaFromJSON(namespace, member)
+			if err != nil {
+				return nil, err
+			}
+			s.branches = append(s.branches, b)
+		}
+		return s, nil
+	case jsonObject:
+		typeValue, _ := t.get("type")
+		typeName, ok := typeValue.(string)
+		if !ok {
+			if len(t) == 1 {
+				// NOTE: Like the codec builder, accept a schema wrapped in an
+				// object, such as {"type":["null","int"]}.
+				return p.schemaFromJSON(namespace, typeValue)
+			}
+			return nil, fmt.Errorf("cannot parse schema: type ought to be string; received: %T", typeValue)
+		}
+		switch typeName {
+		case "record", "error", "enum", "fixed":
+			return p.namedSchemaFromJSON(namespace, typeName, t)
+		case "array", "map":
+			s := &protocolSchema{typ: typeName}
+			key := "items"
+			if typeName == "map" {
+				key = "values"
+			}
+			for _, m := range t {
+				switch m.key {
+				case "type":
+				case key:
+					items, err := p.schemaFromJSON(namespace, m.value)
+					if err != nil {
+						return nil, err
+					}
+					s.items = items
+				default:
+					s.props = append(s.props, m)
+				}
+			}
+			if s.items == nil {
+				return nil, fmt.Errorf("cannot parse %s schema: ought to have %s", typeName, key)
+			}
+			return s, nil
+		case "null", "boolean", "int", "long", "float", "double", "bytes", "string":
+			s := &protocolSchema{typ: typeName}
+			for _, m := range t {
+				if m.key != "type" {
+					s.props = append(s.props, m)
+				}
+			}
+			return s, nil
+		}
+		if len(t) == 1 {
+			return p.schemaFromJSON(namespace, typeName)
+		}
+		return nil, fmt.Errorf("cannot parse schema: unknown type: %q", typeName)
+	}
+	return nil, fmt.Errorf("cannot parse schema: ought to be string, array or object; received: %T", v)
+}
+
+func (p *Protocol) namedSchemaFromJSON(namespace, typeName string, o jsonObject) (*protocolSchema, error) {
+	s := &protocolSchema{typ: typeName}
+	name, _ := o.get("name")
+	nameString, ok := name.(string)
+	if !ok || nameString == "" {
+		return nil, fmt.Errorf("cannot parse %s schema: name ought to be non-empty string; received: %T: %v", typeName, name, name)
+	}
+	if ns, ok := o.get("namespace"); ok {
+		if namespace, ok = ns.(string); !ok {
+			return nil, fmt.Errorf("cannot parse %s schema %q: namespace ought to be string; received: %T", typeName, nameString, ns)
+		}
+	}
+	s.fullName = nameString
+	if namespace != nullNamespace && !strings.ContainsRune(nameString, '.') {
+		s.fullName = namespace + "." + nameString
+	}
+	namespace = namespaceOf(s.fullName)
+	if err := p.define(s); err != nil {
+		return nil, err
+	}
+
+	var err error
+	for _, m := range o {
+		switch m.key {
+		case "type", "name", "namespace":
+		case "doc":
+			if s.doc, ok = m.value.(string); !ok {
+				return nil, fmt.Errorf("cannot parse %s schema %q: doc ought to be string; received: %T", typeName, s.fullName, m.value)
+			}
+		case "aliases":
+			aliases, err := stringsFromJSON(m.value)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse %s schema %q aliases: %s", typeName, s.fullName, err)
+			}
+			for _, alias := range aliases {
+				if namespace != nullNamespace && !strings.ContainsRune(alias, '.') {
+					alias = namespace + "." + alias
+				}
+				s.aliases = append(s.aliases, alias)
+			}
+		case "fields":
+			if typeName != "record" && typeName != "error" {
+				s.props = append(s.props, m)
+				continue
+			}
+			if s.fields, err = p.fieldsFromJSON(namespace, m.value); err != nil {
+				return nil, fmt.Errorf("cannot parse %s schema %q: %s", typeName, s.fullName, err)
+			}
+		case "symbols":
+			if typeName != "enum" {
+				s.props = append(s.props, m)
+				continue
+			}
+			if s.symbols, err = stringsFromJSON(m.value); err != nil {
+				return nil, fmt.Errorf("cannot parse enum schema %q symbols: %s", s.fullName, err)
+			}
+		case "default":
+			if typeName != "enum" {
+				s.props = append(s.props, m)
+				continue
+			}
+			if s.enumDflt, ok = m.value.(string); !ok {
+				return nil, fmt.Errorf("cannot parse enum schema %q: default ought to be string; received: %T", s.fullName, m.value)
+			}
+		case "size":
+			if typeName != "fixed" {
+				s.props = append(s.props, m)
+				continue
+			}
+			n, ok := m.value.(json.Number)
+			size, err := n.Int64()
+			if !ok || err != nil || size < 0 {
+				return nil, fmt.Errorf("cannot parse fixed schema %q: size ought to be non-negative integer; received: %v", s.fullName, m.value)
+			}
+			s.size = int(size)
+		default:
+			s.props = append(s.props, m)
+		}
+	}
+	return s, nil
+}
+
+func (p *Protocol) fieldsFromJSON(namespace string, v interface{}) ([]*protocolField, error) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("fields ought to be array; received: %T", v)
+	}
+	fields := make([]*protocolField, 0, len(list))
+	for i, fv := range list {
+		o, ok := fv.(jsonObject)
+		if !ok {
+			return nil, fmt.Errorf("field %d ought to be JSON object; received: %T", i+1, fv)
+		}
+		f := &protocolField{}
+		name, _ := o.get("name")
+		if f.name, ok = name.(string); !ok || f.name == "" {
+			return nil, fmt.Errorf("field %d name ought to be non-empty string; received: %T: %v", i+1, name, name)
+		}
+		var err error
+		for _, m := range o {
+			switch m.key {
+			case "name":
+			case "type":
+				if f.typ, err = p.schemaFromJSON(namespace, m.value); err != nil {
+					return nil, fmt.Errorf("field %q: %s", f.name, err)
+				}
+			case "doc":
+				if f.doc, ok = m.value.(string); !ok {
+					return nil, fmt.Errorf("field %q doc ought to be string; received: %T", f.name, m.value)
+				}
+			case "default":
+				f.dflt, f.hasDefault = m.value, true
+			case "order":
+				if f.order, ok = m.value.(string); !ok {
+					return nil, fmt.Errorf("field %q order ought to be string; received: %T", f.name, m.value)
+				}
+			case "aliases":
+				if f.aliases, err = stringsFromJSON(m.value); err != nil {
+					return nil, fmt.Errorf("field %q aliases: %s", f.name, err)
+				}
+			default:
+				f.props = append(f.props, m)
+			}
+		}
+		if f.typ == nil {
+			return nil, fmt.Errorf("field %q ought to have type", f.name)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func stringsFromJSON(v interface{}) ([]string, error) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("ought to be array of strings; received: %T", v)
+	}
+	strs := make([]string, len(list))
+	for i, e := range list {
+		if strs[i], ok = e.(string); !ok {
+			return nil, fmt.Errorf("ought to be array of strings; received: %T", e)
+		}
+	}
+	return strs, nil
+}
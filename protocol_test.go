@@ -0,0 +1,194 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"bytes"
+	"crypto/md5"
+	"reflect"
+	"testing"
+)
+
+const testShopProtocol = `{
+  "protocol": "Shop",
+  "namespace": "com.example",
+  "doc": "Sells things.",
+  "types": [
+    {"type": "enum", "name": "Kind", "symbols": ["REGULAR", "GOLD"]},
+    {"type": "record", "name": "Customer", "fields": [
+      {"name": "name", "type": "string"},
+      {"name": "kind", "type": "Kind", "default": "REGULAR"},
+      {"name": "address", "type": {"type": "record", "name": "Address", "namespace": "com.example.post", "fields": [
+        {"name": "city", "type": "string"}
+      ]}}
+    ]},
+    {"type": "error", "name": "NotFound", "fields": [{"name": "message", "type": "string"}]}
+  ],
+  "messages": {
+    "lookup": {
+      "doc": "Finds a customer.",
+      "request": [{"name": "name", "type": "string"}, {"name": "limit", "type": "int", "default": 10}],
+      "response": "Customer",
+      "errors": ["NotFound"]
+    },
+    "move": {
+      "request": [{"name": "name", "type": "string"}, {"name": "to", "type": "com.example.post.Address"}],
+      "response": "null",
+      "one-way": true
+    }
+  }
+}`
+
+func TestParseProtocol(t *testing.T) {
+	p, err := ParseProtocol(testShopProtocol)
+	ensureError(t, err)
+
+	if got, want := p.Name(), "Shop"; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := p.Doc(), "Sells things."; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := p.TypeNames(), []string{"com.example.Kind", "com.example.Customer", "com.example.post.Address", "com.example.NotFound"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	want := `{"protocol":"Shop","namespace":"com.example","doc":"Sells things.","types":[{"type":"enum","name":"Kind","symbols":["REGULAR","GOLD"]},{"type":"record","name":"Customer","fields":[{"name":"name","type":"string"},{"name":"kind","type":"Kind","default":"REGULAR"},{"name":"address","type":{"type":"record","name":"Address","namespace":"com.example.post","fields":[{"name":"city","type":"string"}]}}]},{"type":"error","name":"NotFound","fields":[{"name":"message","type":"string"}]}],"messages":{"lookup":{"doc":"Finds a customer.","request":[{"name":"name","type":"string"},{"name":"limit","type":"int","default":10}],"response":"Customer","errors":["NotFound"]},"move":{"request":[{"name":"name","type":"string"},{"name":"to","type":"com.example.post.Address"}],"response":"null","one-way":true}}}`
+	if got := p.String(); got != want {
+		t.Errorf("GOT:\n%s\nWANT:\n%s", got, want)
+	}
+	if got, want := p.MD5(), md5.Sum([]byte(want)); !bytes.Equal(got, want[:]) {
+		t.Errorf("GOT: %x; WANT: %x", got, want)
+	}
+}
+
+func TestProtocolCodecs(t *testing.T) {
+	p, err := ParseProtocol(testShopProtocol)
+	ensureError(t, err)
+
+	customer, err := p.Codec("Customer")
+	ensureError(t, err)
+	address, err := p.Codec("com.example.post.Address")
+	ensureError(t, err)
+
+	if got, want := customer.Schema(), mustSchema(t, p, "Customer"); got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := address.CanonicalSchema(), `{"name":"com.example.post.Address","type":"record","fields":[{"name":"city","type":"string"}]}`; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	buf, err := customer.BinaryFromNative(nil, map[string]interface{}{
+		"name":    "Ann",
+		"kind":    "GOLD",
+		"address": map[string]interface{}{"city": "Oslo"},
+	})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x06Ann\x02\x08Oslo"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+
+	_, err = p.Codec("Missing")
+	ensureError(t, err, "cannot find type")
+}
+
+func TestProtocolMessageCodecs(t *testing.T) {
+	p, err := ParseProtocol(testShopProtocol)
+	ensureError(t, err)
+
+	lookup := p.Message("lookup")
+	buf, err := lookup.Request().BinaryFromNative(nil, map[string]interface{}{"name": "Ann"})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x06Ann\x14"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+
+	buf, err = lookup.Response().BinaryFromNative(nil, map[string]interface{}{
+		"name":    "Ann",
+		"address": map[string]interface{}{"city": "Oslo"},
+	})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x06Ann\x00\x08Oslo"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+
+	buf, err = lookup.Errors().BinaryFromNative(nil, Union("com.example.NotFound", map[string]interface{}{"message": "gone"}))
+	ensureError(t, err)
+	if got, want := buf, []byte("\x02\x08gone"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+	buf, err = lookup.Errors().BinaryFromNative(nil, Union("string", "unavailable"))
+	ensureError(t, err)
+	if got, want := buf, []byte("\x00\x16unavailable"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+
+	move := p.Message("move")
+	if !move.OneWay() {
+		t.Errorf("GOT: %v; WANT: %v", move.OneWay(), true)
+	}
+	buf, err = move.Request().BinaryFromNative(nil, map[string]interface{}{
+		"name": "Ann",
+		"to":   map[string]interface{}{"city": "Rome"},
+	})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x06Ann\x08Rome"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+	if got, want := move.Response().Schema(), "null"; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestProtocolMessageNamedLikeType(t *testing.T) {
+	p, err := ParseProtocol(`{"protocol":"P","types":[{"type":"fixed","name":"echo","size":2}],"messages":{"echo":{"request":[{"name":"e","type":"long"}],"response":"echo"}}}`)
+	ensureError(t, err)
+
+	buf, err := p.Message("echo").Request().BinaryFromNative(nil, map[string]interface{}{"e": int64(1)})
+	ensureError(t, err)
+	if got, want := buf, []byte("\x02"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+	buf, err = p.Message("echo").Response().BinaryFromNative(nil, []byte("hi"))
+	ensureError(t, err)
+	if got, want := buf, []byte("hi"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+}
+
+func TestParseProtocolErrors(t *testing.T) {
+	cases := []struct {
+		src  string
+		want string
+	}{
+		{`[]`, "ought to be JSON object"},
+		{`{"protocol":"P",}`, "cannot parse protocol"},
+		{`{"namespace":"n"}`, "protocol name"},
+		{`{"protocol":"P","types":[{"type":"record","name":"R","fields":[{"name":"f","type":"Missing"}]}]}`, `"Missing": not declared`},
+		{`{"protocol":"P","types":[{"type":"fixed","name":"F","size":1},{"type":"fixed","name":"F","size":2}]}`, "already declared"},
+		{`{"protocol":"P","messages":{"m":{"request":[]}}}`, "ought to have response"},
+		{`{"protocol":"P","messages":{"m":{"request":[],"response":"int","one-way":true}}}`, "one-way"},
+		{`{"protocol":"P","types":[{"type":"record","name":"R","fields":[]}],"messages":{"m":{"request":[],"response":"null","errors":["R"]}}}`, "ought to be an error"},
+		{`{"protocol":"P","types":[{"type":"enum","name":"E","symbols":["A"],"default":"B"}]}`, "cannot build codec"},
+	}
+	for _, c := range cases {
+		_, err := ParseProtocol(c.src)
+		ensureError(t, err, c.want)
+	}
+}
+
+func mustSchema(t *testing.T, p *Protocol, typeName string) string {
+	t.Helper()
+	schema, err := p.Schema(typeName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
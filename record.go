@@ -34,6 +34,7 @@ func makeRecordCodec(st map[string]*Codec, enclosingNamespace string, schemaMap
 	codecFromIndex := make([]*Codec, len(fieldSchemas))
 	nameFromIndex := make([]string, len(fieldSchemas))
 	defaultValueFromName := make(map[string]interface{}, len(fieldSchemas))
+	binaryDefaultFromIndex := make([][]byte, len(fieldSchemas)) // used by generated code
 
 	for i, fieldSchema := range fieldSchemas {
 		fieldSchemaMap, ok := fieldSchema.(map[string]interface{})
@@ -74,7 +75,7 @@ func makeRecordCodec(st map[string]*Codec, enclosingNamespace string, schemaMap
 				defaultValue = Union(fieldCodec.schemaOriginal, defaultValue)
 			}
 			// attempt to encode default value using codec
-			_, err = fieldCodec.binaryFromNative(nil, defaultValue)
+			binaryDefaultFromIndex[i], err = fieldCodec.binaryFromNative(nil, defaultValue)
 			if err != nil {
 				return nil, fmt.Errorf("Record %q field %q: default value ought to encode using field schema: %s", c.typeName, fieldName, err)
 			}
@@ -88,7 +89,9 @@ func makeRecordCodec(st map[string]*Codec, enclosingNamespace string, schemaMap
 
 	recordTypeName, _ := newNameFromSchemaMap(enclosingNamespace, schemaMap)
 	// Can ignore the error here because it would have been caught above
-	c.generator = NewRecordCodecGenerator(recordTypeName, codecFromIndex, nameFromIndex)
+	c.generator = NewRecordCodecGenerator(recordTypeName, codecFromIndex, nameFromIndex, binaryDefaultFromIndex)
+	c.generator.schema, c.generator.enclosingNamespace = schemaMap, enclosingNamespace
+	c.fieldCodecs = codecFromFieldName
 
 	c.binaryFromNative = func(buf []byte, datum interface{}) ([]byte, error) {
 		valueMap, ok := datum.(map[string]interface{})
@@ -134,6 +137,32 @@ func makeRecordCodec(st map[string]*Codec, enclosingNamespace string, schemaMap
 		return recordMap, buf, nil
 	}
 
+	c.nativeFromBinaryReuse = func(buf []byte, datum interface{}) (interface{}, []byte, error) {
+		recordMap, ok := datum.(map[string]interface{})
+		if !ok || recordMap == nil {
+			return c.nativeFromBinary(buf)
+		}
+		for i, fieldCodec := range codecFromIndex {
+			name := nameFromIndex[i]
+			var value interface{}
+			var err error
+			value, buf, err = fieldCodec.nativeFromBinaryReusing(buf, recordMap[name])
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot decode binary record %q field %q: %s", c.typeName, name, err)
+			}
+			recordMap[name] = value
+		}
+		if len(recordMap) != len(codecFromIndex) {
+			// discard keys of the recycled map that are not record fields
+			for key := range recordMap {
+				if _, ok := codecFromFieldName[key]; !ok {
+					delete(recordMap, key)
+				}
+			}
+		}
+		return recordMap, buf, nil
+	}
+
 	c.nativeFromTextual = func(buf []byte) (interface{}, []byte, error) {
 		var mapValues map[string]interface{}
 		var err error
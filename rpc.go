@@ -0,0 +1,518 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+const (
+	rpcHandshakeRequestSchema = `{"type":"record","name":"HandshakeRequest","namespace":"org.apache.avro.ipc","fields":[
+{"name":"clientHash","type":{"type":"fixed","name":"MD5","size":16}},
+{"name":"clientProtocol","type":["null","string"]},
+{"name":"serverHash","type":"MD5"},
+{"name":"meta","type":["null",{"type":"map","values":"bytes"}]}]}`
+	rpcHandshakeResponseSchema = `{"type":"record","name":"HandshakeResponse","namespace":"org.apache.avro.ipc","fields":[
+{"name":"match","type":{"type":"enum","name":"HandshakeMatch","symbols":["BOTH","CLIENT","NONE"]}},
+{"name":"serverProtocol","type":["null","string"]},
+{"name":"serverHash","type":["null",{"type":"fixed","name":"MD5","size":16}]},
+{"name":"meta","type":["null",{"type":"map","values":"bytes"}]}]}`
+	rpcMD5Name = "org.apache.avro.ipc.MD5"
+
+	// maxRPCClientProtocols is the number of client protocols a server
+	// caches, so that clients cannot make it grow without bound.
+	maxRPCClientProtocols = 1024
+)
+
+var (
+	rpcHandshakeRequestCodec  *Codec
+	rpcHandshakeResponseCodec *Codec
+)
+
+func init() {
+	rpcHandshakeRequestCodec, _ = NewCodec(rpcHandshakeRequestSchema)
+	rpcHandshakeResponseCodec, _ = NewCodec(rpcHandshakeResponseSchema)
+}
+
+// ErrRemote is the error returned by RPCClient.Call when the server responds
+// to a message with an error, and which an RPCHandler returns to respond with
+// one of the errors the message declares.
+type ErrRemote struct {
+	// Name is the full name of the error type the message declares, or
+	// "string" for errors that are not declared, such as those of the RPC
+	// system itself.
+	Name string
+
+	// Value is the value of the error, which is a string when Name is
+	// "string".
+	Value interface{}
+}
+
+func (e ErrRemote) Error() string {
+	if e.Name == "string" {
+		return fmt.Sprintf("remote error: %v", e.Value)
+	}
+	return fmt.Sprintf("remote error: %s: %v", e.Name, e.Value)
+}
+
+// RPCHandler handles a request of an Avro RPC message, whose parameters are
+// the fields of the request record, and returns the response of the message.
+// A handler returns an ErrRemote to respond with an error the message
+// declares. Any other error is sent to the client as a string.
+type RPCHandler func(request map[string]interface{}) (interface{}, error)
+
+// RPCServer responds to Avro RPC messages of a protocol sent over stateful
+// connections, such as TCP sockets, by dispatching them to handlers.
+//
+// The server caches the protocol of each client by its MD5 hash, so that a
+// client need only send it once, up to maxRPCClientProtocols protocols, after
+// which clients with other protocols send them on each connection. Because goavro does not resolve schemas, the
+// request, response and errors of a message ought to be the same in the
+// protocols of the client and the server.
+type RPCServer struct {
+	protocol  *Protocol
+	lock      sync.RWMutex
+	handlers  map[string]RPCHandler
+	protocols map[[md5.Size]byte]*Protocol // client protocols by hash
+}
+
+// NewRPCServer returns a server responding to the messages of the protocol.
+func NewRPCServer(protocol *Protocol) *RPCServer {
+	return &RPCServer{
+		protocol:  protocol,
+		handlers:  make(map[string]RPCHandler),
+		protocols: make(map[[md5.Size]byte]*Protocol),
+	}
+}
+
+// Handle sets the handler of the named message of the protocol of the server.
+func (s *RPCServer) Handle(messageName string, handler RPCHandler) error {
+	if s.protocol.Message(messageName) == nil {
+		return fmt.Errorf("cannot handle message %q: not declared by protocol %q", messageName, s.protocol.name)
+	}
+	s.lock.Lock()
+	s.handlers[messageName] = handler
+	s.lock.Unlock()
+	return nil
+}
+
+// Serve accepts connections from the listener, and serves each of them in its
+// own goroutine, until accepting a connection fails.
+func (s *RPCServer) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			_ = s.ServeConn(conn)
+			_ = conn.Close()
+		}()
+	}
+}
+
+// ServeConn responds to the messages sent over the connection until the client
+// closes it, and returns nil, or until reading a message from, or writing a
+// response to, the connection fails, and returns that error.
+func (s *RPCServer) ServeConn(conn io.ReadWriter) error {
+	var remote *Protocol // the protocol of the client, once the handshake is done
+	for {
+		request, err := readRPCFrame(conn)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var response []byte
+		if response, remote, err = s.respond(request, remote); err != nil {
+			return err
+		}
+		if response == nil {
+			continue // response to a one-way message
+		}
+		if err = writeRPCFrame(conn, response); err != nil {
+			return err
+		}
+	}
+}
+
+// respond returns the response to a request sent over a connection, whose
+// client protocol is remote when the handshake is already done, or nil when
+// the request starts with a handshake.
+func (s *RPCServer) respond(buf []byte, remote *Protocol) ([]byte, *Protocol, error) {
+	var response []byte
+	wasConnected := remote != nil
+	if !wasConnected {
+		var err error
+		if response, remote, buf, err = s.handshake(buf); err != nil {
+			return nil, nil, err
+		}
+		if remote == nil {
+			return response, nil, nil // the client will send its protocol
+		}
+	}
+
+	_, buf, err := ocfMetadataCodec.NativeFromBinary(buf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read RPC request metadata: %s", err)
+	}
+	value, buf, err := stringNativeFromBinary(buf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read RPC request message name: %s", err)
+	}
+	messageName := value.(string)
+	if messageName == "" {
+		return response, remote, nil // a handshake without a message
+	}
+
+	// NOTE: The client does not read responses to one-way messages sent after
+	// the handshake, not even errors.
+	rm := remote.Message(messageName)
+	oneWay := wasConnected && rm != nil && rm.OneWay()
+	response, err = ocfMetadataCodec.BinaryFromNative(response, map[string]interface{}{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("should not get here: cannot write RPC response metadata: %s", err)
+	}
+	if response = s.dispatch(response, messageName, rm, buf); oneWay {
+		response = nil
+	}
+	return response, remote, nil
+}
+
+// dispatch appends to the response, whose metadata is already written, the
+// response of the handler of the message, or an error.
+func (s *RPCServer) dispatch(response []byte, messageName string, rm *Message, buf []byte) []byte {
+	m := s.protocol.Message(messageName)
+	if m == nil {
+		return rpcSystemError(response, "cannot respond to message %q: not declared by protocol %q", messageName, s.protocol.name)
+	}
+	if !sameRPCMessages(m, rm) {
+		return rpcSystemError(response, "cannot respond to message %q: client and server declare it differently", messageName)
+	}
+	request, _, err := m.Request().NativeFromBinary(buf)
+	if err != nil {
+		return rpcSystemError(response, "cannot respond to message %q: %s", messageName, err)
+	}
+	s.lock.RLock()
+	handler := s.handlers[messageName]
+	s.lock.RUnlock()
+	if handler == nil {
+		return rpcSystemError(response, "cannot respond to message %q: no handler", messageName)
+	}
+
+	datum, err := handler(request.(map[string]interface{}))
+	if err != nil {
+		var e ErrRemote
+		switch t := err.(type) {
+		case ErrRemote:
+			e = t
+		case *ErrRemote:
+			e = *t
+		default:
+			return rpcSystemError(response, "%s", err)
+		}
+		buf, err := m.Errors().BinaryFromNative(append(response, 1), Union(e.Name, e.Value))
+		if err != nil {
+			return rpcSystemError(response, "cannot respond to message %q with error: %s", messageName, err)
+		}
+		return buf
+	}
+	buf, err = m.Response().BinaryFromNative(append(response, 0), datum)
+	if err != nil {
+		return rpcSystemError(response, "cannot respond to message %q: %s", messageName, err)
+	}
+	return buf
+}
+
+// rpcSystemError appends an error that the message does not declare to the
+// response, whose metadata is already written.
+func rpcSystemError(response []byte, format string, a ...interface{}) []byte {
+	response = append(response, 1, 0) // true, and the "string" branch of the errors union
+	response, _ = stringBinaryFromNative(response, fmt.Sprintf(format, a...))
+	return response
+}
+
+// handshake reads the handshake request at the start of buf, and returns the
+// handshake response, the protocol of the client, which is nil when the server
+// does not know it, and the rest of buf.
+func (s *RPCServer) handshake(buf []byte) ([]byte, *Protocol, []byte, error) {
+	value, buf, err := rpcHandshakeRequestCodec.NativeFromBinary(buf)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot read RPC handshake request: %s", err)
+	}
+	request := value.(map[string]interface{})
+	var clientHash [md5.Size]byte
+	copy(clientHash[:], request["clientHash"].([]byte))
+
+	s.lock.RLock()
+	remote := s.protocols[clientHash]
+	s.lock.RUnlock()
+	if remote == nil {
+		if clientProtocol, ok := request["clientProtocol"].(map[string]interface{}); ok {
+			text := clientProtocol["string"].(string)
+			// NOTE: The hash is checked before the protocol is cached, so that
+			// a client cannot replace the protocol of another client.
+			if md5.Sum([]byte(text)) != clientHash {
+				return nil, nil, nil, errors.New("cannot read RPC handshake request: client protocol does not match client hash")
+			}
+			if remote, err = ParseProtocol(text); err != nil {
+				return nil, nil, nil, fmt.Errorf("cannot read RPC handshake request: client %s", err)
+			}
+			s.lock.Lock()
+			if len(s.protocols) < maxRPCClientProtocols {
+				s.protocols[clientHash] = remote
+			}
+			s.lock.Unlock()
+		}
+	}
+
+	response := map[string]interface{}{"serverProtocol": nil, "serverHash": nil, "meta": nil}
+	switch {
+	case remote != nil && bytes.Equal(request["serverHash"].([]byte), s.protocol.md5[:]):
+		response["match"] = "BOTH"
+	case remote != nil:
+		response["match"] = "CLIENT"
+	default:
+		response["match"] = "NONE"
+	}
+	if response["match"] != "BOTH" {
+		response["serverProtocol"] = Union("string", s.protocol.String())
+		response["serverHash"] = Union(rpcMD5Name, s.protocol.md5[:])
+	}
+	out, err := rpcHandshakeResponseCodec.BinaryFromNative(nil, response)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("should not get here: cannot write RPC handshake response: %s", err)
+	}
+	return out, remote, buf, nil
+}
+
+// RPCClient sends Avro RPC messages of a protocol over a stateful connection,
+// such as a TCP socket, and returns the responses of the server. The client
+// does the handshake with the server before sending its first message, and
+// only sends its protocol when the server does not already know it. Calls are
+// serialized, so that a client may be used by multiple goroutines.
+type RPCClient struct {
+	protocol *Protocol
+	conn     io.ReadWriter
+
+	lock         sync.Mutex
+	established  bool           // whether the handshake is done
+	sendProtocol bool           // whether the next handshake sends the protocol
+	remote       *Protocol      // the protocol of the server
+	remoteHash   [md5.Size]byte // the hash of the protocol of the server
+}
+
+// NewRPCClient returns a client sending the messages of the protocol over the
+// connection. Until the server tells otherwise, the client assumes the server
+// has the same protocol.
+func NewRPCClient(protocol *Protocol, conn io.ReadWriter) *RPCClient {
+	return &RPCClient{protocol: protocol, conn: conn, remote: protocol, remoteHash: protocol.md5}
+}
+
+// Call sends the named message with the request, whose keys are the names of
+// the parameters of the message, and returns the response of the server. When
+// the server responds with an error, that error is returned as an ErrRemote.
+// One-way messages return a nil response as soon as they are sent.
+//
+//     response, err := client.Call("lookup", map[string]interface{}{"name": "Ann"})
+//     if e, ok := err.(goavro.ErrRemote); ok && e.Name == "com.example.NotFound" {
+//         // the server declined the request
+//     }
+func (c *RPCClient) Call(messageName string, request map[string]interface{}) (interface{}, error) {
+	m := c.protocol.Message(messageName)
+	if m == nil {
+		return nil, fmt.Errorf("cannot call message %q: not declared by protocol %q", messageName, c.protocol.name)
+	}
+	body, err := ocfMetadataCodec.BinaryFromNative(nil, map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("should not get here: cannot write RPC request metadata: %s", err)
+	}
+	body, _ = stringBinaryFromNative(body, messageName)
+	if body, err = m.Request().BinaryFromNative(body, request); err != nil {
+		return nil, fmt.Errorf("cannot call message %q: %s", messageName, err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var buf []byte
+	for attempt := 0; ; attempt++ {
+		wasConnected := c.established
+		buf = nil
+		if !wasConnected {
+			if buf, err = c.handshakeRequest(); err != nil {
+				return nil, err
+			}
+		}
+		if err = writeRPCFrame(c.conn, append(buf, body...)); err != nil {
+			return nil, fmt.Errorf("cannot call message %q: %s", messageName, err)
+		}
+		if m.OneWay() && wasConnected {
+			return nil, nil
+		}
+		if buf, err = readRPCFrame(c.conn); err != nil {
+			return nil, fmt.Errorf("cannot call message %q: %s", messageName, err)
+		}
+		if wasConnected {
+			break
+		}
+		if buf, err = c.handshakeResponse(buf); err != nil {
+			return nil, err
+		}
+		if c.established {
+			break
+		}
+		if attempt > 0 {
+			return nil, fmt.Errorf("cannot call message %q: server does not accept client protocol", messageName)
+		}
+	}
+
+	if !sameRPCMessages(m, c.remote.Message(messageName)) {
+		return nil, fmt.Errorf("cannot call message %q: client and server declare it differently", messageName)
+	}
+	if _, buf, err = ocfMetadataCodec.NativeFromBinary(buf); err != nil {
+		return nil, fmt.Errorf("cannot read RPC response metadata: %s", err)
+	}
+	value, buf, err := booleanNativeFromBinary(buf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read RPC response: %s", err)
+	}
+	if value.(bool) {
+		value, _, err = m.Errors().NativeFromBinary(buf)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read RPC response error: %s", err)
+		}
+		for name, value := range value.(map[string]interface{}) {
+			return nil, ErrRemote{Name: name, Value: value}
+		}
+	}
+	if m.OneWay() {
+		return nil, nil
+	}
+	if value, _, err = m.Response().NativeFromBinary(buf); err != nil {
+		return nil, fmt.Errorf("cannot read RPC response: %s", err)
+	}
+	return value, nil
+}
+
+func (c *RPCClient) handshakeRequest() ([]byte, error) {
+	request := map[string]interface{}{
+		"clientHash":     c.protocol.md5[:],
+		"clientProtocol": nil,
+		"serverHash":     c.remoteHash[:],
+		"meta":           nil,
+	}
+	if c.sendProtocol {
+		request["clientProtocol"] = Union("string", c.protocol.String())
+	}
+	buf, err := rpcHandshakeRequestCodec.BinaryFromNative(nil, request)
+	if err != nil {
+		return nil, fmt.Errorf("should not get here: cannot write RPC handshake request: %s", err)
+	}
+	return buf, nil
+}
+
+// handshakeResponse reads the handshake response at the start of buf, and
+// returns the rest of buf.
+func (c *RPCClient) handshakeResponse(buf []byte) ([]byte, error) {
+	value, buf, err := rpcHandshakeResponseCodec.NativeFromBinary(buf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read RPC handshake response: %s", err)
+	}
+	response := value.(map[string]interface{})
+	if serverProtocol, ok := response["serverProtocol"].(map[string]interface{}); ok {
+		remote, err := ParseProtocol(serverProtocol["string"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read RPC handshake response: server %s", err)
+		}
+		c.remote, c.remoteHash = remote, remote.md5
+		if serverHash, ok := response["serverHash"].(map[string]interface{}); ok {
+			copy(c.remoteHash[:], serverHash[rpcMD5Name].([]byte))
+		}
+	}
+	switch response["match"] {
+	case "BOTH", "CLIENT":
+		c.established, c.sendProtocol = true, false
+	default:
+		c.sendProtocol = true
+	}
+	return buf, nil
+}
+
+// sameRPCMessages returns true when the local and remote messages have the
+// same request, response and errors.
+func sameRPCMessages(local, remote *Message) bool {
+	if remote == nil {
+		return false
+	}
+	if local == remote {
+		return true
+	}
+	return local.oneWay == remote.oneWay &&
+		local.requestCodec.schemaCanonical == remote.requestCodec.schemaCanonical &&
+		local.responseCodec.schemaCanonical == remote.responseCodec.schemaCanonical &&
+		local.errorsCodec.schemaCanonical == remote.errorsCodec.schemaCanonical
+}
+
+// writeRPCFrame writes an Avro RPC message, which is framed as a list of
+// buffers, each preceded by its big-endian 32-bit length, and terminated by an
+// empty buffer.
+func writeRPCFrame(w io.Writer, message []byte) error {
+	buf := make([]byte, 0, len(message)+8)
+	if len(message) > 0 {
+		buf = append(buf, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(buf, uint32(len(message)))
+		buf = append(buf, message...)
+	}
+	buf = append(buf, 0, 0, 0, 0)
+	_, err := w.Write(buf)
+	return err
+}
+
+// readRPCFrame reads an Avro RPC message written by writeRPCFrame, and returns
+// io.EOF when the connection is closed before the message starts.
+func readRPCFrame(r io.Reader) ([]byte, error) {
+	var message bytes.Buffer
+	var header [4]byte
+	for buffers := 0; ; buffers++ {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if err == io.EOF && buffers == 0 {
+				return nil, err
+			}
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("cannot read RPC buffer: %s", err)
+		}
+		size := int64(binary.BigEndian.Uint32(header[:]))
+		if size == 0 {
+			return message.Bytes(), nil
+		}
+		if total := int64(message.Len()) + size; total > MaxBlockSize {
+			return nil, fmt.Errorf("cannot read RPC buffer: size exceeds MaxBlockSize: %d > %d", total, MaxBlockSize)
+		}
+		// NOTE: Rather than allocating the size the peer claims up front, the
+		// message grows as its bytes arrive.
+		if _, err := io.CopyN(&message, r, size); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("cannot read RPC buffer: %s", err)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright [2019] LinkedIn Corp. Licensed under the Apache License, Version
+// 2.0 (the "License"); you may not use this file except in compliance with the
+// License.  You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+
+package goavro
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+// testRPCServer returns a server of the shop protocol, which knows a single
+// customer, and whose move handler sends the requests it receives to moved.
+func testRPCServer(t *testing.T, moved chan<- map[string]interface{}) *RPCServer {
+	t.Helper()
+	p, err := ParseProtocol(testShopProtocol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewRPCServer(p)
+	ensureError(t, s.Handle("lookup", func(request map[string]interface{}) (interface{}, error) {
+		switch request["name"] {
+		case "Ann":
+			return map[string]interface{}{
+				"name":    "Ann",
+				"kind":    "GOLD",
+				"address": map[string]interface{}{"city": "Oslo"},
+			}, nil
+		case "crash":
+			return nil, errors.New("database unavailable")
+		}
+		return nil, ErrRemote{Name: "com.example.NotFound", Value: map[string]interface{}{"message": "no such customer"}}
+	}))
+	ensureError(t, s.Handle("move", func(request map[string]interface{}) (interface{}, error) {
+		moved <- request
+		return nil, nil
+	}))
+	ensureError(t, s.Handle("missing", nil), "not declared")
+	return s
+}
+
+// testRPCConn returns the client end of a connection to the server, which is
+// served until the client end is closed.
+func testRPCConn(t *testing.T, s *RPCServer) (net.Conn, <-chan error) {
+	t.Helper()
+	client, server := net.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- s.ServeConn(server)
+		server.Close()
+	}()
+	return client, done
+}
+
+func TestRPCCall(t *testing.T) {
+	moved := make(chan map[string]interface{}, 1)
+	s := testRPCServer(t, moved)
+	conn, done := testRPCConn(t, s)
+
+	c := NewRPCClient(s.protocol, conn)
+	response, err := c.Call("lookup", map[string]interface{}{"name": "Ann"})
+	ensureError(t, err)
+	want := map[string]interface{}{
+		"name":    "Ann",
+		"kind":    "GOLD",
+		"address": map[string]interface{}{"city": "Oslo"},
+	}
+	if !reflect.DeepEqual(response, want) {
+		t.Errorf("GOT: %v; WANT: %v", response, want)
+	}
+
+	_, err = c.Call("lookup", map[string]interface{}{"name": "Bob"})
+	if got, want := err, (ErrRemote{Name: "com.example.NotFound", Value: map[string]interface{}{"message": "no such customer"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	_, err = c.Call("lookup", map[string]interface{}{"name": "crash"})
+	if got, want := err, (ErrRemote{Name: "string", Value: "database unavailable"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	response, err = c.Call("move", map[string]interface{}{"name": "Ann", "to": map[string]interface{}{"city": "Rome"}})
+	ensureError(t, err)
+	if response != nil {
+		t.Errorf("GOT: %v; WANT: %v", response, nil)
+	}
+	if got, want := <-moved, (map[string]interface{}{"name": "Ann", "to": map[string]interface{}{"city": "Rome"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	// NOTE: The server sends nothing back for the one-way message, so the
+	// next response is that of the next call.
+	response, err = c.Call("lookup", map[string]interface{}{"name": "Ann"})
+	ensureError(t, err)
+	if !reflect.DeepEqual(response, want) {
+		t.Errorf("GOT: %v; WANT: %v", response, want)
+	}
+
+	_, err = c.Call("missing", nil)
+	ensureError(t, err, "not declared")
+	_, err = c.Call("lookup", map[string]interface{}{})
+	ensureError(t, err, "cannot call message \"lookup\"")
+
+	conn.Close()
+	ensureError(t, <-done)
+}
+
+func TestRPCHandshake(t *testing.T) {
+	moved := make(chan map[string]interface{}, 1)
+	s := testRPCServer(t, moved)
+
+	// A client whose protocol differs from that of the server, but declares
+	// the lookup message the same way.
+	p, err := ParseProtocol(`{"protocol":"Client","namespace":"com.example","types":[
+  {"type":"enum","name":"Kind","symbols":["REGULAR","GOLD"]},
+  {"type":"record","name":"Customer","fields":[
+    {"name":"name","type":"string"},
+    {"name":"kind","type":"Kind","default":"REGULAR"},
+    {"name":"address","type":{"type":"record","name":"Address","namespace":"com.example.post","fields":[{"name":"city","type":"string"}]}}]},
+  {"type":"error","name":"NotFound","fields":[{"name":"message","type":"string"}]}],
+"messages":{
+  "lookup":{"request":[{"name":"name","type":"string"},{"name":"limit","type":"int","default":10}],"response":"Customer","errors":["NotFound"]},
+  "unknown":{"request":[],"response":"null"}}}`)
+	ensureError(t, err)
+
+	for i := 0; i < 2; i++ {
+		conn, done := testRPCConn(t, s)
+		c := NewRPCClient(p, conn)
+		response, err := c.Call("lookup", map[string]interface{}{"name": "Ann"})
+		ensureError(t, err)
+		if got, want := response.(map[string]interface{})["name"], "Ann"; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		// NOTE: The server sent its protocol during the handshake.
+		if got, want := c.remote.Name(), "Shop"; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := c.remoteHash[:], s.protocol.MD5(); !bytes.Equal(got, want) {
+			t.Errorf("GOT: %x; WANT: %x", got, want)
+		}
+		// NOTE: The first client sends its protocol after the server does not
+		// recognize its hash, and the second does not, because the server
+		// caches it.
+		if got, want := len(s.protocols), 1; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+
+		_, err = c.Call("unknown", map[string]interface{}{})
+		ensureError(t, err, "cannot call message \"unknown\"", "declare it differently")
+
+		conn.Close()
+		ensureError(t, <-done)
+	}
+}
+
+func TestRPCServerUndeclaredMessage(t *testing.T) {
+	s := NewRPCServer(mustParseProtocol(t, `{"protocol":"P","messages":{"a":{"request":[],"response":"int"}}}`))
+	conn, done := testRPCConn(t, s)
+
+	// NOTE: The client declares the message the server does not.
+	c := NewRPCClient(mustParseProtocol(t, `{"protocol":"P","messages":{"b":{"request":[],"response":"int"}}}`), conn)
+	_, err := c.Call("b", map[string]interface{}{})
+	ensureError(t, err, "cannot call message \"b\"", "declare it differently")
+
+	conn.Close()
+	ensureError(t, <-done)
+
+	conn, done = testRPCConn(t, s)
+	c = NewRPCClient(s.protocol, conn)
+	_, err = c.Call("a", map[string]interface{}{})
+	if got, want := err, (ErrRemote{Name: "string", Value: `cannot respond to message "a": no handler`}); !reflect.DeepEqual(got, want) {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	conn.Close()
+	ensureError(t, <-done)
+}
+
+func TestRPCServeConnHandshakeError(t *testing.T) {
+	s := NewRPCServer(mustParseProtocol(t, `{"protocol":"P","messages":{}}`))
+	conn, done := testRPCConn(t, s)
+	go func() {
+		_ = writeRPCFrame(conn, []byte("\x01"))
+	}()
+	ensureError(t, <-done, "cannot read RPC handshake request")
+	conn.Close()
+}
+
+func TestRPCServerHandshakeClientHash(t *testing.T) {
+	s := NewRPCServer(mustParseProtocol(t, `{"protocol":"P","messages":{"a":{"request":[],"response":"int"}}}`))
+
+	// NOTE: The client sends a protocol whose hash differs from the one it
+	// claims, which the server does not cache.
+	other := mustParseProtocol(t, `{"protocol":"Q","messages":{}}`)
+	request, err := rpcHandshakeRequestCodec.BinaryFromNative(nil, map[string]interface{}{
+		"clientHash":     s.protocol.MD5(),
+		"clientProtocol": Union("string", other.String()),
+		"serverHash":     s.protocol.MD5(),
+		"meta":           nil,
+	})
+	ensureError(t, err)
+	_, _, _, err = s.handshake(request)
+	ensureError(t, err, "cannot read RPC handshake request", "does not match client hash")
+	if got, want := len(s.protocols), 0; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestRPCServerCachesBoundedClientProtocols(t *testing.T) {
+	s := NewRPCServer(mustParseProtocol(t, `{"protocol":"P","messages":{"a":{"request":[],"response":"int"}}}`))
+	ensureError(t, s.Handle("a", func(map[string]interface{}) (interface{}, error) { return int32(3), nil }))
+	for i := 0; i < maxRPCClientProtocols; i++ {
+		var hash [md5.Size]byte
+		binary.BigEndian.PutUint32(hash[:], uint32(i))
+		s.protocols[hash] = s.protocol
+	}
+
+	// NOTE: Once the cache is full, the server still responds to clients
+	// whose protocols it does not know, but does not cache them.
+	conn, done := testRPCConn(t, s)
+	c := NewRPCClient(mustParseProtocol(t, `{"protocol":"P","doc":"client","messages":{"a":{"request":[],"response":"int"}}}`), conn)
+	response, err := c.Call("a", map[string]interface{}{})
+	ensureError(t, err)
+	if got, want := response, int32(3); got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := len(s.protocols), maxRPCClientProtocols; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	conn.Close()
+	ensureError(t, <-done)
+}
+
+func TestRPCFrame(t *testing.T) {
+	buf := new(bytes.Buffer)
+	ensureError(t, writeRPCFrame(buf, []byte("hello")))
+	ensureError(t, writeRPCFrame(buf, nil))
+	if got, want := buf.Bytes(), []byte("\x00\x00\x00\x05hello\x00\x00\x00\x00\x00\x00\x00\x00"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+
+	// NOTE: A message may be split in several buffers.
+	r := bytes.NewReader([]byte("\x00\x00\x00\x02he\x00\x00\x00\x03llo\x00\x00\x00\x00\x00\x00\x00\x00"))
+	message, err := readRPCFrame(r)
+	ensureError(t, err)
+	if got, want := message, []byte("hello"); !bytes.Equal(got, want) {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+	message, err = readRPCFrame(r)
+	ensureError(t, err)
+	if got, want := len(message), 0; got != want {
+		t.Errorf("GOT: %#v; WANT: %#v", got, want)
+	}
+	if _, err = readRPCFrame(r); err != io.EOF {
+		t.Errorf("GOT: %v; WANT: %v", err, io.EOF)
+	}
+
+	_, err = readRPCFrame(bytes.NewReader([]byte("\x00\x00\x00\x02he")))
+	ensureError(t, err, "cannot read RPC buffer", "unexpected EOF")
+	_, err = readRPCFrame(bytes.NewReader([]byte("\x00\x00\x00\x05he")))
+	ensureError(t, err, "cannot read RPC buffer", "unexpected EOF")
+	_, err = readRPCFrame(bytes.NewReader([]byte("\xff\xff\xff\xff")))
+	ensureError(t, err, "exceeds MaxBlockSize")
+}
+
+func mustParseProtocol(t *testing.T, src string) *Protocol {
+	t.Helper()
+	p, err := ParseProtocol(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
@@ -43,6 +43,10 @@ func Union(name string, datum interface{}) interface{} {
 	return map[string]interface{}{name: datum}
 }
 
+// unionSymbolTablePrefix prefixes the symbol table keys of unions that have
+// their own generated types.
+const unionSymbolTablePrefix = "union:"
+
 func buildCodecForTypeDescribedBySlice(st map[string]*Codec, enclosingNamespace string, schemaArray []interface{}) (*Codec, error) {
 	if len(schemaArray) == 0 {
 		return nil, errors.New("Union ought to have one or more members")
@@ -68,13 +72,52 @@ func buildCodecForTypeDescribedBySlice(st map[string]*Codec, enclosingNamespace
 		indexFromName[fullName] = i
 	}
 
+	// unionNativeFromBinary decodes a binary union, recycling the provided datum
+	// when it is a previously decoded value of the same member type.
+	unionNativeFromBinary := func(buf []byte, datum interface{}) (interface{}, []byte, error) {
+		var decoded interface{}
+		var err error
+
+		decoded, buf, err = longNativeFromBinary(buf)
+		if err != nil {
+			return nil, nil, err
+		}
+		index := decoded.(int64) // longDecoder always returns int64, so elide error checking
+		if index < 0 || index >= int64(len(codecFromIndex)) {
+			return nil, nil, fmt.Errorf("cannot decode binary union: index ought to be between 0 and %d; read index: %d", len(codecFromIndex)-1, index)
+		}
+		var recycled interface{}
+		wrapped, ok := datum.(map[string]interface{})
+		if ok && len(wrapped) == 1 {
+			recycled, ok = wrapped[allowedTypes[index]]
+		} else {
+			ok = false
+		}
+		c := codecFromIndex[index]
+		decoded, buf, err = c.nativeFromBinaryReusing(buf, recycled)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot decode binary union item %d: %s", index+1, err)
+		}
+		if decoded == nil {
+			// do not wrap a nil value in a map
+			return nil, buf, nil
+		}
+		if ok {
+			wrapped[allowedTypes[index]] = decoded
+			return wrapped, buf, nil
+		}
+		// Non-nil values are wrapped in a map with single key set to type name of value
+		return Union(allowedTypes[index], decoded), buf, nil
+	}
+
 	generator, err := NewUnionCodecGenerator(codecFromIndex)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create union codec, reason: %s", err)
 	}
 
-	return &Codec{
-		generator: generator,
+	c := &Codec{
+		generator:     generator,
+		elementCodecs: codecFromIndex,
 
 		// NOTE: To support record field default values, union schema set to the
 		// type name of first member
@@ -83,29 +126,9 @@ func buildCodecForTypeDescribedBySlice(st map[string]*Codec, enclosingNamespace
 
 		typeName: &name{"union", nullNamespace},
 		nativeFromBinary: func(buf []byte) (interface{}, []byte, error) {
-			var decoded interface{}
-			var err error
-
-			decoded, buf, err = longNativeFromBinary(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			index := decoded.(int64) // longDecoder always returns int64, so elide error checking
-			if index < 0 || index >= int64(len(codecFromIndex)) {
-				return nil, nil, fmt.Errorf("cannot decode binary union: index ought to be between 0 and %d; read index: %d", len(codecFromIndex)-1, index)
-			}
-			c := codecFromIndex[index]
-			decoded, buf, err = c.nativeFromBinary(buf)
-			if err != nil {
-				return nil, nil, fmt.Errorf("cannot decode binary union item %d: %s", index+1, err)
-			}
-			if decoded == nil {
-				// do not wrap a nil value in a map
-				return nil, buf, nil
-			}
-			// Non-nil values are wrapped in a map with single key set to type name of value
-			return Union(allowedTypes[index], decoded), buf, nil
+			return unionNativeFromBinary(buf, nil)
 		},
+		nativeFromBinaryReuse: unionNativeFromBinary,
 		binaryFromNative: func(buf []byte, datum interface{}) ([]byte, error) {
 			switch v := datum.(type) {
 			case nil:
@@ -182,5 +205,18 @@ func buildCodecForTypeDescribedBySlice(st map[string]*Codec, enclosingNamespace
 			}
 			return nil, fmt.Errorf("cannot encode textual union: non-nil values ought to be specified with Go map[string]interface{}, with single key equal to type name, and value equal to datum value: %v; received: %T", allowedTypes, datum)
 		},
-	}, nil
+	}
+
+	if generator.isWritable {
+		// NOTE: Unions are not named, so the generated type for the union is
+		// stored under a key that cannot be an Avro name, allowing the code
+		// generator to find and write it once for every union with the same
+		// members.
+		key := unionSymbolTablePrefix + generator.goTypeName
+		if _, ok := st[key]; !ok {
+			st[key] = c
+		}
+	}
+
+	return c, nil
 }